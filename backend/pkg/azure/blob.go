@@ -5,4 +5,15 @@ type BlobConfig struct {
 	AccountName   string
 	AccountKey    string
 	ContainerName string
+	// Endpoint overrides the account's blob endpoint, e.g. for Azurite:
+	// http://127.0.0.1:10000/devstoreaccount1
+	Endpoint string
+	// TenantID, ClientID and ClientSecret identify an Azure AD application
+	// to use instead of AccountKey. SAS URLs are then user delegation SAS.
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// SASIPRange optionally restricts SAS URLs to an IP address or range,
+	// e.g. 168.1.5.60-168.1.5.70
+	SASIPRange string
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"strings"
 
@@ -40,6 +41,31 @@ func AuthMiddleware(config auth.TokenConfig) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware sets the same context keys as AuthMiddleware when a
+// valid bearer token is present, and lets anonymous requests through
+func OptionalAuthMiddleware(config auth.TokenConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.Next()
+			return
+		}
+
+		claims, err := auth.ValidateToken(parts[1], config)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+
+		c.Set("claims", claims)
+		c.Set("user_id", claims.UserID)
+		c.Set("profile_id", claims.ProfileID)
+		c.Set("role", claims.Role)
+		c.Next()
+	}
+}
+
 // RequireRole checks if the user has the required role
 func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -62,3 +88,17 @@ func RequireRole(roles ...string) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// InternalAuth guards service-to-service routes with a shared API key sent
+// in the X-Internal-Token header
+func InternalAuth(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("X-Internal-Token")
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid internal token"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
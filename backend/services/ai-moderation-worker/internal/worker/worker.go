@@ -3,20 +3,49 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	"github.com/scouttalent/ai-moderation-worker/internal/moderator"
 	"go.uber.org/zap"
 )
 
-type VideoUploadedEvent struct {
+const (
+	videoProcessedSubject = "media.video.processed"
+	videoModeratedSubject = "media.video.moderated"
+	// consumerName is the durable JetStream consumer on media-service's
+	// MEDIA_VIDEOS stream, shared by every worker replica
+	consumerName = "ai-moderation-processed"
+	// ackWait covers a moderation call; unacknowledged events are
+	// redelivered after it, up to maxDeliver times
+	ackWait    = 2 * time.Minute
+	maxDeliver = 5
+	// awaitingModeration is the media-service status of videos to moderate
+	awaitingModeration = "awaiting_moderation"
+	publishTimeout     = 5 * time.Second
+)
+
+type VideoProcessedEvent struct {
 	EventType string `json:"event_type"`
 	VideoID   string `json:"video_id"`
 	ProfileID string `json:"profile_id"`
 	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// VideoModeratedEvent is the decision media-service applies to the video.
+// Decision is "approved", "rejected" or "failed".
+type VideoModeratedEvent struct {
+	ID        string `json:"id"`
+	EventType string `json:"event_type"`
+	VideoID   string `json:"video_id"`
+	Decision  string `json:"decision"`
+	Reason    string `json:"reason,omitempty"`
 	Timestamp int64  `json:"timestamp"`
 }
 
@@ -25,6 +54,7 @@ type Worker struct {
 	nats       *nats.Conn
 	moderator  *moderator.AIModerator
 	logger     *zap.Logger
+	js         nats.JetStreamContext
 	sub        *nats.Subscription
 }
 
@@ -38,14 +68,27 @@ func NewWorker(db *pgxpool.Pool, nc *nats.Conn, mod *moderator.AIModerator, logg
 }
 
 func (w *Worker) Start(ctx context.Context) error {
-	// Subscribe to video upload events
-	sub, err := w.nats.Subscribe("media.video.uploaded", w.handleVideoUpload)
+	js, err := w.nats.JetStream()
+	if err != nil {
+		return fmt.Errorf("failed to get JetStream context: %w", err)
+	}
+
+	w.js = js
+
+	// Subscribe to video processed events through a durable consumer, so
+	// videos processed while no worker runs are moderated once one does
+	sub, err := js.QueueSubscribe(videoProcessedSubject, consumerName, w.handleVideoProcessed,
+		nats.Durable(consumerName),
+		nats.ManualAck(),
+		nats.AckWait(ackWait),
+		nats.MaxDeliver(maxDeliver),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe to NATS: %w", err)
 	}
 
 	w.sub = sub
-	w.logger.Info("Subscribed to media.video.uploaded events")
+	w.logger.Info("Subscribed to media.video.processed events")
 
 	return nil
 }
@@ -59,12 +102,22 @@ func (w *Worker) Stop() error {
 	return nil
 }
 
-func (w *Worker) handleVideoUpload(msg *nats.Msg) {
-	w.logger.Info("Received video upload event", zap.String("data", string(msg.Data)))
+// handleVideoProcessed moderates a processed video and publishes the
+// decision for media-service to apply; media-service owns the video's
+// status, so the worker never writes it.
+func (w *Worker) handleVideoProcessed(msg *nats.Msg) {
+	w.logger.Info("Received video processed event", zap.String("data", string(msg.Data)))
 
-	var event VideoUploadedEvent
+	var event VideoProcessedEvent
 	if err := json.Unmarshal(msg.Data, &event); err != nil {
 		w.logger.Error("Failed to parse event", zap.Error(err))
+		// Redelivering cannot fix a malformed event
+		msg.Term()
+		return
+	}
+	if event.Status != awaitingModeration {
+		// Processing failed; there is nothing to moderate
+		msg.Ack()
 		return
 	}
 
@@ -72,8 +125,19 @@ func (w *Worker) handleVideoUpload(msg *nats.Msg) {
 
 	// Get video details from database
 	video, err := w.getVideo(ctx, event.VideoID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Deleted since it was processed
+		msg.Ack()
+		return
+	}
 	if err != nil {
 		w.logger.Error("Failed to get video", zap.Error(err))
+		msg.Nak()
+		return
+	}
+	if video.Status != awaitingModeration {
+		// Already decided by an earlier delivery
+		msg.Ack()
 		return
 	}
 
@@ -81,29 +145,88 @@ func (w *Worker) handleVideoUpload(msg *nats.Msg) {
 	result, err := w.moderator.ModerateVideo(ctx, event.VideoID, video.Title, video.Description)
 	if err != nil {
 		w.logger.Error("Failed to moderate video", zap.Error(err))
-		w.updateVideoStatus(ctx, event.VideoID, "failed", "Moderation failed")
+		if !lastDelivery(msg) {
+			msg.Nak()
+			return
+		}
+		// Out of retries; tell media-service so the video does not wait
+		// for moderation forever
+		if err := w.publishDecision(ctx, event.VideoID, "failed", "Moderation failed"); err != nil {
+			w.logger.Error("Failed to publish moderation decision", zap.Error(err))
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+		return
+	}
+
+	// Store moderation result
+	if err := w.storeModerationResult(ctx, event.VideoID, result); err != nil {
+		w.logger.Error("Failed to store moderation result", zap.Error(err))
+	}
+
+	decision := "rejected"
+	if result.Approved {
+		decision = "approved"
+	}
+	if err := w.publishDecision(ctx, event.VideoID, decision, result.Reason); err != nil {
+		w.logger.Error("Failed to publish moderation decision", zap.Error(err))
+		msg.Nak()
 		return
 	}
+	// Events are delivered at least once; acknowledge once handled
+	msg.Ack()
 
-	// Update video status based on moderation result
 	if result.Approved {
-		w.updateVideoStatus(ctx, event.VideoID, "approved", result.Reason)
 		w.logger.Info("Video approved",
 			zap.String("video_id", event.VideoID),
 			zap.Float64("confidence", result.Confidence),
 		)
 	} else {
-		w.updateVideoStatus(ctx, event.VideoID, "rejected", result.Reason)
 		w.logger.Warn("Video rejected",
 			zap.String("video_id", event.VideoID),
 			zap.Strings("flags", result.Flags),
 		)
 	}
+}
 
-	// Store moderation result
-	if err := w.storeModerationResult(ctx, event.VideoID, result); err != nil {
-		w.logger.Error("Failed to store moderation result", zap.Error(err))
+// lastDelivery reports whether JetStream will not redeliver msg again.
+func lastDelivery(msg *nats.Msg) bool {
+	meta, err := msg.Metadata()
+	if err != nil {
+		return true
 	}
+	return meta.NumDelivered >= maxDeliver
+}
+
+// publishDecision publishes the decision on a video to the MEDIA_VIDEOS
+// stream and waits for it to be stored. The event ID is derived from the
+// video so the stream drops a decision published again on redelivery.
+func (w *Worker) publishDecision(ctx context.Context, videoID, decision, reason string) error {
+	event := VideoModeratedEvent{
+		ID:        videoID + ".moderated",
+		EventType: videoModeratedSubject,
+		VideoID:   videoID,
+		Decision:  decision,
+		Reason:    reason,
+		Timestamp: time.Now().Unix(),
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(videoModeratedSubject)
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, event.ID)
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	if _, err := w.js.PublishMsg(msg, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", videoModeratedSubject, err)
+	}
+	return nil
 }
 
 type Video struct {
@@ -127,14 +250,6 @@ func (w *Worker) getVideo(ctx context.Context, videoID string) (*Video, error) {
 	return &video, nil
 }
 
-func (w *Worker) updateVideoStatus(ctx context.Context, videoID, status, reason string) error {
-	_, err := w.db.Exec(ctx,
-		"UPDATE videos SET status = $1, updated_at = $2 WHERE id = $3",
-		status, time.Now(), videoID,
-	)
-	return err
-}
-
 func (w *Worker) storeModerationResult(ctx context.Context, videoID string, result *moderator.ModerationResult) error {
 	resultJSON, err := result.ToJSON()
 	if err != nil {
@@ -56,6 +56,18 @@ func main() {
 	router.GET("/health", h.Health)
 	router.POST("/api/v1/auth/register", h.Register)
 	router.POST("/api/v1/auth/login", h.Login)
+	router.POST("/api/v1/auth/invites/accept", h.AcceptInvite)
+
+	// Internal service-to-service routes
+	if cfg.InternalAPIKey != "" {
+		internal := router.Group("/internal/v1")
+		internal.Use(middleware.InternalAuth(cfg.InternalAPIKey))
+		{
+			internal.POST("/users/invite", h.InviteUser)
+		}
+	} else {
+		logger.Warn("INTERNAL_API_KEY not set, internal routes disabled")
+	}
 
 	// Protected routes
 	protected := router.Group("/api/v1/auth")
@@ -14,6 +14,8 @@ type Config struct {
 	Database      database.Config
 	Redis         RedisConfig
 	JWT           auth.TokenConfig
+
+	InternalAPIKey string
 }
 
 type RedisConfig struct {
@@ -40,6 +42,7 @@ func Load() (*Config, error) {
 			Issuer:               "scouttalent.com",
 			Audience:             []string{"api.scouttalent.com"},
 		},
+		InternalAPIKey: getEnv("INTERNAL_API_KEY", ""),
 	}
 
 	if cfg.Database.URL == "" {
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/auth-service/internal/model"
+	"github.com/scouttalent/auth-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// InviteUser is called by other services on the internal API.
+func (h *AuthHandler) InviteUser(c *gin.Context) {
+	var req model.InviteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.InviteUser(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to invite user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to invite user"})
+		return
+	}
+
+	status := http.StatusOK
+	if result.Created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+func (h *AuthHandler) AcceptInvite(c *gin.Context) {
+	var req model.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.AcceptInvite(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidInvite) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("failed to accept invite", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to accept invite"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":    user,
+		"message": "Invite accepted. You can now log in.",
+	})
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+)
+
+// InviteUserRequest is sent by other services over the internal API to
+// create an account on a user's behalf.
+type InviteUserRequest struct {
+	Email     string `json:"email" binding:"required,email,max=255"`
+	Role      string `json:"role" binding:"required,oneof=player scout academy"`
+	InvitedBy string `json:"invited_by" binding:"required,max=100"`
+}
+
+// InviteResult describes the invited account. Token is only set when a new
+// invite was issued; existing active accounts are returned without one.
+type InviteResult struct {
+	UserID    string     `json:"user_id"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	Status    string     `json:"status"`
+	Created   bool       `json:"created"`
+	Token     string     `json:"invite_token,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type AcceptInviteRequest struct {
+	Token    string `json:"token" binding:"required,len=64"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+type Invite struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	InvitedBy  string     `json:"invited_by" db:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
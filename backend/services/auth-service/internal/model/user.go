@@ -12,7 +12,7 @@ type User struct {
 	PhoneVerified   bool      `json:"phone_verified" db:"phone_verified"`
 	PasswordHash    string    `json:"-" db:"password_hash"`
 	Role            string    `json:"role" db:"role"` // player, scout, academy, admin
-	Status          string    `json:"status" db:"status"` // active, suspended, banned
+	Status          string    `json:"status" db:"status"` // invited, active, suspended, banned
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
 	LastLoginAt     *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
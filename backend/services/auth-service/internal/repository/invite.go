@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/auth-service/internal/model"
+)
+
+var ErrInviteNotFound = errors.New("invite not found")
+
+// CreateInvitedUser inserts a user without a password. It reports false when
+// an account with the email already exists.
+func (r *UserRepository) CreateInvitedUser(ctx context.Context, user *model.User) (bool, error) {
+	query := `
+		INSERT INTO users (id, email, role, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (email) DO NOTHING
+	`
+
+	tag, err := r.pool.Exec(ctx, query,
+		user.ID,
+		user.Email,
+		user.Role,
+		user.Status,
+		user.CreatedAt,
+		user.UpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create invited user: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *UserRepository) CreateInvite(ctx context.Context, invite *model.Invite, tokenHash string) error {
+	query := `
+		INSERT INTO user_invites (id, user_id, token_hash, invited_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		invite.ID,
+		invite.UserID,
+		tokenHash,
+		invite.InvitedBy,
+		invite.ExpiresAt,
+		invite.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetInviteByTokenHash(ctx context.Context, tokenHash string) (*model.Invite, error) {
+	query := `
+		SELECT id, user_id, invited_by, expires_at, accepted_at, created_at
+		FROM user_invites
+		WHERE token_hash = $1
+	`
+
+	var invite model.Invite
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&invite.ID,
+		&invite.UserID,
+		&invite.InvitedBy,
+		&invite.ExpiresAt,
+		&invite.AcceptedAt,
+		&invite.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInviteNotFound
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	return &invite, nil
+}
+
+// AcceptInvite sets the user's password and activates the account. The email
+// is treated as verified since the invite token was delivered to it.
+func (r *UserRepository) AcceptInvite(ctx context.Context, inviteID, userID, passwordHash string, acceptedAt time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE user_invites
+		SET accepted_at = $1
+		WHERE id = $2 AND accepted_at IS NULL
+	`, acceptedAt, inviteID)
+	if err != nil {
+		return fmt.Errorf("failed to accept invite: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInviteNotFound
+	}
+
+	tag, err = tx.Exec(ctx, `
+		UPDATE users
+		SET password_hash = $1, status = 'active', email_verified = true, updated_at = $2
+		WHERE id = $3 AND status = 'invited'
+	`, passwordHash, acceptedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to activate invited user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInviteNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit invite acceptance: %w", err)
+	}
+
+	return nil
+}
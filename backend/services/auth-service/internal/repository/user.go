@@ -51,7 +51,7 @@ func (r *UserRepository) Create(ctx context.Context, user *model.User) error {
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
 	query := `
-		SELECT id, email, email_verified, phone, phone_verified, password_hash, 
+		SELECT id, email, email_verified, phone, phone_verified, COALESCE(password_hash, ''),
 		       role, status, created_at, updated_at, last_login_at
 		FROM users
 		WHERE id = $1
@@ -84,7 +84,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*model.User, e
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	query := `
-		SELECT id, email, email_verified, phone, phone_verified, password_hash, 
+		SELECT id, email, email_verified, phone, phone_verified, COALESCE(password_hash, ''),
 		       role, status, created_at, updated_at, last_login_at
 		FROM users
 		WHERE email = $1
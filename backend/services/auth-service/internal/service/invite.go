@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/auth-service/internal/model"
+	"github.com/scouttalent/auth-service/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const inviteTTL = 14 * 24 * time.Hour
+
+var ErrInvalidInvite = errors.New("invite is invalid or has expired")
+
+// InviteUser creates an invite-pending account for the email, or returns the
+// existing account. A fresh invite token is issued for accounts that are
+// still pending so the invite can be re-sent.
+func (s *AuthService) InviteUser(ctx context.Context, req model.InviteUserRequest) (*model.InviteResult, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	created := false
+	if errors.Is(err, repository.ErrUserNotFound) {
+		now := time.Now()
+		user = &model.User{
+			ID:        uuid.New().String(),
+			Email:     email,
+			Role:      req.Role,
+			Status:    "invited",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		created, err = s.repo.CreateInvitedUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if !created {
+			// Lost a race with another invite or registration
+			user, err = s.repo.GetByEmail(ctx, email)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.InviteResult{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Role:    user.Role,
+		Status:  user.Status,
+		Created: created,
+	}
+	if user.Status != "invited" {
+		return result, nil
+	}
+
+	token, err := generateInviteToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invite := &model.Invite{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		InvitedBy: req.InvitedBy,
+		ExpiresAt: now.Add(inviteTTL),
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateInvite(ctx, invite, hashInviteToken(token)); err != nil {
+		return nil, err
+	}
+
+	result.Token = token
+	result.ExpiresAt = &invite.ExpiresAt
+	return result, nil
+}
+
+// AcceptInvite sets the invited user's password and activates the account.
+func (s *AuthService) AcceptInvite(ctx context.Context, req model.AcceptInviteRequest) (*model.User, error) {
+	invite, err := s.repo.GetInviteByTokenHash(ctx, hashInviteToken(req.Token))
+	if err != nil {
+		if errors.Is(err, repository.ErrInviteNotFound) {
+			return nil, ErrInvalidInvite
+		}
+		return nil, err
+	}
+	if invite.AcceptedAt != nil || time.Now().After(invite.ExpiresAt) {
+		return nil, ErrInvalidInvite
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.repo.AcceptInvite(ctx, invite.ID, invite.UserID, string(hashedPassword), time.Now()); err != nil {
+		if errors.Is(err, repository.ErrInviteNotFound) {
+			return nil, ErrInvalidInvite
+		}
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, invite.UserID)
+}
+
+func generateInviteToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
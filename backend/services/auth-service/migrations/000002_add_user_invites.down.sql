@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS user_invites;
+
+DELETE FROM users WHERE status = 'invited';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'suspended', 'banned'));
+
+ALTER TABLE users ALTER COLUMN password_hash SET NOT NULL;
@@ -0,0 +1,19 @@
+-- Invited users are created on someone else's behalf (e.g. an academy roster
+-- import) and have no password until they accept the invite
+ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('invited', 'active', 'suspended', 'banned'));
+
+CREATE TABLE IF NOT EXISTS user_invites (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    invited_by VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_invites_user ON user_invites(user_id);
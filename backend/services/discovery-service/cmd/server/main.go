@@ -73,8 +73,9 @@ func main() {
 	// API routes
 	api := router.Group("/api/v1")
 	{
-		// Search endpoints
+		// Search endpoints (token optional; used to apply privacy settings)
 		search := api.Group("/search")
+		search.Use(middleware.OptionalAuthMiddleware(cfg.JWT))
 		{
 			search.GET("/profiles", searchHandler.SearchProfiles)
 			search.GET("/videos", searchHandler.SearchVideos)
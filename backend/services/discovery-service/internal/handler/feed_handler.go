@@ -21,7 +21,7 @@ func (h *FeedHandler) GetFeed(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	videos, total, err := h.service.GetFeed(c.Request.Context(), limit, offset)
+	videos, total, err := h.service.GetFeed(c.Request.Context(), viewerFromContext(c), limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get feed"})
 		return
@@ -38,7 +38,7 @@ func (h *FeedHandler) GetFeed(c *gin.Context) {
 func (h *FeedHandler) GetTrendingVideos(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	videos, err := h.service.GetTrendingVideos(c.Request.Context(), limit)
+	videos, err := h.service.GetTrendingVideos(c.Request.Context(), viewerFromContext(c), limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get trending videos"})
 		return
@@ -25,7 +25,7 @@ func (h *RecommendationHandler) GetProfileRecommendations(c *gin.Context) {
 
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	profiles, err := h.service.GetProfileRecommendations(c.Request.Context(), profileID, limit)
+	profiles, err := h.service.GetProfileRecommendations(c.Request.Context(), viewerFromContext(c), profileID, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
 		return
@@ -43,7 +43,7 @@ func (h *RecommendationHandler) GetVideoRecommendations(c *gin.Context) {
 
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	videos, err := h.service.GetVideoRecommendations(c.Request.Context(), profileID, limit)
+	videos, err := h.service.GetVideoRecommendations(c.Request.Context(), viewerFromContext(c), profileID, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
 		return
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/scouttalent/discovery-service/internal/model"
 	"github.com/scouttalent/discovery-service/internal/service"
+	"github.com/scouttalent/pkg/auth"
 )
 
 type SearchHandler struct {
@@ -27,8 +28,12 @@ func (h *SearchHandler) SearchProfiles(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if filters.Lang == "" {
+		filters.Lang = model.PreferredLocale(c.GetHeader("Accept-Language"))
+	}
+	c.Header("Vary", "Accept-Language")
 
-	profiles, total, err := h.service.SearchProfiles(c.Request.Context(), query, filters, limit, offset)
+	profiles, total, err := h.service.SearchProfiles(c.Request.Context(), viewerFromContext(c), query, filters, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search profiles"})
 		return
@@ -47,7 +52,7 @@ func (h *SearchHandler) SearchVideos(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	videos, total, err := h.service.SearchVideos(c.Request.Context(), query, limit, offset)
+	videos, total, err := h.service.SearchVideos(c.Request.Context(), viewerFromContext(c), query, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search videos"})
 		return
@@ -59,4 +64,22 @@ func (h *SearchHandler) SearchVideos(c *gin.Context) {
 		Limit:   limit,
 		Offset:  offset,
 	})
-}
\ No newline at end of file
+}
+
+// viewerFromContext reads the caller from the claims set by the auth
+// middlewares. Requests without a valid token are anonymous.
+func viewerFromContext(c *gin.Context) model.Viewer {
+	claims, ok := c.Get("claims")
+	if !ok {
+		return model.Viewer{}
+	}
+	userClaims, ok := claims.(*auth.Claims)
+	if !ok {
+		return model.Viewer{}
+	}
+	return model.Viewer{
+		UserID:     userClaims.UserID,
+		Role:       userClaims.Role,
+		TrustLevel: userClaims.TrustLevel,
+	}
+}
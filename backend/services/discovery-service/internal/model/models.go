@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Profile struct {
 	ID            string    `json:"id"`
@@ -13,25 +18,103 @@ type Profile struct {
 	PreferredFoot string    `json:"preferred_foot,omitempty"`
 	Height        int       `json:"height,omitempty"`
 	Weight        int       `json:"weight,omitempty"`
+	DistanceKM    *float64  `json:"distance_km,omitempty"`
+	Locale        string    `json:"locale,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 }
 
 type Video struct {
-	ID          string    `json:"id"`
-	ProfileID   string    `json:"profile_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	FileName    string    `json:"file_name"`
-	BlobURL     string    `json:"blob_url"`
-	Status      string    `json:"status"`
-	ViewCount   int       `json:"view_count"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID           string    `json:"id"`
+	ProfileID    string    `json:"profile_id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	FileName     string    `json:"file_name"`
+	BlobURL      string    `json:"blob_url"`
+	Status       string    `json:"status"`
+	ViewCount    int       `json:"view_count"`
+	ThumbnailURL *string   `json:"thumbnail_url,omitempty"`
+	PosterURL    *string   `json:"poster_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 type ProfileFilters struct {
 	ProfileType string `form:"profile_type"`
 	Position    string `form:"position"`
 	Location    string `form:"location"`
+	// Country is an ISO 3166-1 alpha-2 code
+	Country string `form:"country" binding:"omitempty,len=2,alpha"`
+	// NearLat, NearLng and RadiusKM restrict results to profiles whose city
+	// lies within RadiusKM of the point. They must be given together.
+	NearLat  *float64 `form:"near_lat" binding:"required_with=NearLng RadiusKM,omitempty,min=-90,max=90"`
+	NearLng  *float64 `form:"near_lng" binding:"required_with=NearLat RadiusKM,omitempty,min=-180,max=180"`
+	RadiusKM *float64 `form:"radius_km" binding:"required_with=NearLat NearLng,omitempty,gt=0,max=1000"`
+	// Lang is the locale bios are returned in where the profile has a
+	// translation. It defaults to the Accept-Language header.
+	Lang string `form:"lang" binding:"omitempty,oneof=en fr es pt yo ha ig"`
+}
+
+// HasRadius reports whether the filters include a radius search.
+func (f ProfileFilters) HasRadius() bool {
+	return f.NearLat != nil && f.NearLng != nil && f.RadiusKM != nil
+}
+
+// ContentLocales are the profile-service content languages.
+var ContentLocales = []string{"en", "fr", "es", "pt", "yo", "ha", "ig"}
+
+// PreferredLocale returns the most preferred content locale in an
+// Accept-Language header, or "" when none is supported.
+func PreferredLocale(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > bestQ && slices.Contains(ContentLocales, base) {
+			best, bestQ = base, q
+		}
+	}
+	return best
+}
+
+// Viewer is the caller of a discovery request. Role and TrustLevel are empty
+// for anonymous requests.
+type Viewer struct {
+	UserID     string
+	Role       string
+	TrustLevel string
+}
+
+// Audiences returns the profile-service privacy audiences the viewer belongs
+// to. Hidden profiles are never returned by discovery.
+func (v Viewer) Audiences() []string {
+	audiences := []string{"public"}
+	if v.Role == "scout" || v.Role == "academy" {
+		audiences = append(audiences, "scouts_and_academies")
+	}
+	if v.Role == "scout" && (v.TrustLevel == "verified" || v.TrustLevel == "pro") {
+		audiences = append(audiences, "verified_scouts")
+	}
+	return audiences
+}
+
+// VideoVisibilities returns the media-service video visibilities the viewer
+// may discover. Scouts-only videos, which minors' videos default to, are
+// for the roles allowed to view all videos. Private and unlisted videos are
+// never returned by discovery.
+func (v Viewer) VideoVisibilities() []string {
+	visibilities := []string{"public"}
+	if v.Role == "scout" || v.Role == "academy" || v.Role == "admin" {
+		visibilities = append(visibilities, "scouts_only")
+	}
+	return visibilities
 }
 
 type SearchResponse struct {
@@ -39,4 +122,4 @@ type SearchResponse struct {
 	Total   int         `json:"total"`
 	Limit   int         `json:"limit"`
 	Offset  int         `json:"offset"`
-}
\ No newline at end of file
+}
@@ -3,11 +3,17 @@ package repository
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/scouttalent/discovery-service/internal/model"
 )
 
+const (
+	earthRadiusKM = 6371.0
+	kmPerDegree   = 111.045
+)
+
 type ProfileRepository struct {
 	db *pgxpool.Pool
 }
@@ -16,24 +22,58 @@ func NewProfileRepository(db *pgxpool.Pool) *ProfileRepository {
 	return &ProfileRepository{db: db}
 }
 
-func (r *ProfileRepository) SearchProfiles(ctx context.Context, query string, filters model.ProfileFilters, limit, offset int) ([]model.Profile, int, error) {
-	// Build search query
+func (r *ProfileRepository) SearchProfiles(ctx context.Context, viewer model.Viewer, query string, filters model.ProfileFilters, limit, offset int) ([]model.Profile, int, error) {
+	// Build search query. $1 holds the viewer's privacy audiences: only
+	// profiles visible to them are returned, and location is only shown or
+	// matched when the profile's city is visible to them. $2 holds the
+	// viewer's user ID, to leave out profiles blocked with theirs.
+	args := []interface{}{viewer.Audiences(), viewer.UserID}
+	argCount := 3
+
+	// Radius searches select the distance so results can be ordered by it
+	distance := "NULL::float8"
+	if filters.HasRadius() {
+		distance = haversineSQL(argCount, argCount+1)
+		args = append(args, *filters.NearLat, *filters.NearLng)
+		argCount += 2
+	}
+
+	// Bios are returned in filters.Lang where the profile has a translation
+	// in it, otherwise in the profile's primary language
+	bio, locale, translationJoin := "p.bio", "p.primary_language", ""
+	if filters.Lang != "" {
+		bio = "COALESCE(tl.bio, p.bio)"
+		locale = "CASE WHEN tl.bio IS NOT NULL THEN tl.locale ELSE p.primary_language END"
+		translationJoin = fmt.Sprintf("LEFT JOIN profile_translations tl ON tl.profile_id = p.id AND tl.locale = $%d", argCount)
+		args = append(args, filters.Lang)
+		argCount++
+	}
+
 	sqlQuery := `
-		SELECT p.id, p.user_id, p.bio, p.location, p.profile_type, p.avatar_url, p.created_at,
-		       pd.position, pd.preferred_foot, pd.height, pd.weight
+		SELECT p.id, p.user_id, ` + bio + `,
+		       CASE WHEN p.city_visibility::text = ANY($1) THEN p.location ELSE '' END,
+		       p.profile_type, p.avatar_url, p.created_at,
+		       pd.position, pd.preferred_foot, pd.height, pd.weight,
+		       ` + distance + ` AS distance_km, ` + locale + `
 		FROM profiles p
 		LEFT JOIN player_details pd ON p.id = pd.profile_id
-		WHERE 1=1
+		` + translationJoin + `
+		WHERE p.status = 'active' AND p.visibility::text = ANY($1)
+		  AND ` + notBlockedSQL("p.id", 2) + `
 	`
 
-	args := []interface{}{}
-	argCount := 1
-
-	// Add search conditions
+	// Add search conditions. The primary text and every translation are
+	// each matched with the text search configuration of their own
+	// language, so stemming works whichever language the query is in.
 	if query != "" {
-		sqlQuery += fmt.Sprintf(" AND (p.bio ILIKE $%d OR p.location ILIKE $%d)", argCount, argCount)
-		args = append(args, "%"+query+"%")
-		argCount++
+		sqlQuery += fmt.Sprintf(`
+		AND (p.search_vector @@ websearch_to_tsquery(profile_text_search_config(p.primary_language), $%[1]d)
+		     OR EXISTS (SELECT 1 FROM profile_translations t
+		                WHERE t.profile_id = p.id
+		                  AND t.search_vector @@ websearch_to_tsquery(profile_text_search_config(t.locale), $%[1]d))
+		     OR (p.city_visibility::text = ANY($1) AND p.location ILIKE $%[2]d))`, argCount, argCount+1)
+		args = append(args, query, "%"+query+"%")
+		argCount += 2
 	}
 
 	if filters.ProfileType != "" {
@@ -49,11 +89,35 @@ func (r *ProfileRepository) SearchProfiles(ctx context.Context, query string, fi
 	}
 
 	if filters.Location != "" {
-		sqlQuery += fmt.Sprintf(" AND p.location ILIKE $%d", argCount)
+		sqlQuery += fmt.Sprintf(" AND p.city_visibility::text = ANY($1) AND p.location ILIKE $%d", argCount)
 		args = append(args, "%"+filters.Location+"%")
 		argCount++
 	}
 
+	if filters.Country != "" {
+		sqlQuery += fmt.Sprintf(" AND p.location_country_code = UPPER($%d)", argCount)
+		args = append(args, filters.Country)
+		argCount++
+	}
+
+	// Only profiles whose city the viewer may see can be placed on the map.
+	// The bounding box lets the coordinates index narrow the candidates
+	// before the exact distance check.
+	if filters.HasRadius() {
+		minLat, maxLat, minLng, maxLng := boundingBox(*filters.NearLat, *filters.NearLng, *filters.RadiusKM)
+		sqlQuery += fmt.Sprintf(" AND p.city_visibility::text = ANY($1) AND p.location_lat BETWEEN $%d AND $%d", argCount, argCount+1)
+		args = append(args, minLat, maxLat)
+		argCount += 2
+		if minLng >= -180 && maxLng <= 180 {
+			sqlQuery += fmt.Sprintf(" AND p.location_lng BETWEEN $%d AND $%d", argCount, argCount+1)
+			args = append(args, minLng, maxLng)
+			argCount += 2
+		}
+		sqlQuery += fmt.Sprintf(" AND %s <= $%d", distance, argCount)
+		args = append(args, *filters.RadiusKM)
+		argCount++
+	}
+
 	// Get total count
 	countQuery := "SELECT COUNT(*) FROM (" + sqlQuery + ") AS count_query"
 	var total int
@@ -63,7 +127,11 @@ func (r *ProfileRepository) SearchProfiles(ctx context.Context, query string, fi
 	}
 
 	// Add pagination
-	sqlQuery += fmt.Sprintf(" ORDER BY p.created_at DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	orderBy := "p.created_at DESC"
+	if filters.HasRadius() {
+		orderBy = "distance_km, p.created_at DESC"
+	}
+	sqlQuery += fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", orderBy, argCount, argCount+1)
 	args = append(args, limit, offset)
 
 	// Execute query
@@ -81,7 +149,7 @@ func (r *ProfileRepository) SearchProfiles(ctx context.Context, query string, fi
 
 		err := rows.Scan(
 			&p.ID, &p.UserID, &p.Bio, &p.Location, &p.ProfileType, &p.AvatarURL, &p.CreatedAt,
-			&position, &preferredFoot, &height, &weight,
+			&position, &preferredFoot, &height, &weight, &p.DistanceKM, &p.Locale,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -106,20 +174,25 @@ func (r *ProfileRepository) SearchProfiles(ctx context.Context, query string, fi
 	return profiles, total, nil
 }
 
-func (r *ProfileRepository) GetSimilarProfiles(ctx context.Context, profileID string, limit int) ([]model.Profile, error) {
-	// Get profiles with similar characteristics
+func (r *ProfileRepository) GetSimilarProfiles(ctx context.Context, viewer model.Viewer, profileID string, limit int) ([]model.Profile, error) {
+	// Get profiles with similar characteristics, honoring privacy settings
 	query := `
-		SELECT p.id, p.user_id, p.bio, p.location, p.profile_type, p.avatar_url, p.created_at,
+		SELECT p.id, p.user_id, p.bio,
+		       CASE WHEN p.city_visibility::text = ANY($3) THEN p.location ELSE '' END,
+		       p.profile_type, p.avatar_url, p.created_at,
 		       pd.position, pd.preferred_foot, pd.height, pd.weight
 		FROM profiles p
 		LEFT JOIN player_details pd ON p.id = pd.profile_id
 		WHERE p.id != $1
 		  AND p.profile_type = (SELECT profile_type FROM profiles WHERE id = $1)
+		  AND p.status = 'active'
+		  AND p.visibility::text = ANY($3)
+		  AND ` + notBlockedSQL("p.id", 4) + `
 		ORDER BY p.created_at DESC
 		LIMIT $2
 	`
 
-	rows, err := r.db.Query(ctx, query, profileID, limit)
+	rows, err := r.db.Query(ctx, query, profileID, limit, viewer.Audiences(), viewer.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -156,4 +229,44 @@ func (r *ProfileRepository) GetSimilarProfiles(ctx context.Context, profileID st
 	}
 
 	return profiles, nil
-}
\ No newline at end of file
+}
+
+// notBlockedSQL leaves out profiles blocked with the viewer's own profile
+// in either direction. userArg is the placeholder holding the viewer's user
+// ID; anonymous viewers have none and see every profile.
+func notBlockedSQL(profileColumn string, userArg int) string {
+	return fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM profile_blocks b
+		JOIN profiles viewer ON viewer.user_id = NULLIF($%[2]d::text, '')::uuid
+		WHERE (b.blocker_id = viewer.id AND b.blocked_id = %[1]s)
+		   OR (b.blocker_id = %[1]s AND b.blocked_id = viewer.id))`, profileColumn, userArg)
+}
+
+// haversineSQL is the great-circle distance in kilometres between a
+// profile's city and the point in the given placeholders.
+func haversineSQL(latArg, lngArg int) string {
+	return fmt.Sprintf(`(2 * %g * ASIN(SQRT(
+		POWER(SIN(RADIANS(p.location_lat - $%[2]d) / 2), 2) +
+		COS(RADIANS($%[2]d)) * COS(RADIANS(p.location_lat)) *
+		POWER(SIN(RADIANS(p.location_lng - $%[3]d) / 2), 2))))`, earthRadiusKM, latArg, lngArg)
+}
+
+// boundingBox returns the latitude and longitude ranges that contain every
+// point within radiusKM of the centre. The longitude range runs outside
+// [-180, 180] when the circle crosses the antimeridian or a pole.
+func boundingBox(lat, lng, radiusKM float64) (minLat, maxLat, minLng, maxLng float64) {
+	latDelta := radiusKM / kmPerDegree
+	minLat, maxLat = lat-latDelta, lat+latDelta
+	if minLat <= -90 || maxLat >= 90 {
+		return minLat, maxLat, -181, 181
+	}
+
+	// The circle reaches its widest longitude poleward of the centre, so
+	// scaling by the centre's cos(lat) alone would clip large radii
+	ratio := math.Sin(radiusKM/earthRadiusKM) / math.Cos(lat*math.Pi/180)
+	if ratio >= 1 {
+		return minLat, maxLat, -181, 181
+	}
+	lngDelta := math.Asin(ratio) * 180 / math.Pi
+	return minLat, maxLat, lng - lngDelta, lng + lngDelta
+}
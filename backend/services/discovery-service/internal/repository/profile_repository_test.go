@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// destination returns the point distanceKM from (lat, lng) along bearing
+// degrees, on the same sphere haversineSQL uses.
+func destination(lat, lng, bearing, distanceKM float64) (float64, float64) {
+	const rad = math.Pi / 180
+	d := distanceKM / earthRadiusKM
+	lat1, lng1, b := lat*rad, lng*rad, bearing*rad
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(b))
+	lng2 := lng1 + math.Atan2(math.Sin(b)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+	return lat2 / rad, lng2 / rad
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name           string
+		lat, lng, km   float64
+		wantAllLngs    bool
+		wantLngOutside bool // the longitude range crosses the antimeridian
+	}{
+		{name: "equator", lat: 0, lng: 0, km: 100},
+		{name: "Lagos", lat: 6.455, lng: 3.3841, km: 250},
+		{name: "Oslo", lat: 59.9139, lng: 10.7522, km: 1000},
+		{name: "southern hemisphere", lat: -33.4489, lng: -70.6693, km: 500},
+		{name: "near the antimeridian", lat: -17.7134, lng: 178.065, km: 300, wantLngOutside: true},
+		{name: "reaches the north pole", lat: 85, lng: 20, km: 1000, wantAllLngs: true},
+		{name: "reaches the south pole", lat: -89.5, lng: -45, km: 100, wantAllLngs: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minLat, maxLat, minLng, maxLng := boundingBox(tt.lat, tt.lng, tt.km)
+
+			if tt.wantAllLngs {
+				if minLng != -181 || maxLng != 181 {
+					t.Errorf("longitude range = [%v, %v], want every longitude", minLng, maxLng)
+				}
+			}
+			crosses := minLng < -180 || maxLng > 180
+			if !tt.wantAllLngs && crosses != tt.wantLngOutside {
+				t.Errorf("longitude range = [%v, %v], crosses antimeridian = %v, want %v", minLng, maxLng, crosses, tt.wantLngOutside)
+			}
+
+			// Every point on the circle must fall inside the box, or the
+			// box would filter out profiles the distance check accepts
+			for bearing := 0.0; bearing < 360; bearing += 5 {
+				lat, lng := destination(tt.lat, tt.lng, bearing, tt.km*0.999)
+				if lat < minLat || lat > maxLat {
+					t.Fatalf("bearing %v: lat %v outside [%v, %v]", bearing, lat, minLat, maxLat)
+				}
+				if tt.wantAllLngs {
+					continue
+				}
+				// Unwrap so the comparison works across the antimeridian
+				for lng < minLng {
+					lng += 360
+				}
+				for lng > maxLng && lng-360 >= minLng {
+					lng -= 360
+				}
+				if lng < minLng || lng > maxLng {
+					t.Fatalf("bearing %v: lng %v outside [%v, %v]", bearing, lng, minLng, maxLng)
+				}
+			}
+		})
+	}
+}
+
+func TestHaversineSQL(t *testing.T) {
+	sql := haversineSQL(3, 4)
+	for _, want := range []string{"p.location_lat - $3", "RADIANS($3)", "p.location_lng - $4", "2 * 6371 *"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("haversineSQL(3, 4) = %s, missing %q", sql, want)
+		}
+	}
+	if strings.Contains(sql, "%!") {
+		t.Errorf("haversineSQL(3, 4) has a formatting error: %s", sql)
+	}
+}
@@ -16,15 +16,16 @@ func NewVideoRepository(db *pgxpool.Pool) *VideoRepository {
 	return &VideoRepository{db: db}
 }
 
-func (r *VideoRepository) SearchVideos(ctx context.Context, query string, limit, offset int) ([]model.Video, int, error) {
+func (r *VideoRepository) SearchVideos(ctx context.Context, viewer model.Viewer, query string, limit, offset int) ([]model.Video, int, error) {
 	sqlQuery := `
-		SELECT id, profile_id, title, description, file_name, blob_url, status, view_count, created_at
+		SELECT id, profile_id, title, description, file_name, blob_url, status, view_count, thumbnail_url, poster_url, created_at
 		FROM videos
-		WHERE status = 'ready'
+		WHERE status = 'published' AND NOT profile_hidden AND visibility = ANY($1)
+		  AND ` + notBlockedSQL("profile_id", 2) + `
 	`
 
-	args := []interface{}{}
-	argCount := 1
+	args := []interface{}{viewer.VideoVisibilities(), viewer.UserID}
+	argCount := 3
 
 	if query != "" {
 		sqlQuery += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", argCount, argCount)
@@ -53,7 +54,7 @@ func (r *VideoRepository) SearchVideos(ctx context.Context, query string, limit,
 	videos := []model.Video{}
 	for rows.Next() {
 		var v model.Video
-		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.CreatedAt)
+		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.ThumbnailURL, &v.PosterURL, &v.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -63,16 +64,18 @@ func (r *VideoRepository) SearchVideos(ctx context.Context, query string, limit,
 	return videos, total, nil
 }
 
-func (r *VideoRepository) GetFeed(ctx context.Context, limit, offset int) ([]model.Video, int, error) {
+func (r *VideoRepository) GetFeed(ctx context.Context, viewer model.Viewer, limit, offset int) ([]model.Video, int, error) {
+	visibilities := viewer.VideoVisibilities()
 	query := `
-		SELECT id, profile_id, title, description, file_name, blob_url, status, view_count, created_at
+		SELECT id, profile_id, title, description, file_name, blob_url, status, view_count, thumbnail_url, poster_url, created_at
 		FROM videos
-		WHERE status = 'ready'
+		WHERE status = 'published' AND NOT profile_hidden AND visibility = ANY($1)
+		  AND ` + notBlockedSQL("profile_id", 4) + `
 		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
+		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	rows, err := r.db.Query(ctx, query, visibilities, limit, offset, viewer.UserID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -81,7 +84,7 @@ func (r *VideoRepository) GetFeed(ctx context.Context, limit, offset int) ([]mod
 	videos := []model.Video{}
 	for rows.Next() {
 		var v model.Video
-		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.CreatedAt)
+		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.ThumbnailURL, &v.PosterURL, &v.CreatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -90,7 +93,10 @@ func (r *VideoRepository) GetFeed(ctx context.Context, limit, offset int) ([]mod
 
 	// Get total count
 	var total int
-	err = r.db.QueryRow(ctx, "SELECT COUNT(*) FROM videos WHERE status = 'ready'").Scan(&total)
+	err = r.db.QueryRow(ctx,
+		"SELECT COUNT(*) FROM videos WHERE status = 'published' AND NOT profile_hidden AND visibility = ANY($1) AND "+notBlockedSQL("profile_id", 2),
+		visibilities, viewer.UserID,
+	).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -98,16 +104,17 @@ func (r *VideoRepository) GetFeed(ctx context.Context, limit, offset int) ([]mod
 	return videos, total, nil
 }
 
-func (r *VideoRepository) GetTrendingVideos(ctx context.Context, limit int) ([]model.Video, error) {
+func (r *VideoRepository) GetTrendingVideos(ctx context.Context, viewer model.Viewer, limit int) ([]model.Video, error) {
 	query := `
-		SELECT id, profile_id, title, description, file_name, blob_url, status, view_count, created_at
+		SELECT id, profile_id, title, description, file_name, blob_url, status, view_count, thumbnail_url, poster_url, created_at
 		FROM videos
-		WHERE status = 'ready'
+		WHERE status = 'published' AND NOT profile_hidden AND visibility = ANY($1)
+		  AND ` + notBlockedSQL("profile_id", 3) + `
 		ORDER BY view_count DESC, created_at DESC
-		LIMIT $1
+		LIMIT $2
 	`
 
-	rows, err := r.db.Query(ctx, query, limit)
+	rows, err := r.db.Query(ctx, query, viewer.VideoVisibilities(), limit, viewer.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +123,7 @@ func (r *VideoRepository) GetTrendingVideos(ctx context.Context, limit int) ([]m
 	videos := []model.Video{}
 	for rows.Next() {
 		var v model.Video
-		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.CreatedAt)
+		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.ThumbnailURL, &v.PosterURL, &v.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -126,20 +133,24 @@ func (r *VideoRepository) GetTrendingVideos(ctx context.Context, limit int) ([]m
 	return videos, nil
 }
 
-func (r *VideoRepository) GetRecommendedVideos(ctx context.Context, profileID string, limit int) ([]model.Video, error) {
+func (r *VideoRepository) GetRecommendedVideos(ctx context.Context, viewer model.Viewer, profileID string, limit int) ([]model.Video, error) {
 	// Get videos from similar profiles
 	query := `
-		SELECT v.id, v.profile_id, v.title, v.description, v.file_name, v.blob_url, v.status, v.view_count, v.created_at
+		SELECT v.id, v.profile_id, v.title, v.description, v.file_name, v.blob_url, v.status, v.view_count, v.thumbnail_url, v.poster_url, v.created_at
 		FROM videos v
 		JOIN profiles p ON v.profile_id = p.id
-		WHERE v.status = 'ready'
+		WHERE v.status = 'published'
+		  AND NOT v.profile_hidden
+		  AND v.visibility = ANY($3)
+		  AND p.status = 'active'
 		  AND v.profile_id != $1
 		  AND p.profile_type = (SELECT profile_type FROM profiles WHERE id = $1)
+		  AND ` + notBlockedSQL("v.profile_id", 4) + `
 		ORDER BY v.view_count DESC, v.created_at DESC
 		LIMIT $2
 	`
 
-	rows, err := r.db.Query(ctx, query, profileID, limit)
+	rows, err := r.db.Query(ctx, query, profileID, limit, viewer.VideoVisibilities(), viewer.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +159,7 @@ func (r *VideoRepository) GetRecommendedVideos(ctx context.Context, profileID st
 	videos := []model.Video{}
 	for rows.Next() {
 		var v model.Video
-		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.CreatedAt)
+		err := rows.Scan(&v.ID, &v.ProfileID, &v.Title, &v.Description, &v.FileName, &v.BlobURL, &v.Status, &v.ViewCount, &v.ThumbnailURL, &v.PosterURL, &v.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
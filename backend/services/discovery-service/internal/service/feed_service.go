@@ -20,10 +20,10 @@ func NewFeedService(videoRepo *repository.VideoRepository, logger *zap.Logger) *
 	}
 }
 
-func (s *FeedService) GetFeed(ctx context.Context, limit, offset int) ([]model.Video, int, error) {
-	return s.videoRepo.GetFeed(ctx, limit, offset)
+func (s *FeedService) GetFeed(ctx context.Context, viewer model.Viewer, limit, offset int) ([]model.Video, int, error) {
+	return s.videoRepo.GetFeed(ctx, viewer, limit, offset)
 }
 
-func (s *FeedService) GetTrendingVideos(ctx context.Context, limit int) ([]model.Video, error) {
-	return s.videoRepo.GetTrendingVideos(ctx, limit)
+func (s *FeedService) GetTrendingVideos(ctx context.Context, viewer model.Viewer, limit int) ([]model.Video, error) {
+	return s.videoRepo.GetTrendingVideos(ctx, viewer, limit)
 }
\ No newline at end of file
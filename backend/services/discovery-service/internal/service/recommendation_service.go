@@ -22,10 +22,10 @@ func NewRecommendationService(profileRepo *repository.ProfileRepository, videoRe
 	}
 }
 
-func (s *RecommendationService) GetProfileRecommendations(ctx context.Context, profileID string, limit int) ([]model.Profile, error) {
-	return s.profileRepo.GetSimilarProfiles(ctx, profileID, limit)
+func (s *RecommendationService) GetProfileRecommendations(ctx context.Context, viewer model.Viewer, profileID string, limit int) ([]model.Profile, error) {
+	return s.profileRepo.GetSimilarProfiles(ctx, viewer, profileID, limit)
 }
 
-func (s *RecommendationService) GetVideoRecommendations(ctx context.Context, profileID string, limit int) ([]model.Video, error) {
-	return s.videoRepo.GetRecommendedVideos(ctx, profileID, limit)
+func (s *RecommendationService) GetVideoRecommendations(ctx context.Context, viewer model.Viewer, profileID string, limit int) ([]model.Video, error) {
+	return s.videoRepo.GetRecommendedVideos(ctx, viewer, profileID, limit)
 }
\ No newline at end of file
@@ -22,10 +22,10 @@ func NewSearchService(profileRepo *repository.ProfileRepository, videoRepo *repo
 	}
 }
 
-func (s *SearchService) SearchProfiles(ctx context.Context, query string, filters model.ProfileFilters, limit, offset int) ([]model.Profile, int, error) {
-	return s.profileRepo.SearchProfiles(ctx, query, filters, limit, offset)
+func (s *SearchService) SearchProfiles(ctx context.Context, viewer model.Viewer, query string, filters model.ProfileFilters, limit, offset int) ([]model.Profile, int, error) {
+	return s.profileRepo.SearchProfiles(ctx, viewer, query, filters, limit, offset)
 }
 
-func (s *SearchService) SearchVideos(ctx context.Context, query string, limit, offset int) ([]model.Video, int, error) {
-	return s.videoRepo.SearchVideos(ctx, query, limit, offset)
+func (s *SearchService) SearchVideos(ctx context.Context, viewer model.Viewer, query string, limit, offset int) ([]model.Video, int, error) {
+	return s.videoRepo.SearchVideos(ctx, viewer, query, limit, offset)
 }
\ No newline at end of file
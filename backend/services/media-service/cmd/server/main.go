@@ -8,8 +8,12 @@ import (
 	"syscall"
 
 	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/media-service/internal/client"
 	"github.com/scouttalent/media-service/internal/config"
+	"github.com/scouttalent/media-service/internal/consumer"
+	"github.com/scouttalent/media-service/internal/events"
 	"github.com/scouttalent/media-service/internal/handler"
+	"github.com/scouttalent/media-service/internal/jobs"
 	"github.com/scouttalent/media-service/internal/repository"
 	"github.com/scouttalent/media-service/internal/service"
 	"github.com/scouttalent/media-service/internal/storage"
@@ -55,36 +59,117 @@ func main() {
 
 	logger.Info("connected to NATS")
 
-	// Initialize Azure Blob Storage
-	blobClient, err := storage.NewAzureBlobClient(cfg.Azure)
+	js, err := nc.JetStream()
 	if err != nil {
-		logger.Fatal("failed to initialize Azure Blob Storage", zap.Error(err))
+		logger.Fatal("failed to get JetStream context", zap.Error(err))
+	}
+	if err := events.EnsureStream(js); err != nil {
+		logger.Fatal("failed to set up event stream", zap.Error(err))
+	}
+
+	// Initialize object storage
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		logger.Fatal("failed to initialize storage", zap.Error(err))
 	}
 
-	logger.Info("initialized Azure Blob Storage")
+	logger.Info("initialized storage", zap.String("driver", store.Driver()))
 
 	// Initialize layers
 	repo := repository.NewMediaRepository(pool)
-	svc := service.NewMediaService(repo, blobClient, nc, logger.Logger)
+	profiles := client.NewProfileClient(cfg.ProfileService.URL, cfg.ProfileService.APIKey)
+	if cfg.ProfileService.URL == "" {
+		logger.Warn("PROFILE_SERVICE_URL not set, all uploads get newcomer limits and start scouts-only")
+	}
+	if !cfg.Transcode.Enabled {
+		logger.Warn("TRANSCODE_ENABLED is off, videos are streamed as uploaded")
+	}
+	svc := service.NewMediaService(repo, store, nc, profiles, service.Options{
+		UploadLimits:         cfg.UploadLimits,
+		Transcode:            cfg.Transcode.Enabled,
+		TranscodeMaxAttempts: cfg.Transcode.MaxAttempts,
+		StreamBaseURL:        cfg.Stream.BaseURL,
+		StreamSigningKey:     cfg.Stream.SigningKey,
+	})
 	h := handler.NewMediaHandler(svc, logger.Logger)
 
+	// Follow profile lifecycle events from profile-service
+	profileConsumer := consumer.NewProfileConsumer(nc, svc, logger.Logger)
+	if err := profileConsumer.Start(); err != nil {
+		logger.Fatal("failed to start profile consumer", zap.Error(err))
+	}
+	defer profileConsumer.Stop()
+
+	// Publish or reject videos as ai-moderation-worker decides
+	moderationConsumer := consumer.NewModerationConsumer(js, svc, logger.Logger)
+	if err := moderationConsumer.Start(); err != nil {
+		logger.Fatal("failed to start moderation consumer", zap.Error(err))
+	}
+	defer moderationConsumer.Stop()
+
+	// Abandon tus uploads that stopped receiving chunks
+	go jobs.NewUploadReaper(svc, logger.Logger).Run(ctx)
+
+	// Relay outbox events to JetStream, including those the transcoder
+	// records
+	relay := events.NewRelay(repo, js, logger.Logger)
+	go relay.Run(ctx)
+
 	// Setup router
 	router := gin.Default()
 
 	// Public routes
 	router.GET("/health", h.Health)
 
+	// Local storage serves its own signed upload and download URLs
+	if local, ok := store.(*storage.LocalBackend); ok {
+		router.Any(storage.LocalRoutePrefix+"/*key", gin.WrapH(local))
+	}
+
+	// Internal service-to-service routes
+	if cfg.InternalAPIKey != "" {
+		internal := router.Group("/internal/v1")
+		internal.Use(middleware.InternalAuth(cfg.InternalAPIKey))
+		{
+			internal.GET("/profiles/:profile_id/public-videos", h.ListPublicVideos)
+		}
+	} else {
+		logger.Warn("INTERNAL_API_KEY not set, internal routes disabled")
+	}
+
+	// tus discovery is unauthenticated so browsers can preflight
+	router.OPTIONS("/api/v1/videos/tus", h.TusOptions)
+	router.OPTIONS("/api/v1/videos/tus/:id", h.TusOptions)
+
+	// HLS playlists are authorized by the signed stream URL
+	router.GET("/api/v1/videos/:id/hls/*file", h.StreamPlaylist)
+
+	// Images are linked from feeds, so their URLs need no token
+	router.GET("/api/v1/videos/:id/images/:file", h.GetImage)
+
+	// Unlisted videos are opened by their share link alone
+	router.GET("/api/v1/videos/shared/:token", h.GetSharedVideo)
+
 	// Protected routes
 	api := router.Group("/api/v1/videos")
 	api.Use(middleware.AuthMiddleware(cfg.JWT))
 	{
 		api.POST("/upload", h.InitiateUpload)
-		api.PATCH("/upload/:id", h.ResumeUpload)
+		api.POST("/tus", h.CreateTusUpload)
+		api.HEAD("/tus/:id", h.HeadTusUpload)
+		api.PATCH("/tus/:id", h.PatchTusUpload)
 		api.POST("/:id/complete", h.CompleteUpload)
 		api.GET("/:id", h.GetVideo)
 		api.GET("/profile/:profile_id", h.ListProfileVideos)
 		api.PUT("/:id", h.UpdateVideo)
 		api.DELETE("/:id", h.DeleteVideo)
+		api.GET("/:id/thumbnails", h.ListThumbnails)
+		api.PUT("/:id/thumbnail", h.SelectThumbnail)
+		api.POST("/:id/thumbnail", h.UploadThumbnail)
+		api.POST("/:id/archive", h.ArchiveVideo)
+		api.POST("/:id/restore", h.RestoreVideo)
+		api.GET("/:id/status-history", h.VideoStatusHistory)
+		api.POST("/:id/share-link", h.ResetShareLink)
 	}
 
 	// Start server
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/scouttalent/media-service/internal/config"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/storage"
+	"github.com/scouttalent/media-service/internal/transcode"
+	"github.com/scouttalent/pkg/database"
+	"github.com/scouttalent/pkg/logging"
+	"github.com/scouttalent/pkg/messaging"
+	"go.uber.org/zap"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	// Initialize logger
+	logger, err := logging.NewLogger("media-transcoder")
+	if err != nil {
+		log.Fatalf("failed to create logger: %v", err)
+	}
+	defer logger.Sync()
+
+	// Initialize database
+	pool, err := database.NewPool(ctx, cfg.Database)
+	if err != nil {
+		logger.Fatal("failed to connect to database", zap.Error(err))
+	}
+	defer pool.Close()
+
+	logger.Info("connected to database")
+
+	// Initialize NATS
+	nc, err := messaging.NewNATSClient(cfg.NATS)
+	if err != nil {
+		logger.Fatal("failed to connect to NATS", zap.Error(err))
+	}
+	defer nc.Close()
+
+	logger.Info("connected to NATS")
+
+	// Initialize object storage
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		logger.Fatal("failed to initialize storage", zap.Error(err))
+	}
+
+	logger.Info("initialized storage", zap.String("driver", store.Driver()))
+
+	repo := repository.NewMediaRepository(pool)
+	worker := transcode.NewWorker(repo, store, transcode.Config{
+		FFmpegPath:  cfg.Transcode.FFmpegPath,
+		FFprobePath: cfg.Transcode.FFprobePath,
+		WorkDir:     cfg.Transcode.WorkDir,
+		Concurrency: cfg.Transcode.Concurrency,
+		BaseURL:     cfg.Stream.BaseURL,
+	}, logger.Logger)
+
+	sub, err := worker.Subscribe(nc)
+	if err != nil {
+		logger.Fatal("failed to subscribe to transcode requests", zap.Error(err))
+	}
+	defer sub.Drain()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		worker.Run(ctx)
+	}()
+
+	logger.Info("transcoder started", zap.Int("concurrency", cfg.Transcode.Concurrency))
+
+	// Graceful shutdown: jobs in progress are handed back to the queue
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("shutting down transcoder...")
+	cancel()
+	<-done
+}
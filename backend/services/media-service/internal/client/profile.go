@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+const profileRequestTimeout = 3 * time.Second
+
+// ProfileClient calls profile-service's internal API. A client with an
+// empty base URL is disabled and reports every profile as a newcomer and a
+// minor, since it cannot tell their age.
+type ProfileClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func NewProfileClient(baseURL, apiKey string) *ProfileClient {
+	return &ProfileClient{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: profileRequestTimeout},
+	}
+}
+
+// GetProfile returns the trust level and status of a profile.
+func (c *ProfileClient) GetProfile(ctx context.Context, profileID string) (*model.ProfileInfo, error) {
+	if c == nil || c.baseURL == "" {
+		return &model.ProfileInfo{ID: profileID, TrustLevel: model.TrustLevelNewcomer, Minor: true}, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/v1/profiles/%s", c.baseURL, url.PathEscape(profileID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build profile request: %w", err)
+	}
+	req.Header.Set("X-Internal-Token", c.apiKey)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get profile: profile-service returned %d", resp.StatusCode)
+	}
+
+	var profile model.ProfileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to decode profile: %w", err)
+	}
+
+	return &profile, nil
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/storage"
 	"github.com/scouttalent/pkg/auth"
 	"github.com/scouttalent/pkg/azure"
 	"github.com/scouttalent/pkg/database"
@@ -13,11 +19,64 @@ type Config struct {
 	ServerAddress string
 	Database      database.Config
 	NATS          messaging.NATSConfig
-	JWT           auth.JWTConfig
-	Azure         azure.BlobConfig
+	JWT           auth.TokenConfig
+	Storage       storage.Config
+	// InternalAPIKey authenticates service-to-service calls
+	InternalAPIKey string
+	ProfileService ProfileServiceConfig
+	// UploadLimits maps trust levels to the uploads they allow
+	UploadLimits map[string]model.UploadLimits
+	Transcode    TranscodeConfig
+	Stream       StreamConfig
+}
+
+type ProfileServiceConfig struct {
+	URL    string
+	APIKey string
+}
+
+// TranscodeConfig configures HLS transcoding. When it is disabled completed
+// uploads are ready at once and served as uploaded.
+type TranscodeConfig struct {
+	Enabled     bool
+	FFmpegPath  string
+	FFprobePath string
+	WorkDir     string
+	Concurrency int
+	MaxAttempts int
+}
+
+// StreamConfig configures the signed playlist URLs HLS videos are played
+// from. BaseURL is where clients reach this service.
+type StreamConfig struct {
+	BaseURL    string
+	SigningKey string
+}
+
+// defaultUploadLimits apply unless overridden with UPLOAD_MAX_SIZE_MB_<LEVEL>
+// and UPLOAD_MAX_DURATION_<LEVEL>.
+var defaultUploadLimits = map[string]model.UploadLimits{
+	model.TrustLevelNewcomer:    {MaxSize: 500 << 20, MaxDuration: 10 * time.Minute},
+	model.TrustLevelEstablished: {MaxSize: 1 << 30, MaxDuration: 30 * time.Minute},
+	model.TrustLevelVerified:    {MaxSize: 2 << 30, MaxDuration: 2 * time.Hour},
+	model.TrustLevelPro:         {MaxSize: 2 << 30, MaxDuration: 2 * time.Hour},
 }
 
 func Load() (*Config, error) {
+	uploadLimits, err := loadUploadLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency, err := getEnvInt("TRANSCODE_CONCURRENCY", 1)
+	if err != nil || concurrency < 1 {
+		return nil, fmt.Errorf("invalid TRANSCODE_CONCURRENCY")
+	}
+	maxAttempts, err := getEnvInt("TRANSCODE_MAX_ATTEMPTS", 3)
+	if err != nil || maxAttempts < 1 {
+		return nil, fmt.Errorf("invalid TRANSCODE_MAX_ATTEMPTS")
+	}
+
 	return &Config{
 		ServerAddress: getEnv("SERVER_ADDRESS", ":8082"),
 		Database: database.Config{
@@ -30,20 +89,85 @@ func Load() (*Config, error) {
 		NATS: messaging.NATSConfig{
 			URL: getEnv("NATS_URL", "nats://localhost:4222"),
 		},
-		JWT: auth.JWTConfig{
-			Secret: getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+		JWT: auth.TokenConfig{
+			SecretKey: getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 		},
-		Azure: azure.BlobConfig{
-			AccountName:   getEnv("AZURE_STORAGE_ACCOUNT", ""),
-			AccountKey:    getEnv("AZURE_STORAGE_KEY", ""),
-			ContainerName: getEnv("AZURE_CONTAINER_NAME", "videos"),
+		Storage: storage.Config{
+			Driver: getEnv("STORAGE_DRIVER", storage.DriverLocal),
+			Azure: azure.BlobConfig{
+				AccountName:   getEnv("AZURE_STORAGE_ACCOUNT", ""),
+				AccountKey:    getEnv("AZURE_STORAGE_KEY", ""),
+				ContainerName: getEnv("AZURE_CONTAINER_NAME", "videos"),
+				Endpoint:      getEnv("AZURE_STORAGE_ENDPOINT", ""),
+				TenantID:      getEnv("AZURE_TENANT_ID", ""),
+				ClientID:      getEnv("AZURE_CLIENT_ID", ""),
+				ClientSecret:  getEnv("AZURE_CLIENT_SECRET", ""),
+				SASIPRange:    getEnv("AZURE_SAS_IP_RANGE", ""),
+			},
+			S3: storage.S3Config{
+				Endpoint:        getEnv("S3_ENDPOINT", ""),
+				Region:          getEnv("S3_REGION", "us-east-1"),
+				Bucket:          getEnv("S3_BUCKET", "videos"),
+				AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", ""),
+				SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
+				UsePathStyle:    getEnv("S3_USE_PATH_STYLE", "false") == "true",
+			},
+			Local: storage.LocalConfig{
+				Root:       getEnv("LOCAL_STORAGE_ROOT", "./data/media"),
+				BaseURL:    getEnv("LOCAL_STORAGE_BASE_URL", "http://localhost:8082"),
+				SigningKey: getEnv("LOCAL_STORAGE_SIGNING_KEY", "local-storage-key-change-in-production"),
+			},
+		},
+		InternalAPIKey: getEnv("INTERNAL_API_KEY", ""),
+		ProfileService: ProfileServiceConfig{
+			URL:    getEnv("PROFILE_SERVICE_URL", ""),
+			APIKey: getEnv("PROFILE_SERVICE_API_KEY", ""),
+		},
+		UploadLimits: uploadLimits,
+		Transcode: TranscodeConfig{
+			Enabled:     getEnv("TRANSCODE_ENABLED", "true") == "true",
+			FFmpegPath:  getEnv("FFMPEG_PATH", "ffmpeg"),
+			FFprobePath: getEnv("FFPROBE_PATH", "ffprobe"),
+			WorkDir:     getEnv("TRANSCODE_WORK_DIR", os.TempDir()),
+			Concurrency: concurrency,
+			MaxAttempts: maxAttempts,
+		},
+		Stream: StreamConfig{
+			BaseURL:    getEnv("STREAM_BASE_URL", "http://localhost:8082"),
+			SigningKey: getEnv("STREAM_SIGNING_KEY", "stream-signing-key-change-in-production"),
 		},
 	}, nil
 }
 
+func loadUploadLimits() (map[string]model.UploadLimits, error) {
+	limits := map[string]model.UploadLimits{}
+	for level, defaults := range defaultUploadLimits {
+		suffix := strings.ToUpper(level)
+
+		sizeKey := "UPLOAD_MAX_SIZE_MB_" + suffix
+		sizeMB, err := strconv.ParseInt(getEnv(sizeKey, strconv.FormatInt(defaults.MaxSize>>20, 10)), 10, 64)
+		if err != nil || sizeMB < 0 {
+			return nil, fmt.Errorf("invalid %s", sizeKey)
+		}
+
+		durationKey := "UPLOAD_MAX_DURATION_" + suffix
+		duration, err := time.ParseDuration(getEnv(durationKey, defaults.MaxDuration.String()))
+		if err != nil || duration < 0 {
+			return nil, fmt.Errorf("invalid %s", durationKey)
+		}
+
+		limits[level] = model.UploadLimits{MaxSize: sizeMB << 20, MaxDuration: duration}
+	}
+	return limits, nil
+}
+
+func getEnvInt(key string, defaultValue int) (int, error) {
+	return strconv.Atoi(getEnv(key, strconv.Itoa(defaultValue)))
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
@@ -0,0 +1,85 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	moderationDurable    = "media-service-moderation"
+	moderationAckWait    = time.Minute
+	moderationMaxDeliver = 10
+)
+
+// ModerationConsumer applies ai-moderation-worker's decisions, publishing
+// or rejecting videos awaiting moderation. It uses a durable JetStream
+// consumer so decisions made while the service is down are not lost.
+type ModerationConsumer struct {
+	js      nats.JetStreamContext
+	service *service.MediaService
+	logger  *zap.Logger
+	sub     *nats.Subscription
+}
+
+func NewModerationConsumer(js nats.JetStreamContext, svc *service.MediaService, logger *zap.Logger) *ModerationConsumer {
+	return &ModerationConsumer{
+		js:      js,
+		service: svc,
+		logger:  logger,
+	}
+}
+
+func (c *ModerationConsumer) Start() error {
+	sub, err := c.js.QueueSubscribe(model.EventVideoModerated, queueGroup, c.handle,
+		nats.Durable(moderationDurable),
+		nats.ManualAck(),
+		nats.AckWait(moderationAckWait),
+		nats.MaxDeliver(moderationMaxDeliver),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", model.EventVideoModerated, err)
+	}
+	c.sub = sub
+	c.logger.Info("subscribed to moderation events", zap.String("subject", model.EventVideoModerated))
+
+	return nil
+}
+
+func (c *ModerationConsumer) Stop() error {
+	if c.sub == nil {
+		return nil
+	}
+	return c.sub.Drain()
+}
+
+func (c *ModerationConsumer) handle(msg *nats.Msg) {
+	var event model.VideoModeratedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil || event.VideoID == "" {
+		c.logger.Error("failed to parse moderation event", zap.Error(err))
+		// Redelivering a malformed event cannot help
+		msg.Term()
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := c.service.ApplyModeration(ctx, event); err != nil {
+		c.logger.Error("failed to apply moderation decision",
+			zap.String("event_id", event.ID),
+			zap.String("video_id", event.VideoID),
+			zap.String("decision", string(event.Decision)),
+			zap.Error(err),
+		)
+		msg.Nak()
+		return
+	}
+	msg.Ack()
+}
@@ -0,0 +1,99 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	ProfileDeactivatedSubject = "profile.deactivated"
+	ProfileRestoredSubject    = "profile.restored"
+	ProfileDeletedSubject     = "profile.deleted"
+	queueGroup                = "media-service"
+)
+
+// ProfileConsumer keeps videos in step with their profile's lifecycle in
+// profile-service: hidden while the profile is deactivated or taken down,
+// and deleted with it.
+type ProfileConsumer struct {
+	nats    *nats.Conn
+	service *service.MediaService
+	logger  *zap.Logger
+	subs    []*nats.Subscription
+}
+
+func NewProfileConsumer(nc *nats.Conn, svc *service.MediaService, logger *zap.Logger) *ProfileConsumer {
+	return &ProfileConsumer{
+		nats:    nc,
+		service: svc,
+		logger:  logger,
+	}
+}
+
+func (c *ProfileConsumer) Start() error {
+	handlers := map[string]func(context.Context, string) error{
+		ProfileDeactivatedSubject: c.service.HideProfileVideos,
+		ProfileRestoredSubject:    c.service.ShowProfileVideos,
+		ProfileDeletedSubject: func(ctx context.Context, profileID string) error {
+			deleted, err := c.service.DeleteProfileVideos(ctx, profileID)
+			if err == nil {
+				c.logger.Info("deleted videos of deleted profile",
+					zap.String("profile_id", profileID),
+					zap.Int64("videos", deleted),
+				)
+			}
+			return err
+		},
+	}
+
+	for subject, handle := range handlers {
+		// Queue subscription so only one replica handles each event
+		sub, err := c.nats.QueueSubscribe(subject, queueGroup, c.handler(handle))
+		if err != nil {
+			c.Stop()
+			return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+		}
+		c.subs = append(c.subs, sub)
+		c.logger.Info("subscribed to profile events", zap.String("subject", subject))
+	}
+
+	return nil
+}
+
+func (c *ProfileConsumer) Stop() error {
+	for _, sub := range c.subs {
+		if err := sub.Drain(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ProfileConsumer) handler(handle func(context.Context, string) error) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		var event model.ProfileEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil || event.ProfileID == "" {
+			c.logger.Error("failed to parse profile event", zap.String("subject", msg.Subject), zap.Error(err))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := handle(ctx, event.ProfileID); err != nil {
+			c.logger.Error("failed to handle profile event",
+				zap.String("subject", msg.Subject),
+				zap.String("event_id", event.ID),
+				zap.String("profile_id", event.ProfileID),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -31,73 +32,50 @@ func (h *MediaHandler) Health(c *gin.Context) {
 }
 
 func (h *MediaHandler) InitiateUpload(c *gin.Context) {
-	var req model.CreateVideoRequest
+	var req model.VideoUploadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Get profile ID from JWT claims
-	profileID, exists := c.Get("profile_id")
-	if !exists {
+	// Videos are always uploaded to the caller's own profile
+	req.ProfileID = c.GetString("profile_id")
+	if req.ProfileID == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "profile_id not found in token"})
 		return
 	}
 
-	profileUUID, err := uuid.Parse(profileID.(string))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid profile_id"})
+	resp, err := h.service.InitiateUpload(c.Request.Context(), &req)
+	if errors.Is(err, service.ErrInvalidUpload) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrUploadTooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
 		return
 	}
-
-	video, upload, err := h.service.InitiateUpload(c.Request.Context(), profileUUID, &req)
 	if err != nil {
 		h.logger.Error("failed to initiate upload", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to initiate upload"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"video":     video,
-		"upload":    upload,
-		"upload_url": video.BlobURL,
-	})
+	c.JSON(http.StatusCreated, resp)
 }
 
-func (h *MediaHandler) ResumeUpload(c *gin.Context) {
-	uploadID, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload_id"})
-		return
-	}
-
-	// Get progress from request
-	progressStr := c.Query("progress")
-	progress, err := strconv.Atoi(progressStr)
-	if err != nil || progress < 0 || progress > 100 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid progress value"})
+func (h *MediaHandler) CompleteUpload(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
 		return
 	}
 
-	if err := h.service.UpdateUploadProgress(c.Request.Context(), uploadID, progress); err != nil {
-		h.logger.Error("failed to update upload progress", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update upload progress"})
+	err := h.service.CompleteUpload(c.Request.Context(), profileID, videoID)
+	if errors.Is(err, service.ErrUploadRejected) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		return
 	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "upload progress updated"})
-}
-
-func (h *MediaHandler) CompleteUpload(c *gin.Context) {
-	videoID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid video_id"})
-		return
-	}
-
-	if err := h.service.CompleteUpload(c.Request.Context(), videoID); err != nil {
-		h.logger.Error("failed to complete upload", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to complete upload"})
+		h.statusError(c, "failed to complete upload", err)
 		return
 	}
 
@@ -111,14 +89,13 @@ func (h *MediaHandler) GetVideo(c *gin.Context) {
 		return
 	}
 
-	video, err := h.service.GetVideo(c.Request.Context(), videoID)
+	video, err := h.service.GetVideo(c.Request.Context(), viewerFromContext(c), videoID.String())
 	if err != nil {
-		h.logger.Error("failed to get video", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": "video not found"})
+		h.visibilityError(c, "failed to get video", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, model.VideoResponse{Video: video})
+	c.JSON(http.StatusOK, video)
 }
 
 func (h *MediaHandler) ListProfileVideos(c *gin.Context) {
@@ -144,7 +121,7 @@ func (h *MediaHandler) ListProfileVideos(c *gin.Context) {
 		}
 	}
 
-	videos, total, err := h.service.ListProfileVideos(c.Request.Context(), profileID, limit, offset)
+	videos, total, err := h.service.ListProfileVideos(c.Request.Context(), viewerFromContext(c), profileID.String(), limit, offset)
 	if err != nil {
 		h.logger.Error("failed to list videos", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list videos"})
@@ -154,47 +131,66 @@ func (h *MediaHandler) ListProfileVideos(c *gin.Context) {
 	c.JSON(http.StatusOK, model.VideoListResponse{
 		Videos: videos,
 		Total:  total,
+		Limit:  limit,
+		Offset: offset,
 	})
 }
 
 func (h *MediaHandler) UpdateVideo(c *gin.Context) {
-	videoID, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid video_id"})
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
 		return
 	}
 
-	var req model.UpdateVideoRequest
+	var req model.VideoUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	video, err := h.service.UpdateVideo(c.Request.Context(), videoID, &req)
+	video, err := h.service.UpdateVideo(c.Request.Context(), profileID, videoID, &req)
 	if err != nil {
-		h.logger.Error("failed to update video", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update video"})
+		h.visibilityError(c, "failed to update video", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, model.VideoResponse{
-		Video:   video,
-		Message: "video updated successfully",
-	})
+	c.JSON(http.StatusOK, video)
 }
 
 func (h *MediaHandler) DeleteVideo(c *gin.Context) {
-	videoID, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid video_id"})
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
 		return
 	}
 
-	if err := h.service.DeleteVideo(c.Request.Context(), videoID); err != nil {
-		h.logger.Error("failed to delete video", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete video"})
+	if err := h.service.DeleteVideo(c.Request.Context(), profileID, videoID); err != nil {
+		h.visibilityError(c, "failed to delete video", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "video deleted successfully"})
-}
\ No newline at end of file
+}
+// ListPublicVideos is called by profile-service to render public profile pages
+func (h *MediaHandler) ListPublicVideos(c *gin.Context) {
+	profileID, err := uuid.Parse(c.Param("profile_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid profile_id"})
+		return
+	}
+
+	limit := 12
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
+			limit = l
+		}
+	}
+
+	videos, err := h.service.ListPublicVideos(c.Request.Context(), profileID.String(), limit)
+	if err != nil {
+		h.logger.Error("failed to list public videos", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list public videos"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"videos": videos})
+}
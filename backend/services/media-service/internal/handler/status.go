@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// ArchiveVideo takes one of the owner's published videos out of listings.
+func (h *MediaHandler) ArchiveVideo(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
+		return
+	}
+
+	video, err := h.service.ArchiveVideo(c.Request.Context(), profileID, videoID)
+	if err != nil {
+		h.statusError(c, "failed to archive video", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, video)
+}
+
+// RestoreVideo publishes an archived video again.
+func (h *MediaHandler) RestoreVideo(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
+		return
+	}
+
+	video, err := h.service.RestoreVideo(c.Request.Context(), profileID, videoID)
+	if err != nil {
+		h.statusError(c, "failed to restore video", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, video)
+}
+
+// VideoStatusHistory lists a video's status changes, oldest first.
+func (h *MediaHandler) VideoStatusHistory(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.service.VideoStatusHistory(c.Request.Context(), profileID, videoID)
+	if err != nil {
+		h.statusError(c, "failed to get status history", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": history})
+}
+
+func (h *MediaHandler) statusError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrVideoNotFound), errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusNotFound, gin.H{"error": "video not found"})
+	case errors.Is(err, service.ErrInvalidStatus):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/scouttalent/media-service/internal/hls"
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// StreamPlaylist serves a video's HLS playlists. It is unauthenticated:
+// players cannot add an Authorization header to every request they make,
+// so the signed query of the video's stream URL grants access instead.
+func (h *MediaHandler) StreamPlaylist(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "playlist not found"})
+		return
+	}
+	file := strings.TrimPrefix(c.Param("file"), "/")
+
+	playlist, err := h.service.StreamPlaylist(c.Request.Context(), videoID.String(), file, c.Request.URL.Query())
+	switch {
+	case errors.Is(err, hls.ErrInvalidToken), errors.Is(err, hls.ErrTokenExpired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, service.ErrPlaylistNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "playlist not found"})
+		return
+	case err != nil:
+		h.logger.Error("failed to serve playlist", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to serve playlist"})
+		return
+	}
+
+	// Segment URLs in the playlist expire, so it must not outlive them in
+	// a cache
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, hls.PlaylistContentType, playlist)
+}
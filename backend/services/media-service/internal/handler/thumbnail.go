@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/scouttalent/media-service/internal/images"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// ListThumbnails lists the thumbnails the owner can choose from.
+func (h *MediaHandler) ListThumbnails(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
+		return
+	}
+
+	thumbnails, err := h.service.ListThumbnails(c.Request.Context(), profileID, videoID)
+	if err != nil {
+		h.thumbnailError(c, "failed to list thumbnails", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"thumbnails": thumbnails})
+}
+
+// SelectThumbnail shows one of the listed thumbnails for the video.
+func (h *MediaHandler) SelectThumbnail(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
+		return
+	}
+
+	var req model.SelectThumbnailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := uuid.Parse(req.ThumbnailID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thumbnail_id"})
+		return
+	}
+
+	thumbnail, err := h.service.SelectThumbnail(c.Request.Context(), profileID, videoID, req.ThumbnailID)
+	if err != nil {
+		h.thumbnailError(c, "failed to select thumbnail", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"thumbnail": thumbnail})
+}
+
+// UploadThumbnail takes a custom thumbnail as the "file" field of a
+// multipart form and shows it for the video.
+func (h *MediaHandler) UploadThumbnail(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if header.Size > service.MaxThumbnailSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "thumbnail is too large"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	thumbnail, err := h.service.UploadThumbnail(c.Request.Context(), profileID, videoID, file)
+	if err != nil {
+		h.thumbnailError(c, "failed to upload thumbnail", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"thumbnail": thumbnail})
+}
+
+// GetImage serves a video's images at the stable URLs stored on it. It is
+// unauthenticated so feeds can show them in image tags; images redirect to
+// a signed storage URL, while the seek preview track is served directly.
+func (h *MediaHandler) GetImage(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "image not found"})
+		return
+	}
+	file := c.Param("file")
+
+	if file == images.SpriteTrack {
+		track, err := h.service.SeekPreviewTrack(c.Request.Context(), videoID.String())
+		if err != nil {
+			h.imageError(c, err)
+			return
+		}
+		c.Data(http.StatusOK, images.VTTContentType, track)
+		return
+	}
+
+	url, err := h.service.ImageURL(c.Request.Context(), videoID.String(), file)
+	if err != nil {
+		h.imageError(c, err)
+		return
+	}
+
+	// Cached redirects must expire before the signed URL does
+	c.Header("Cache-Control", "public, max-age=600")
+	c.Redirect(http.StatusFound, url)
+}
+
+func (h *MediaHandler) imageError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrImageNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "image not found"})
+		return
+	}
+	h.logger.Error("failed to serve image", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to serve image"})
+}
+
+func (h *MediaHandler) thumbnailError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrVideoNotFound), errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusNotFound, gin.H{"error": "video not found"})
+	case errors.Is(err, repository.ErrThumbnailNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "thumbnail not found"})
+	case errors.Is(err, service.ErrInvalidThumbnail):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUploadTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// ownerRequest reads the video ID from the path and the caller's profile
+// from the token, aborting the request if either is missing.
+func ownerRequest(c *gin.Context) (string, string, bool) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid video_id"})
+		return "", "", false
+	}
+	profileID := c.GetString("profile_id")
+	if profileID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "profile_id not found in token"})
+		return "", "", false
+	}
+	return videoID.String(), profileID, true
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,checksum"
+
+	// statusChecksumMismatch is the tus checksum extension's response to a
+	// chunk that fails its Upload-Checksum.
+	statusChecksumMismatch = 460
+)
+
+// TusOptions advertises the server's tus capabilities. It is unauthenticated
+// so browsers can preflight.
+func (h *MediaHandler) TusOptions(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+	c.Header("Tus-Version", tusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	c.Header("Tus-Max-Size", strconv.FormatInt(service.TusMaxSize, 10))
+	c.Header("Tus-Checksum-Algorithm", strings.Join(service.TusChecksumAlgorithms, ","))
+	c.Status(http.StatusNoContent)
+}
+
+// CreateTusUpload implements the tus creation extension. The file name and
+// type come from Upload-Metadata.
+func (h *MediaHandler) CreateTusUpload(c *gin.Context) {
+	if !h.tusResumable(c) {
+		return
+	}
+	profileID, ok := tusProfileID(c)
+	if !ok {
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil {
+		h.tusError(c, http.StatusBadRequest, "Upload-Length is required")
+		return
+	}
+	metadata, err := parseTusMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		h.tusError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	upload, err := h.service.CreateTusUpload(c.Request.Context(), &model.TusUploadRequest{
+		ProfileID: profileID,
+		Length:    length,
+		Metadata:  metadata,
+	})
+	if err != nil {
+		h.tusServiceError(c, "failed to create upload", err)
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+upload.ID)
+	setUploadExpires(c, upload)
+	c.Status(http.StatusCreated)
+}
+
+// HeadTusUpload reports how much of an upload the server has stored.
+func (h *MediaHandler) HeadTusUpload(c *gin.Context) {
+	if !h.tusResumable(c) {
+		return
+	}
+	profileID, ok := tusProfileID(c)
+	if !ok {
+		return
+	}
+
+	upload, err := h.service.GetTusUpload(c.Request.Context(), profileID, c.Param("id"))
+	if err != nil {
+		h.tusServiceError(c, "failed to get upload", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	if upload.Length != nil {
+		c.Header("Upload-Length", strconv.FormatInt(*upload.Length, 10))
+	}
+	setUploadExpires(c, upload)
+	c.Status(http.StatusOK)
+}
+
+// PatchTusUpload stores a chunk. Chunks are stored whole or not at all, so
+// a dropped connection resumes from the end of the last complete chunk;
+// clients should upload in chunks of a few megabytes.
+func (h *MediaHandler) PatchTusUpload(c *gin.Context) {
+	if !h.tusResumable(c) {
+		return
+	}
+	profileID, ok := tusProfileID(c)
+	if !ok {
+		return
+	}
+
+	if c.ContentType() != "application/offset+octet-stream" {
+		h.tusError(c, http.StatusUnsupportedMediaType, "Content-Type must be application/offset+octet-stream")
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		h.tusError(c, http.StatusBadRequest, "Upload-Offset is required")
+		return
+	}
+	if c.Request.ContentLength < 0 {
+		h.tusError(c, http.StatusLengthRequired, "Content-Length is required")
+		return
+	}
+
+	var checksum *service.UploadChecksum
+	if header := c.GetHeader("Upload-Checksum"); header != "" {
+		checksum, err = parseUploadChecksum(header)
+		if err != nil {
+			h.tusError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	upload, err := h.service.WriteTusChunk(c.Request.Context(), profileID, c.Param("id"),
+		offset, c.Request.Body, c.Request.ContentLength, checksum)
+	if err != nil {
+		h.tusServiceError(c, "failed to store upload chunk", err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	setUploadExpires(c, upload)
+	c.Status(http.StatusNoContent)
+}
+
+// tusResumable sets the Tus-Resumable response header and rejects requests
+// for another protocol version.
+func (h *MediaHandler) tusResumable(c *gin.Context) bool {
+	c.Header("Tus-Resumable", tusVersion)
+	if c.GetHeader("Tus-Resumable") != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		h.tusError(c, http.StatusPreconditionFailed, "unsupported tus version")
+		return false
+	}
+	return true
+}
+
+func (h *MediaHandler) tusError(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{"error": message})
+}
+
+func (h *MediaHandler) tusServiceError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrUploadNotFound), errors.Is(err, service.ErrForbidden):
+		h.tusError(c, http.StatusNotFound, "upload not found")
+	case errors.Is(err, service.ErrUploadOffsetMismatch):
+		h.tusError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrUploadClosed):
+		h.tusError(c, http.StatusGone, err.Error())
+	case errors.Is(err, service.ErrUploadTooLarge):
+		h.tusError(c, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, service.ErrChecksumMismatch):
+		h.tusError(c, statusChecksumMismatch, err.Error())
+	case errors.Is(err, service.ErrUploadRejected):
+		h.tusError(c, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, service.ErrInvalidUpload), errors.Is(err, service.ErrUnsupportedChecksum):
+		h.tusError(c, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(message, zap.Error(err))
+		h.tusError(c, http.StatusInternalServerError, message)
+	}
+}
+
+func tusProfileID(c *gin.Context) (string, bool) {
+	profileID := c.GetString("profile_id")
+	if profileID == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "profile_id not found in token"})
+		return "", false
+	}
+	return profileID, true
+}
+
+func setUploadExpires(c *gin.Context, upload *model.VideoUpload) {
+	if upload.ExpiresAt != nil && upload.Status != model.UploadStatusCompleted {
+		c.Header("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+// parseTusMetadata decodes an Upload-Metadata header: comma-separated keys,
+// each followed by a space and its base64 value. Values may be omitted.
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, errors.New("invalid Upload-Metadata")
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.New("invalid Upload-Metadata value for " + key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+// parseUploadChecksum decodes an Upload-Checksum header: the algorithm, a
+// space and the base64 digest.
+func parseUploadChecksum(header string) (*service.UploadChecksum, error) {
+	algorithm, encoded, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok {
+		return nil, errors.New("invalid Upload-Checksum")
+	}
+	digest, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("invalid Upload-Checksum digest")
+	}
+	return &service.UploadChecksum{Algorithm: strings.ToLower(algorithm), Digest: digest}, nil
+}
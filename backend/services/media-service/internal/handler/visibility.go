@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// GetSharedVideo serves an unlisted video to anyone holding its share
+// link, without a token.
+func (h *MediaHandler) GetSharedVideo(c *gin.Context) {
+	video, err := h.service.GetSharedVideo(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.visibilityError(c, "failed to get shared video", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, video)
+}
+
+// ResetShareLink replaces the share link of one of the owner's unlisted
+// videos, revoking the old one.
+func (h *MediaHandler) ResetShareLink(c *gin.Context) {
+	videoID, profileID, ok := ownerRequest(c)
+	if !ok {
+		return
+	}
+
+	video, err := h.service.ResetShareLink(c.Request.Context(), profileID, videoID)
+	if err != nil {
+		h.visibilityError(c, "failed to reset share link", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, video)
+}
+
+// visibilityError answers 404 for videos the caller may not see, so their
+// existence is not revealed.
+func (h *MediaHandler) visibilityError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrVideoNotFound), errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusNotFound, gin.H{"error": "video not found"})
+	case errors.Is(err, service.ErrInvalidVisibility):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// viewerFromContext reads the caller from the claims the auth middleware
+// set.
+func viewerFromContext(c *gin.Context) model.Viewer {
+	return model.Viewer{
+		ProfileID: c.GetString("profile_id"),
+		Role:      c.GetString("role"),
+	}
+}
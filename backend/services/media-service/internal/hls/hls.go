@@ -0,0 +1,107 @@
+// Package hls lays out a video's HLS renditions in storage and reads and
+// writes the playlists that index them.
+package hls
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+const (
+	PlaylistContentType = "application/vnd.apple.mpegurl"
+	SegmentContentType  = "video/mp2t"
+
+	// MasterPlaylist lists a video's renditions; each rendition's
+	// segments are listed by its VariantPlaylist.
+	MasterPlaylist  = "master.m3u8"
+	VariantPlaylist = "index.m3u8"
+)
+
+// Prefix is the key prefix every HLS object of a video is stored under.
+func Prefix(videoID string) string {
+	return videoID + "/hls/"
+}
+
+// Key is the key of file, a path relative to the video's HLS directory
+// such as "720p/index.m3u8".
+func Key(videoID, file string) string {
+	return Prefix(videoID) + file
+}
+
+// VariantURI is a rendition's playlist relative to the master playlist.
+func VariantURI(name string) string {
+	return name + "/" + VariantPlaylist
+}
+
+// WriteMaster writes a master playlist offering renditions, which must be
+// ordered by bandwidth.
+func WriteMaster(w io.Writer, renditions []model.Rendition) error {
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-INDEPENDENT-SEGMENTS\n")
+	for _, r := range renditions {
+		fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d,AVERAGE-BANDWIDTH=%d,RESOLUTION=%dx%d,CODECS=\"%s\"\n",
+			r.Bandwidth, r.AverageBandwidth, r.Width, r.Height, r.Codecs)
+		buf.WriteString(VariantURI(r.Name) + "\n")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Segment is a media segment listed in a variant playlist.
+type Segment struct {
+	URI      string
+	Duration time.Duration
+}
+
+// Segments returns the segments a variant playlist lists, in order.
+func Segments(playlist []byte) ([]Segment, error) {
+	var segments []Segment
+	var duration time.Duration
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "#EXTINF:"):
+			value, _, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil || seconds < 0 {
+				return nil, fmt.Errorf("invalid segment duration %q", value)
+			}
+			duration = time.Duration(seconds * float64(time.Second))
+		case line == "" || strings.HasPrefix(line, "#"):
+		default:
+			segments = append(segments, Segment{URI: line, Duration: duration})
+			duration = 0
+		}
+	}
+	return segments, scanner.Err()
+}
+
+// Rewrite returns playlist with each URI line replaced by uri(line). Tags
+// and comments are kept as they are.
+func Rewrite(playlist []byte, uri func(string) (string, error)) ([]byte, error) {
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			rewritten, err := uri(line)
+			if err != nil {
+				return nil, err
+			}
+			line = rewritten
+		}
+		out.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
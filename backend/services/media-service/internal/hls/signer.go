@@ -0,0 +1,61 @@
+package hls
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid stream token")
+	ErrTokenExpired = errors.New("stream token expired")
+)
+
+// Signer issues and checks the tokens that let a player fetch a video's
+// playlists. A token covers every playlist of one video until it expires,
+// so the player can follow the master playlist to its renditions.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key string) *Signer {
+	return &Signer{key: []byte(key)}
+}
+
+// Sign returns the query parameters granting access to videoID's playlists
+// until expires.
+func (s *Signer) Sign(videoID string, expires time.Time) url.Values {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return url.Values{
+		"expires": {exp},
+		"sig":     {s.signature(videoID, exp)},
+	}
+}
+
+// Verify checks query parameters made by Sign for videoID and returns when
+// they expire.
+func (s *Signer) Verify(videoID string, query url.Values) (time.Time, error) {
+	exp := query.Get("expires")
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(query.Get("sig")), []byte(s.signature(videoID, exp))) {
+		return time.Time{}, ErrInvalidToken
+	}
+	expires := time.Unix(unix, 0)
+	if time.Now().After(expires) {
+		return time.Time{}, ErrTokenExpired
+	}
+	return expires, nil
+}
+
+func (s *Signer) signature(videoID, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(videoID + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
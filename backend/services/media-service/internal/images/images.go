@@ -0,0 +1,101 @@
+// Package images lays out the poster, thumbnails and seek preview sprite of
+// a video in storage and names the stable URLs they are served from.
+package images
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"time"
+)
+
+const (
+	JPEGContentType = "image/jpeg"
+	PNGContentType  = "image/png"
+	VTTContentType  = "text/vtt"
+
+	Poster = "poster.jpg"
+	// Sprite is the seek preview sheet; SpriteTrack is the WebVTT track
+	// mapping each span of the video to its tile.
+	Sprite      = "sprite.jpg"
+	SpriteTrack = "sprite.vtt"
+)
+
+// filePattern matches every file that may be stored in a video's image
+// directory.
+var filePattern = regexp.MustCompile(`^(poster|sprite|thumb_[0-9]+|custom_[0-9a-f]+)\.(jpg|png|vtt)$`)
+
+// Prefix is the key prefix every image of a video is stored under.
+func Prefix(videoID string) string {
+	return videoID + "/images/"
+}
+
+func Key(videoID, file string) string {
+	return Prefix(videoID) + file
+}
+
+// ValidFile reports whether file names an image a video may have.
+func ValidFile(file string) bool {
+	return filePattern.MatchString(file)
+}
+
+// CandidateFile names the i-th thumbnail candidate, counting from 1.
+func CandidateFile(i int) string {
+	return fmt.Sprintf("thumb_%d.jpg", i)
+}
+
+// CustomFile names a new uploaded thumbnail. Each upload gets a new name so
+// caches never serve the image it replaced.
+func CustomFile(ext string) string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "custom_" + hex.EncodeToString(b) + ext
+}
+
+// URL is the stable address of a video's image: media-service's image
+// route, which redirects to a signed storage URL.
+func URL(baseURL, videoID, file string) string {
+	return fmt.Sprintf("%s/api/v1/videos/%s/images/%s", baseURL, videoID, file)
+}
+
+// ContentType returns the content type of an image file by its extension.
+func ContentType(file string) string {
+	switch path.Ext(file) {
+	case ".png":
+		return PNGContentType
+	case ".vtt":
+		return VTTContentType
+	default:
+		return JPEGContentType
+	}
+}
+
+// Cue maps a span of the video to a tile of the sprite sheet.
+type Cue struct {
+	Start, End          time.Duration
+	X, Y, Width, Height int
+}
+
+// WriteTrack writes a WebVTT seek preview track. Cues point at sprite with
+// a media fragment, relative to the track so the sprite is fetched through
+// the same route.
+func WriteTrack(w io.Writer, sprite string, cues []Cue) error {
+	var buf bytes.Buffer
+	buf.WriteString("WEBVTT\n")
+	for _, cue := range cues {
+		fmt.Fprintf(&buf, "\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n",
+			timestamp(cue.Start), timestamp(cue.End), sprite, cue.X, cue.Y, cue.Width, cue.Height)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// timestamp formats d as a WebVTT hh:mm:ss.ttt timestamp.
+func timestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const uploadReapInterval = 15 * time.Minute
+
+// UploadReaper expires tus uploads that stopped receiving chunks and frees
+// the storage their chunks use.
+type UploadReaper struct {
+	svc    *service.MediaService
+	logger *zap.Logger
+}
+
+func NewUploadReaper(svc *service.MediaService, logger *zap.Logger) *UploadReaper {
+	return &UploadReaper{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// Run reaps on start and then every uploadReapInterval until ctx is
+// cancelled.
+func (r *UploadReaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(uploadReapInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			count, err := r.svc.ExpireUploads(ctx)
+			if err != nil {
+				r.logger.Error("failed to expire uploads", zap.Error(err))
+				break
+			}
+			if count == 0 {
+				break
+			}
+			r.logger.Info("expired stale uploads", zap.Int("count", count))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+const (
+	EventVideoUploaded  = "media.video.uploaded"
+	EventVideoProcessed = "media.video.processed"
+	EventVideoReady     = "media.video.ready"
+	EventVideoUpdated   = "media.video.updated"
+	EventVideoDeleted   = "media.video.deleted"
+	// EventVideoModerated is published by ai-moderation-worker with its
+	// decision on a video awaiting moderation
+	EventVideoModerated = "media.video.moderated"
+
+	// EventSchemaVersion is bumped whenever the event payload changes in a
+	// backwards-incompatible way.
+	EventSchemaVersion = 1
+
+	// EventStream is the JetStream stream that keeps video events for
+	// consumers that were away when they were published.
+	EventStream         = "MEDIA_VIDEOS"
+	EventStreamSubjects = "media.video.>"
+)
+
+// VideoEvent is published for every change in a video's lifecycle. ID is
+// also sent as the Nats-Msg-Id header; delivery is at-least-once, so
+// consumers de-duplicate on it. Timestamp is in Unix seconds.
+type VideoEvent struct {
+	ID        string      `json:"id"`
+	EventType string      `json:"event_type"`
+	Version   int         `json:"version"`
+	VideoID   string      `json:"video_id"`
+	ProfileID string      `json:"profile_id"`
+	Title     string      `json:"title"`
+	Status    VideoStatus `json:"status,omitempty"`
+	Timestamp int64       `json:"timestamp"`
+}
+
+type ModerationDecision string
+
+const (
+	ModerationApproved ModerationDecision = "approved"
+	ModerationRejected ModerationDecision = "rejected"
+	// ModerationFailed means the video could not be moderated
+	ModerationFailed ModerationDecision = "failed"
+)
+
+// VideoModeratedEvent carries ai-moderation-worker's decision on a video.
+// Reason is shown to the owner of a rejected video.
+type VideoModeratedEvent struct {
+	ID        string             `json:"id"`
+	EventType string             `json:"event_type"`
+	VideoID   string             `json:"video_id"`
+	Decision  ModerationDecision `json:"decision"`
+	Reason    string             `json:"reason,omitempty"`
+	Timestamp int64              `json:"timestamp"`
+}
+
+// OutboxEvent is a row in outbox_events awaiting relay to JetStream.
+type OutboxEvent struct {
+	ID       int64
+	EventID  string
+	Subject  string
+	Payload  []byte
+	Attempts int
+}
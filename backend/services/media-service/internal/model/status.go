@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// videoTransitions lists the statuses a video may move to from each status.
+// Rejected and failed are final; a published video can be archived by its
+// owner and restored.
+var videoTransitions = map[VideoStatus][]VideoStatus{
+	VideoStatusUploading:          {VideoStatusUploaded, VideoStatusFailed},
+	VideoStatusUploaded:           {VideoStatusProcessing, VideoStatusAwaitingModeration, VideoStatusFailed},
+	VideoStatusProcessing:         {VideoStatusAwaitingModeration, VideoStatusFailed},
+	VideoStatusAwaitingModeration: {VideoStatusPublished, VideoStatusRejected, VideoStatusFailed},
+	VideoStatusPublished:          {VideoStatusArchived},
+	VideoStatusArchived:           {VideoStatusPublished},
+}
+
+// CanTransitionTo reports whether a video may move from s to next.
+func (s VideoStatus) CanTransitionTo(next VideoStatus) bool {
+	for _, allowed := range videoTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// VideoStatusChange is a row of a video's status history. FromStatus is
+// empty for the row recording the video's creation.
+type VideoStatusChange struct {
+	ID         int64       `json:"-" db:"id"`
+	VideoID    string      `json:"-" db:"video_id"`
+	FromStatus VideoStatus `json:"from_status,omitempty" db:"from_status"`
+	ToStatus   VideoStatus `json:"to_status" db:"to_status"`
+	Reason     string      `json:"reason,omitempty" db:"reason"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type ThumbnailKind string
+
+const (
+	// ThumbnailKindCandidate is a frame picked while transcoding
+	ThumbnailKindCandidate ThumbnailKind = "candidate"
+	// ThumbnailKindCustom is an image the owner uploaded
+	ThumbnailKindCustom ThumbnailKind = "custom"
+)
+
+// Thumbnail is an image the owner can choose to show for a video. URL is
+// stable; it redirects to the stored image.
+type Thumbnail struct {
+	ID       string        `json:"id" db:"id"`
+	VideoID  string        `json:"-" db:"video_id"`
+	Kind     ThumbnailKind `json:"kind" db:"kind"`
+	ImageKey string        `json:"-" db:"image_key"`
+	URL      string        `json:"url" db:"url"`
+	// OffsetMS is where in the video a candidate was taken from
+	OffsetMS  *int      `json:"offset_ms,omitempty" db:"offset_ms"`
+	Width     int       `json:"width" db:"width"`
+	Height    int       `json:"height" db:"height"`
+	Selected  bool      `json:"selected" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type SelectThumbnailRequest struct {
+	ThumbnailID string `json:"thumbnail_id" binding:"required"`
+}
+
+// VideoImages are the images transcoding makes for a video.
+type VideoImages struct {
+	PosterURL      string
+	SeekPreviewURL string
+	Candidates     []Thumbnail
+}
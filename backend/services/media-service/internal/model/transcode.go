@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+type TranscodeStatus string
+
+const (
+	TranscodeStatusQueued    TranscodeStatus = "queued"
+	TranscodeStatusRunning   TranscodeStatus = "running"
+	TranscodeStatusCompleted TranscodeStatus = "completed"
+	TranscodeStatusFailed    TranscodeStatus = "failed"
+)
+
+// TranscodeSubject is where media-service asks the transcoder to pick up a
+// job. The message only saves waiting for the transcoder's next poll; the
+// job row is what gets processed.
+const TranscodeSubject = "media.transcode.requested"
+
+// TranscodeJob turns a video's upload into HLS renditions. Attempts counts
+// claims, so it also fences a worker whose lease expired out of updating
+// the job.
+type TranscodeJob struct {
+	ID            string          `json:"id" db:"id"`
+	VideoID       string          `json:"video_id" db:"video_id"`
+	Status        TranscodeStatus `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	MaxAttempts   int             `json:"max_attempts" db:"max_attempts"`
+	Progress      int             `json:"progress" db:"progress"`
+	ErrorMsg      *string         `json:"error_msg,omitempty" db:"error_msg"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LockedUntil   *time.Time      `json:"locked_until,omitempty" db:"locked_until"`
+	StartedAt     *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// TranscodeRequest is the TranscodeSubject message.
+type TranscodeRequest struct {
+	JobID   string `json:"job_id"`
+	VideoID string `json:"video_id"`
+}
+
+// Rendition is one HLS variant of a video. Bitrates are the encoder
+// targets; bandwidths are measured from the segments, in bits per second.
+type Rendition struct {
+	ID               string    `json:"-" db:"id"`
+	VideoID          string    `json:"-" db:"video_id"`
+	Name             string    `json:"name" db:"name"`
+	Width            int       `json:"width" db:"width"`
+	Height           int       `json:"height" db:"height"`
+	VideoBitrate     int       `json:"video_bitrate" db:"video_bitrate"`
+	AudioBitrate     int       `json:"audio_bitrate" db:"audio_bitrate"`
+	Bandwidth        int       `json:"bandwidth" db:"bandwidth"`
+	AverageBandwidth int       `json:"average_bandwidth" db:"average_bandwidth"`
+	Codecs           string    `json:"codecs" db:"codecs"`
+	PlaylistKey      string    `json:"-" db:"playlist_key"`
+	SegmentCount     int       `json:"segment_count" db:"segment_count"`
+	SizeBytes        int64     `json:"size_bytes" db:"size_bytes"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+}
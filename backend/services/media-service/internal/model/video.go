@@ -6,51 +6,110 @@ type VideoStatus string
 type UploadStatus string
 
 const (
-	VideoStatusPending    VideoStatus = "pending"
-	VideoStatusProcessing VideoStatus = "processing"
-	VideoStatusReady      VideoStatus = "ready"
-	VideoStatusFailed     VideoStatus = "failed"
-	VideoStatusModerated  VideoStatus = "moderated"
-	VideoStatusRejected   VideoStatus = "rejected"
+	// Video statuses; see VideoStatus.CanTransitionTo for how a video
+	// moves between them
+	VideoStatusUploading          VideoStatus = "uploading"
+	VideoStatusUploaded           VideoStatus = "uploaded"
+	VideoStatusProcessing         VideoStatus = "processing"
+	VideoStatusAwaitingModeration VideoStatus = "awaiting_moderation"
+	VideoStatusPublished          VideoStatus = "published"
+	VideoStatusRejected           VideoStatus = "rejected"
+	VideoStatusFailed             VideoStatus = "failed"
+	VideoStatusArchived           VideoStatus = "archived"
 
 	UploadStatusInitiated  UploadStatus = "initiated"
 	UploadStatusInProgress UploadStatus = "in_progress"
 	UploadStatusCompleted  UploadStatus = "completed"
 	UploadStatusFailed     UploadStatus = "failed"
+	UploadStatusExpired    UploadStatus = "expired"
+)
+
+// Video visibilities. Owners always see their own videos; unlisted videos
+// are left out of every listing and watched through their share link.
+const (
+	VisibilityPublic     = "public"
+	VisibilityScoutsOnly = "scouts_only"
+	VisibilityPrivate    = "private"
+	VisibilityUnlisted   = "unlisted"
 )
 
 type Video struct {
-	ID           string      `json:"id" db:"id"`
-	ProfileID    string      `json:"profile_id" db:"profile_id"`
-	Title        string      `json:"title" db:"title"`
-	Description  string      `json:"description" db:"description"`
-	FileName     string      `json:"file_name" db:"file_name"`
-	FileSize     int64       `json:"file_size" db:"file_size"`
-	MimeType     string      `json:"mime_type" db:"mime_type"`
-	Duration     *int        `json:"duration,omitempty" db:"duration"`
-	ThumbnailURL *string     `json:"thumbnail_url,omitempty" db:"thumbnail_url"`
-	BlobURL      string      `json:"blob_url" db:"blob_url"`
-	StreamURL    string      `json:"stream_url,omitempty" db:"-"`
-	Status       VideoStatus `json:"status" db:"status"`
-	Visibility   string      `json:"visibility" db:"visibility"`
-	ViewCount    int         `json:"view_count" db:"view_count"`
-	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time   `json:"updated_at" db:"updated_at"`
+	ID          string `json:"id" db:"id"`
+	ProfileID   string `json:"profile_id" db:"profile_id"`
+	Title       string `json:"title" db:"title"`
+	Description string `json:"description" db:"description"`
+	FileName    string `json:"file_name" db:"file_name"`
+	FileSize    int64  `json:"file_size" db:"file_size"`
+	MimeType    string `json:"mime_type" db:"mime_type"`
+	Duration    *int   `json:"duration,omitempty" db:"duration"`
+	// Width and Height are as displayed, after Rotation is applied
+	Width      *int     `json:"width,omitempty" db:"width"`
+	Height     *int     `json:"height,omitempty" db:"height"`
+	FrameRate  *float64 `json:"frame_rate,omitempty" db:"frame_rate"`
+	VideoCodec *string  `json:"video_codec,omitempty" db:"video_codec"`
+	AudioCodec *string  `json:"audio_codec,omitempty" db:"audio_codec"`
+	// Bitrate is the file's overall bitrate in bits per second
+	Bitrate  *int64 `json:"bitrate,omitempty" db:"bitrate"`
+	Rotation *int   `json:"rotation,omitempty" db:"rotation"`
+	// RecordedAt is when the file says it was recorded
+	RecordedAt *time.Time `json:"recorded_at,omitempty" db:"recorded_at"`
+	// Metadata holds everything probed from the file
+	Metadata     map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
+	ThumbnailURL *string                `json:"thumbnail_url,omitempty" db:"thumbnail_url"`
+	PosterURL    *string                `json:"poster_url,omitempty" db:"poster_url"`
+	// SeekPreviewURL is a WebVTT track indexing the seek preview sprite
+	SeekPreviewURL *string     `json:"seek_preview_url,omitempty" db:"seek_preview_url"`
+	BlobURL        string      `json:"blob_url" db:"blob_url"`
+	StreamURL      string      `json:"stream_url,omitempty" db:"-"`
+	Status         VideoStatus `json:"status" db:"status"`
+	Visibility     string      `json:"visibility" db:"visibility"`
+	ViewCount      int         `json:"view_count" db:"view_count"`
+	// ShareToken opens an unlisted video's share link; only its owner sees it
+	ShareToken *string `json:"share_token,omitempty" db:"share_token"`
+	// ProfileHidden is set while the owning profile is deactivated or taken down
+	ProfileHidden bool `json:"-" db:"profile_hidden"`
+	// ContentHash is the hex SHA-256 of the uploaded file
+	ContentHash   *string `json:"content_hash,omitempty" db:"content_hash"`
+	FailureReason *string `json:"failure_reason,omitempty" db:"failure_reason"`
+	// HLSMasterKey is the master playlist of the transcoded renditions
+	HLSMasterKey *string     `json:"-" db:"hls_master_key"`
+	Renditions   []Rendition `json:"renditions,omitempty" db:"-"`
+	// ProcessingProgress is the transcoding percentage while processing
+	ProcessingProgress *int      `json:"processing_progress,omitempty" db:"-"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// VideoUpload tracks the transfer of a video's file. For tus uploads Offset
+// is the number of bytes stored so far out of Length, and ExpiresAt is when
+// an unfinished upload is abandoned.
 type VideoUpload struct {
 	ID          string       `json:"id" db:"id"`
 	VideoID     string       `json:"video_id" db:"video_id"`
 	Status      UploadStatus `json:"status" db:"status"`
 	Progress    int          `json:"progress" db:"progress"`
+	Offset      int64        `json:"offset" db:"upload_offset"`
+	Length      *int64       `json:"length,omitempty" db:"upload_length"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty" db:"expires_at"`
 	ErrorMsg    *string      `json:"error_msg,omitempty" db:"error_msg"`
 	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
 	CompletedAt *time.Time   `json:"completed_at,omitempty" db:"completed_at"`
 }
 
+// TusUploadRequest is a tus creation request. Metadata is the decoded
+// Upload-Metadata header; filename is required, filetype, title and
+// description are optional.
+type TusUploadRequest struct {
+	ProfileID string
+	Length    int64
+	Metadata  map[string]string
+}
+
+// VideoUploadRequest starts a presigned URL upload. ProfileID is the
+// caller's, taken from their token.
 type VideoUploadRequest struct {
-	ProfileID   string `json:"profile_id" binding:"required"`
+	ProfileID   string `json:"-"`
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description"`
 	FileName    string `json:"file_name" binding:"required"`
@@ -58,18 +117,22 @@ type VideoUploadRequest struct {
 	MimeType    string `json:"mime_type" binding:"required"`
 }
 
+// VideoUploadResponse tells the client where to upload the file. The
+// request must use UploadMethod and send UploadHeaders as given.
 type VideoUploadResponse struct {
-	VideoID   string    `json:"video_id"`
-	UploadID  string    `json:"upload_id"`
-	UploadURL string    `json:"upload_url"`
-	ExpiresAt time.Time `json:"expires_at"`
-	TestMode  bool      `json:"test_mode"`
+	VideoID       string            `json:"video_id"`
+	UploadID      string            `json:"upload_id"`
+	UploadURL     string            `json:"upload_url"`
+	UploadMethod  string            `json:"upload_method"`
+	UploadHeaders map[string]string `json:"upload_headers,omitempty"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	TestMode      bool              `json:"test_mode"`
 }
 
 type VideoUpdateRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
-	Visibility  string `json:"visibility"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=public scouts_only private unlisted"`
 }
 
 type VideoListResponse struct {
@@ -77,4 +140,67 @@ type VideoListResponse struct {
 	Total  int      `json:"total"`
 	Limit  int      `json:"limit"`
 	Offset int      `json:"offset"`
-}
\ No newline at end of file
+}
+
+// Trust levels are assigned by profile-service and select the UploadLimits
+// that apply to a profile.
+const (
+	TrustLevelNewcomer    = "newcomer"
+	TrustLevelEstablished = "established"
+	TrustLevelVerified    = "verified"
+	TrustLevelPro         = "pro"
+)
+
+// UploadLimits caps the files a profile may upload. Zero means no limit.
+type UploadLimits struct {
+	MaxSize     int64
+	MaxDuration time.Duration
+}
+
+// ProfileInfo is what profile-service's internal API tells media-service
+// about a profile.
+type ProfileInfo struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	TrustLevel string `json:"trust_level"`
+	Status     string `json:"status"`
+	Minor      bool   `json:"minor"`
+}
+
+// Roles are the auth-service roles of callers.
+const (
+	RoleScout   = "scout"
+	RoleAcademy = "academy"
+	RoleAdmin   = "admin"
+)
+
+// Viewer is the caller of a request, from its token. ProfileID and Role
+// are empty for anonymous requests.
+type Viewer struct {
+	ProfileID string
+	Role      string
+}
+
+// CanSeeScoutsOnly reports whether the viewer may watch scouts-only videos:
+// scouts and academies, whose roles may view all videos, and admins.
+func (v Viewer) CanSeeScoutsOnly() bool {
+	return v.Role == RoleScout || v.Role == RoleAcademy || v.Role == RoleAdmin
+}
+
+// Visibilities returns the visibilities of another profile's videos the
+// viewer may find in listings.
+func (v Viewer) Visibilities() []string {
+	visibilities := []string{VisibilityPublic}
+	if v.CanSeeScoutsOnly() {
+		visibilities = append(visibilities, VisibilityScoutsOnly)
+	}
+	return visibilities
+}
+
+// ProfileEvent is the envelope of profile-service events. Only the fields
+// media-service acts on are decoded.
+type ProfileEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	ProfileID string `json:"profile_id"`
+}
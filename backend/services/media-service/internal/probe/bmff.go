@@ -0,0 +1,97 @@
+package probe
+
+import (
+	"io"
+	"time"
+)
+
+// bmffDuration reads the duration from the movie header of an ISO base
+// media file (MP4) or QuickTime movie. The moov box may come before or
+// after the media data; headers of the boxes in between are read to skip
+// them.
+func bmffDuration(r io.ReaderAt, size int64) (time.Duration, error) {
+	moovStart, moovEnd, err := findBox(r, 0, size, "moov")
+	if err != nil {
+		return 0, err
+	}
+	mvhdStart, mvhdEnd, err := findBox(r, moovStart, moovEnd, "mvhd")
+	if err != nil {
+		return 0, err
+	}
+
+	mvhd, err := readAt(r, mvhdStart, int(min(mvhdEnd-mvhdStart, 32)))
+	if err != nil {
+		return 0, err
+	}
+	if len(mvhd) < 20 {
+		return 0, malformed("mvhd box is too short")
+	}
+
+	var timescale, duration uint64
+	switch mvhd[0] {
+	case 0:
+		timescale = readUint(mvhd[12:16])
+		duration = readUint(mvhd[16:20])
+		if duration == 0xFFFFFFFF {
+			return 0, ErrNoDuration
+		}
+	case 1:
+		if len(mvhd) < 32 {
+			return 0, malformed("mvhd box is too short")
+		}
+		timescale = readUint(mvhd[20:24])
+		duration = readUint(mvhd[24:32])
+		if duration == 0xFFFFFFFFFFFFFFFF {
+			return 0, ErrNoDuration
+		}
+	default:
+		return 0, malformed("unknown mvhd version %d", mvhd[0])
+	}
+
+	if timescale == 0 {
+		return 0, malformed("mvhd timescale is zero")
+	}
+	if duration == 0 {
+		return 0, ErrNoDuration
+	}
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second)), nil
+}
+
+// findBox walks the boxes between start and end for the first of type
+// boxType and returns the bounds of its payload.
+func findBox(r io.ReaderAt, start, end int64, boxType string) (int64, int64, error) {
+	off := start
+	for i := 0; i < maxSiblings && off < end; i++ {
+		header, err := readAt(r, off, int(min(end-off, 16)))
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(header) < 8 {
+			return 0, 0, malformed("truncated box header at %d", off)
+		}
+
+		size := int64(readUint(header[0:4]))
+		headerLen := int64(8)
+		switch size {
+		case 0:
+			// The last box may extend to the end of the file
+			size = end - off
+		case 1:
+			if len(header) < 16 {
+				return 0, 0, malformed("truncated box header at %d", off)
+			}
+			size = int64(readUint(header[8:16]))
+			headerLen = 16
+		}
+		if size < headerLen || size > end-off {
+			return 0, 0, malformed("box %q at %d overruns its parent", header[4:8], off)
+		}
+
+		if string(header[4:8]) == boxType {
+			return off + headerLen, off + size, nil
+		}
+		off += size
+	}
+
+	return 0, 0, malformed("no %s box", boxType)
+}
@@ -0,0 +1,74 @@
+package probe
+
+import (
+	"fmt"
+	"strings"
+)
+
+type codec struct {
+	// Name is the codec as ffprobe reports it; Display is shown to owners
+	Name    string
+	Display string
+}
+
+// videoCodecs are the video codecs phones, cameras and editors export.
+var videoCodecs = []codec{
+	{"h264", "H.264"},
+	{"hevc", "HEVC"},
+	{"vp8", "VP8"},
+	{"vp9", "VP9"},
+	{"av1", "AV1"},
+	{"mpeg4", "MPEG-4"},
+}
+
+// audioCodecs leaves out uncompressed PCM, which comes in many sample
+// formats and is always accepted.
+var audioCodecs = []codec{
+	{"aac", "AAC"},
+	{"mp3", "MP3"},
+	{"opus", "Opus"},
+	{"vorbis", "Vorbis"},
+	{"ac3", "AC-3"},
+	{"eac3", "E-AC-3"},
+	{"alac", "ALAC"},
+	{"flac", "FLAC"},
+}
+
+// CheckCodecs returns an error naming the offending codec and the
+// supported ones if a stream of the file uses a codec uploads may not use.
+// The message is written to be shown to the video's owner.
+func CheckCodecs(m *Metadata) error {
+	if !supported(videoCodecs, m.VideoCodec) {
+		return fmt.Errorf("video codec %s is not supported; use one of %s",
+			codecName(m.VideoCodec), listCodecs(videoCodecs))
+	}
+	if m.HasAudio && !supported(audioCodecs, m.AudioCodec) && !strings.HasPrefix(m.AudioCodec, "pcm_") {
+		return fmt.Errorf("audio codec %s is not supported; use PCM or one of %s",
+			codecName(m.AudioCodec), listCodecs(audioCodecs))
+	}
+	return nil
+}
+
+func supported(codecs []codec, name string) bool {
+	for _, c := range codecs {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func codecName(name string) string {
+	if name == "" {
+		return "(unknown)"
+	}
+	return name
+}
+
+func listCodecs(codecs []codec) string {
+	names := make([]string, len(codecs))
+	for i, c := range codecs {
+		names[i] = c.Display
+	}
+	return strings.Join(names, ", ")
+}
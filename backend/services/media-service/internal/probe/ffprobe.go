@@ -0,0 +1,263 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoVideoStream is returned by FFProbe for a file without a video
+// stream.
+var ErrNoVideoStream = errors.New("file has no video stream")
+
+// Metadata describes a file's first video and audio streams. Width and
+// Height are as stored; Rotation is the clockwise rotation a player applies
+// to display it, in degrees. Zero values mean ffprobe did not report the
+// field.
+type Metadata struct {
+	// Container lists the format names ffprobe matched, e.g.
+	// "mov,mp4,m4a,3gp,3g2,mj2"
+	Container string
+	Duration  time.Duration
+	// Bitrate is the overall bitrate in bits per second
+	Bitrate int64
+	// CreationTime is when the file says it was recorded
+	CreationTime time.Time
+
+	Width        int
+	Height       int
+	Rotation     int
+	FrameRate    float64
+	VideoCodec   string
+	VideoProfile string
+	PixelFormat  string
+	VideoBitrate int64
+
+	HasAudio        bool
+	AudioCodec      string
+	AudioChannels   int
+	AudioSampleRate int
+	AudioBitrate    int64
+}
+
+// DisplaySize returns the width and height the video is shown at once
+// rotated.
+func (m *Metadata) DisplaySize() (int, int) {
+	if m.Rotation == 90 || m.Rotation == 270 {
+		return m.Height, m.Width
+	}
+	return m.Width, m.Height
+}
+
+// Map returns the metadata as JSON-friendly values, leaving out fields
+// ffprobe did not report.
+func (m *Metadata) Map() map[string]interface{} {
+	fields := map[string]interface{}{
+		"width":     m.Width,
+		"height":    m.Height,
+		"rotation":  m.Rotation,
+		"has_audio": m.HasAudio,
+	}
+	set := func(key string, value interface{}, ok bool) {
+		if ok {
+			fields[key] = value
+		}
+	}
+	set("container", m.Container, m.Container != "")
+	set("duration_seconds", m.Duration.Seconds(), m.Duration > 0)
+	set("bitrate", m.Bitrate, m.Bitrate > 0)
+	set("creation_time", m.CreationTime.UTC().Format(time.RFC3339), !m.CreationTime.IsZero())
+	set("frame_rate", m.FrameRate, m.FrameRate > 0)
+	set("video_codec", m.VideoCodec, m.VideoCodec != "")
+	set("video_profile", m.VideoProfile, m.VideoProfile != "")
+	set("pixel_format", m.PixelFormat, m.PixelFormat != "")
+	set("video_bitrate", m.VideoBitrate, m.VideoBitrate > 0)
+	set("audio_codec", m.AudioCodec, m.AudioCodec != "")
+	set("audio_channels", m.AudioChannels, m.AudioChannels > 0)
+	set("audio_sample_rate", m.AudioSampleRate, m.AudioSampleRate > 0)
+	set("audio_bitrate", m.AudioBitrate, m.AudioBitrate > 0)
+	return fields
+}
+
+type ffprobeOutput struct {
+	Streams []struct {
+		CodecType    string            `json:"codec_type"`
+		CodecName    string            `json:"codec_name"`
+		Profile      string            `json:"profile"`
+		Width        int               `json:"width"`
+		Height       int               `json:"height"`
+		PixelFormat  string            `json:"pix_fmt"`
+		AvgFrameRate string            `json:"avg_frame_rate"`
+		RFrameRate   string            `json:"r_frame_rate"`
+		Channels     int               `json:"channels"`
+		SampleRate   string            `json:"sample_rate"`
+		BitRate      string            `json:"bit_rate"`
+		Duration     string            `json:"duration"`
+		Tags         map[string]string `json:"tags"`
+		SideData     []ffprobeSideData `json:"side_data_list"`
+	} `json:"streams"`
+	Format struct {
+		FormatName string            `json:"format_name"`
+		Duration   string            `json:"duration"`
+		BitRate    string            `json:"bit_rate"`
+		Tags       map[string]string `json:"tags"`
+	} `json:"format"`
+}
+
+type ffprobeSideData struct {
+	Type     string  `json:"side_data_type"`
+	Rotation float64 `json:"rotation"`
+}
+
+// FFProbe runs the ffprobe binary at ffprobePath on a local file.
+func FFProbe(ctx context.Context, ffprobePath, path string) (*Metadata, error) {
+	cmd := exec.CommandContext(ctx, ffprobePath,
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		path,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var probed ffprobeOutput
+	if err := json.Unmarshal(out, &probed); err != nil {
+		return nil, fmt.Errorf("failed to decode ffprobe output: %w", err)
+	}
+
+	meta := &Metadata{
+		Container:    probed.Format.FormatName,
+		Bitrate:      parseInt(probed.Format.BitRate),
+		CreationTime: creationTime(probed.Format.Tags),
+	}
+	foundVideo := false
+	for _, stream := range probed.Streams {
+		switch stream.CodecType {
+		case "video":
+			// Cover art is stored as a single-frame video stream after
+			// the real one
+			if foundVideo {
+				continue
+			}
+			foundVideo = true
+			meta.Width = stream.Width
+			meta.Height = stream.Height
+			meta.VideoCodec = stream.CodecName
+			meta.VideoProfile = stream.Profile
+			meta.PixelFormat = stream.PixelFormat
+			meta.VideoBitrate = parseInt(stream.BitRate)
+			meta.Rotation = streamRotation(stream.Tags["rotate"], stream.SideData)
+			// The average rate is the real one for variable frame rate
+			// phone recordings; the base rate is the fallback
+			meta.FrameRate = parseRate(stream.AvgFrameRate)
+			if meta.FrameRate == 0 {
+				meta.FrameRate = parseRate(stream.RFrameRate)
+			}
+			if meta.Duration == 0 {
+				meta.Duration = parseSeconds(stream.Duration)
+			}
+			if meta.CreationTime.IsZero() {
+				meta.CreationTime = creationTime(stream.Tags)
+			}
+		case "audio":
+			if !meta.HasAudio {
+				meta.HasAudio = true
+				meta.AudioCodec = stream.CodecName
+				meta.AudioChannels = stream.Channels
+				meta.AudioSampleRate = int(parseInt(stream.SampleRate))
+				meta.AudioBitrate = parseInt(stream.BitRate)
+			}
+		}
+	}
+	if !foundVideo {
+		return nil, ErrNoVideoStream
+	}
+	if d := parseSeconds(probed.Format.Duration); d > 0 {
+		meta.Duration = d
+	}
+
+	return meta, nil
+}
+
+// streamRotation normalises the rotation from the legacy rotate tag or the
+// display matrix to 0, 90, 180 or 270. The display matrix angle is
+// counter-clockwise.
+func streamRotation(tag string, sideData []ffprobeSideData) int {
+	degrees := 0
+	if v, err := strconv.Atoi(tag); err == nil {
+		degrees = v
+	}
+	for _, data := range sideData {
+		if data.Type == "Display Matrix" {
+			degrees = -int(math.Round(data.Rotation))
+		}
+	}
+	degrees = ((degrees % 360) + 360) % 360
+	return (degrees + 45) / 90 % 4 * 90
+}
+
+// creationTime reads the recording time from a format or stream's tags.
+// Files written without one often carry the zero time of their container's
+// epoch, which is ignored.
+func creationTime(tags map[string]string) time.Time {
+	for _, key := range []string{"com.apple.quicktime.creationdate", "creation_time"} {
+		value, ok := tags[key]
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			// QuickTime writes the offset without a colon
+			t, err = time.Parse("2006-01-02T15:04:05-0700", value)
+		}
+		if err == nil && t.After(time.Unix(0, 0)) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseRate parses a frame rate ffprobe gives as a fraction such as
+// "30000/1001", returning 0 for "0/0".
+func parseRate(s string) float64 {
+	num, den, ok := strings.Cut(s, "/")
+	if !ok {
+		den = "1"
+	}
+	n, err1 := strconv.ParseFloat(num, 64)
+	d, err2 := strconv.ParseFloat(den, 64)
+	if err1 != nil || err2 != nil || n <= 0 || d <= 0 {
+		return 0
+	}
+	return math.Round(n/d*1000) / 1000
+}
+
+// parseInt parses one of ffprobe's integer strings, returning 0 for "N/A".
+func parseInt(s string) int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// parseSeconds parses ffprobe's decimal seconds, returning 0 for "N/A".
+func parseSeconds(s string) time.Duration {
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,214 @@
+package probe
+
+import (
+	"io"
+	"math"
+	"time"
+)
+
+// EBML element IDs, with their length markers, used by Matroska and WebM.
+const (
+	ebmlHeaderID    = 0x1A45DFA3
+	ebmlDocTypeID   = 0x4282
+	segmentID       = 0x18538067
+	infoID          = 0x1549A966
+	clusterID       = 0x1F43B675
+	timecodeScaleID = 0x2AD7B1
+	durationID      = 0x4489
+)
+
+const (
+	// unknownSize marks an element whose size was not written, as in live
+	// recordings.
+	unknownSize = -1
+
+	defaultTimecodeScale = 1_000_000
+	maxInfoSize          = 64 << 10
+)
+
+// matroskaDuration reads the duration from the segment Info of a Matroska
+// or WebM file. Info comes before the first cluster, so only the start of
+// the file is read. Browser recordings often leave the duration out.
+func matroskaDuration(r io.ReaderAt, size int64) (time.Duration, error) {
+	id, dataSize, headerLen, err := readElementHeader(r, 0, size)
+	if err != nil {
+		return 0, err
+	}
+	if id != ebmlHeaderID || dataSize == unknownSize {
+		return 0, malformed("missing EBML header")
+	}
+	if dataSize > size-int64(headerLen) {
+		return 0, malformed("EBML header overruns the file")
+	}
+
+	off := int64(headerLen) + dataSize
+	id, dataSize, headerLen, err = readElementHeader(r, off, size)
+	if err != nil {
+		return 0, err
+	}
+	if id != segmentID {
+		return 0, malformed("missing segment")
+	}
+
+	off += int64(headerLen)
+	end := size
+	if dataSize != unknownSize {
+		if dataSize > size-off {
+			return 0, malformed("segment overruns the file")
+		}
+		end = off + dataSize
+	}
+
+	for i := 0; i < maxSiblings && off < end; i++ {
+		id, dataSize, headerLen, err := readElementHeader(r, off, end)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case id == clusterID:
+			return 0, ErrNoDuration
+		case dataSize == unknownSize:
+			return 0, malformed("element %#x at %d has no size", id, off)
+		case dataSize > end-off-int64(headerLen):
+			return 0, malformed("element %#x at %d overruns the segment", id, off)
+		case id == infoID:
+			if dataSize > maxInfoSize {
+				return 0, malformed("segment info is %d bytes", dataSize)
+			}
+			info, err := readAt(r, off+int64(headerLen), int(dataSize))
+			if err != nil {
+				return 0, err
+			}
+			return infoDuration(info)
+		}
+
+		off += int64(headerLen) + dataSize
+	}
+
+	return 0, malformed("no segment info")
+}
+
+// infoDuration reads the duration from the payload of a segment Info
+// element.
+func infoDuration(info []byte) (time.Duration, error) {
+	scale := uint64(defaultTimecodeScale)
+	duration := -1.0
+
+	for len(info) > 0 {
+		id, dataSize, headerLen, err := parseElementHeader(info)
+		if err != nil {
+			return 0, err
+		}
+		if dataSize == unknownSize || dataSize > int64(len(info)-headerLen) {
+			return 0, malformed("segment info element %#x overruns", id)
+		}
+		data := info[headerLen : int64(headerLen)+dataSize]
+
+		switch id {
+		case timecodeScaleID:
+			if len(data) == 0 || len(data) > 8 {
+				return 0, malformed("invalid timecode scale")
+			}
+			scale = readUint(data)
+		case durationID:
+			switch len(data) {
+			case 4:
+				duration = float64(math.Float32frombits(uint32(readUint(data))))
+			case 8:
+				duration = math.Float64frombits(readUint(data))
+			default:
+				return 0, malformed("invalid duration")
+			}
+		}
+
+		info = info[int64(headerLen)+dataSize:]
+	}
+
+	if duration <= 0 || math.IsNaN(duration) || math.IsInf(duration, 0) {
+		return 0, ErrNoDuration
+	}
+	return time.Duration(duration * float64(scale)), nil
+}
+
+// ebmlDocType reads the DocType from the EBML header at the start of
+// header.
+func ebmlDocType(header []byte) (string, error) {
+	_, dataSize, headerLen, err := parseElementHeader(header)
+	if err != nil || dataSize == unknownSize {
+		return "", ErrUnknownContainer
+	}
+
+	payload := header[headerLen:]
+	if int64(len(payload)) > dataSize {
+		payload = payload[:dataSize]
+	}
+	for len(payload) > 0 {
+		id, size, n, err := parseElementHeader(payload)
+		if err != nil || size == unknownSize || size > int64(len(payload)-n) {
+			break
+		}
+		if id == ebmlDocTypeID {
+			return string(payload[n : int64(n)+size]), nil
+		}
+		payload = payload[int64(n)+size:]
+	}
+
+	return "", ErrUnknownContainer
+}
+
+// readElementHeader reads the ID and data size of the element at off, which
+// must start before end.
+func readElementHeader(r io.ReaderAt, off, end int64) (uint64, int64, int, error) {
+	if off >= end {
+		return 0, 0, 0, malformed("element at %d is past the end at %d", off, end)
+	}
+	header, err := readAt(r, off, int(min(end-off, 12)))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return parseElementHeader(header)
+}
+
+// parseElementHeader decodes the variable-length ID and data size at the
+// start of b. IDs keep their length marker; sizes drop it.
+func parseElementHeader(b []byte) (uint64, int64, int, error) {
+	idLen, err := vintLength(b, 4)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	id := readUint(b[:idLen])
+
+	sizeLen, err := vintLength(b[idLen:], 8)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	raw := b[idLen : idLen+sizeLen]
+	mask := uint64(1)<<(7*sizeLen) - 1
+	size := readUint(raw) & mask
+	if size == mask {
+		return id, unknownSize, idLen + sizeLen, nil
+	}
+	if size > math.MaxInt64 {
+		return 0, 0, 0, malformed("element size overflows")
+	}
+
+	return id, int64(size), idLen + sizeLen, nil
+}
+
+// vintLength returns the length of the variable-length integer at the start
+// of b from the position of its marker bit.
+func vintLength(b []byte, maxLen int) (int, error) {
+	if len(b) == 0 {
+		return 0, malformed("truncated element header")
+	}
+	for n := 1; n <= maxLen; n++ {
+		if b[0]&(0x80>>(n-1)) != 0 {
+			if len(b) < n {
+				return 0, malformed("truncated element header")
+			}
+			return n, nil
+		}
+	}
+	return 0, malformed("invalid element header")
+}
@@ -0,0 +1,129 @@
+package probe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// element encodes an EBML element with a one-byte size, or the given raw
+// size bytes when size is non-nil.
+func element(id uint64, size []byte, payload ...[]byte) []byte {
+	var b []byte
+	for shift := 24; shift >= 0; shift -= 8 {
+		if c := byte(id >> shift); c != 0 || len(b) > 0 {
+			b = append(b, c)
+		}
+	}
+	data := bytes.Join(payload, nil)
+	if size == nil {
+		size = []byte{0x80 | byte(len(data))}
+	}
+	b = append(b, size...)
+	return append(b, data...)
+}
+
+func float64Bytes(f float64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, math.Float64bits(f))
+	return b
+}
+
+func TestMatroskaDuration(t *testing.T) {
+	header := element(ebmlHeaderID, nil, element(ebmlDocTypeID, nil, []byte("webm")))
+	info := element(infoID, nil,
+		element(timecodeScaleID, nil, []byte{0x0F, 0x42, 0x40}),
+		element(durationID, nil, float64Bytes(12500)),
+	)
+	segment := element(segmentID, nil, info)
+
+	tests := []struct {
+		name string
+		file []byte
+		want time.Duration
+		err  error
+	}{
+		{
+			name: "valid",
+			file: append(append([]byte{}, header...), segment...),
+			want: 12500 * time.Millisecond,
+		},
+		{
+			name: "unknown-size segment",
+			file: append(append([]byte{}, header...), element(segmentID, []byte{0xFF}, info)...),
+			want: 12500 * time.Millisecond,
+		},
+		{
+			name: "empty file",
+			file: nil,
+			err:  ErrMalformed,
+		},
+		{
+			name: "truncated EBML header",
+			file: header[:3],
+			err:  ErrMalformed,
+		},
+		{
+			name: "truncated after EBML header",
+			file: header,
+			err:  ErrMalformed,
+		},
+		{
+			name: "oversized EBML header",
+			file: element(ebmlHeaderID, []byte{0x40, 0xFF}),
+			err:  ErrMalformed,
+		},
+		{
+			name: "huge EBML header",
+			file: element(ebmlHeaderID, []byte{0x01, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE}),
+			err:  ErrMalformed,
+		},
+		{
+			name: "unknown-size EBML header",
+			file: element(ebmlHeaderID, []byte{0xFF}, element(ebmlDocTypeID, nil, []byte("webm"))),
+			err:  ErrMalformed,
+		},
+		{
+			name: "oversized segment",
+			file: append(append([]byte{}, header...), element(segmentID, []byte{0x40, 0xFF}, info)...),
+			err:  ErrMalformed,
+		},
+		{
+			name: "oversized info",
+			file: append(append([]byte{}, header...),
+				element(segmentID, []byte{0xFF}, element(infoID, []byte{0x01, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE}))...),
+			err: ErrMalformed,
+		},
+		{
+			name: "cluster before info",
+			file: append(append([]byte{}, header...), element(segmentID, nil, element(clusterID, nil))...),
+			err:  ErrNoDuration,
+		},
+		{
+			name: "not EBML",
+			file: []byte("ftypisom"),
+			err:  ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matroskaDuration(bytes.NewReader(tt.file), int64(len(tt.file)))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("duration = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Package probe inspects uploaded video files without decoding them: it
+// recognises the container from its magic bytes and reads the duration the
+// container records, fetching only the bytes it needs. FFProbe reads the
+// stream details of a downloaded file for processing.
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"time"
+)
+
+// Container is a video container format.
+type Container string
+
+const (
+	ContainerMP4  Container = "mp4"
+	ContainerMOV  Container = "mov"
+	ContainerWebM Container = "webm"
+	ContainerMKV  Container = "mkv"
+)
+
+// SniffLength is how many leading bytes of a file Sniff needs.
+const SniffLength = 64
+
+// maxSiblings bounds how many sibling boxes or elements are walked looking
+// for one, so a file of tiny boxes cannot turn probing into thousands of
+// reads.
+const maxSiblings = 256
+
+var (
+	ErrUnknownContainer = errors.New("unrecognised container format")
+	ErrMalformed        = errors.New("malformed container")
+	ErrNoDuration       = errors.New("container does not record a duration")
+)
+
+var containerMimeTypes = map[Container][]string{
+	ContainerMP4:  {"video/mp4", "video/x-m4v"},
+	ContainerMOV:  {"video/quicktime"},
+	ContainerWebM: {"video/webm"},
+	ContainerMKV:  {"video/x-matroska"},
+}
+
+// Matches reports whether mimeType is a type files in c are declared as.
+func (c Container) Matches(mimeType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+	for _, t := range containerMimeTypes[c] {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Sniff identifies the container from the first SniffLength bytes of a
+// file.
+func Sniff(header []byte) (Container, error) {
+	if len(header) >= 8 {
+		switch string(header[4:8]) {
+		case "ftyp":
+			if len(header) < 12 {
+				break
+			}
+			if string(header[8:12]) == "qt  " {
+				return ContainerMOV, nil
+			}
+			return ContainerMP4, nil
+		case "moov", "mdat", "wide", "free", "skip", "pnot":
+			// QuickTime files predating ftyp start with any top-level atom
+			return ContainerMOV, nil
+		}
+	}
+
+	if len(header) >= 4 && readUint(header[:4]) == ebmlHeaderID {
+		docType, err := ebmlDocType(header)
+		if err != nil {
+			return "", err
+		}
+		switch docType {
+		case "webm":
+			return ContainerWebM, nil
+		case "matroska":
+			return ContainerMKV, nil
+		}
+	}
+
+	return "", ErrUnknownContainer
+}
+
+// Duration returns the running time the container records. r holds the
+// whole file of size bytes; only box and element headers and the few
+// fields needed are read. Files that end early fail with ErrMalformed.
+func Duration(r io.ReaderAt, size int64, c Container) (time.Duration, error) {
+	switch c {
+	case ContainerMP4, ContainerMOV:
+		return bmffDuration(r, size)
+	case ContainerWebM, ContainerMKV:
+		return matroskaDuration(r, size)
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnknownContainer, c)
+	}
+}
+
+// readAt reads exactly n bytes at off, or fewer at the end of the file. A
+// negative n means a size field pointed past the end.
+func readAt(r io.ReaderAt, off int64, n int) ([]byte, error) {
+	if n < 0 || off < 0 {
+		return nil, malformed("read of %d bytes at %d is out of range", n, off)
+	}
+	buf := make([]byte, n)
+	read, err := r.ReadAt(buf, off)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return buf[:read], nil
+}
+
+// readUint decodes a big-endian unsigned integer of up to 8 bytes.
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func malformed(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrMalformed, fmt.Sprintf(format, args...))
+}
@@ -2,29 +2,69 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/scouttalent/media-service/internal/model"
 )
 
+var ErrVideoNotFound = errors.New("video not found")
+
+// dbtx is satisfied by both *pgxpool.Pool and pgx.Tx so repository methods
+// can run inside or outside a transaction.
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type MediaRepository struct {
 	pool *pgxpool.Pool
+	db   dbtx
 }
 
 func NewMediaRepository(pool *pgxpool.Pool) *MediaRepository {
-	return &MediaRepository{pool: pool}
+	return &MediaRepository{pool: pool, db: pool}
+}
+
+// InTx runs fn with a repository bound to a single transaction, committing
+// if fn returns nil and rolling back otherwise.
+func (r *MediaRepository) InTx(ctx context.Context, fn func(repo *MediaRepository) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&MediaRepository{pool: r.pool, db: tx}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
+// CreateVideo inserts a video and starts its status history.
 func (r *MediaRepository) CreateVideo(ctx context.Context, video *model.Video) error {
 	query := `
-		INSERT INTO videos (id, profile_id, title, description, blob_url, thumbnail_url, 
-			duration, file_size, mime_type, status, metadata, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		WITH created AS (
+			INSERT INTO videos (id, profile_id, title, description, blob_url, thumbnail_url, 
+				duration, file_size, mime_type, status, metadata, created_at, updated_at, file_name, visibility)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			RETURNING id, status, created_at
+		)
+		INSERT INTO video_status_history (video_id, to_status, created_at)
+		SELECT id, status, created_at FROM created
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := r.db.Exec(ctx, query,
 		video.ID,
 		video.ProfileID,
 		video.Title,
@@ -38,21 +78,26 @@ func (r *MediaRepository) CreateVideo(ctx context.Context, video *model.Video) e
 		video.Metadata,
 		video.CreatedAt,
 		video.UpdatedAt,
+		video.FileName,
+		video.Visibility,
 	)
 
 	return err
 }
 
-func (r *MediaRepository) GetVideoByID(ctx context.Context, id uuid.UUID) (*model.Video, error) {
+func (r *MediaRepository) GetVideoByID(ctx context.Context, id string) (*model.Video, error) {
 	query := `
 		SELECT id, profile_id, title, description, blob_url, thumbnail_url, 
-			duration, file_size, mime_type, status, metadata, created_at, updated_at
+			duration, file_size, mime_type, status, metadata, created_at, updated_at,
+			file_name, content_hash, failure_reason, hls_master_key, poster_url, seek_preview_url,
+			width, height, frame_rate, video_codec, audio_codec, bitrate, rotation, recorded_at,
+			visibility, share_token, profile_hidden
 		FROM videos
 		WHERE id = $1
 	`
 
 	var video model.Video
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := r.db.QueryRow(ctx, query, id).Scan(
 		&video.ID,
 		&video.ProfileID,
 		&video.Title,
@@ -66,26 +111,48 @@ func (r *MediaRepository) GetVideoByID(ctx context.Context, id uuid.UUID) (*mode
 		&video.Metadata,
 		&video.CreatedAt,
 		&video.UpdatedAt,
+		&video.FileName,
+		&video.ContentHash,
+		&video.FailureReason,
+		&video.HLSMasterKey,
+		&video.PosterURL,
+		&video.SeekPreviewURL,
+		&video.Width,
+		&video.Height,
+		&video.FrameRate,
+		&video.VideoCodec,
+		&video.AudioCodec,
+		&video.Bitrate,
+		&video.Rotation,
+		&video.RecordedAt,
+		&video.Visibility,
+		&video.ShareToken,
+		&video.ProfileHidden,
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrVideoNotFound
+		}
 		return nil, err
 	}
 
 	return &video, nil
 }
 
-func (r *MediaRepository) GetVideosByProfileID(ctx context.Context, profileID uuid.UUID, limit, offset int) ([]model.Video, error) {
+func (r *MediaRepository) GetVideosByProfileID(ctx context.Context, profileID string, limit, offset int) ([]model.Video, error) {
 	query := `
 		SELECT id, profile_id, title, description, blob_url, thumbnail_url, 
-			duration, file_size, mime_type, status, metadata, created_at, updated_at
+			duration, file_size, mime_type, status, metadata, created_at, updated_at,
+			file_name, hls_master_key, poster_url, seek_preview_url,
+			width, height, frame_rate, video_codec, audio_codec, bitrate, rotation, recorded_at
 		FROM videos
 		WHERE profile_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, profileID, limit, offset)
+	rows, err := r.db.Query(ctx, query, profileID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +175,18 @@ func (r *MediaRepository) GetVideosByProfileID(ctx context.Context, profileID uu
 			&video.Metadata,
 			&video.CreatedAt,
 			&video.UpdatedAt,
+			&video.FileName,
+			&video.HLSMasterKey,
+			&video.PosterURL,
+			&video.SeekPreviewURL,
+			&video.Width,
+			&video.Height,
+			&video.FrameRate,
+			&video.VideoCodec,
+			&video.AudioCodec,
+			&video.Bitrate,
+			&video.Rotation,
+			&video.RecordedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -118,32 +197,37 @@ func (r *MediaRepository) GetVideosByProfileID(ctx context.Context, profileID uu
 	return videos, rows.Err()
 }
 
-func (r *MediaRepository) CountVideosByProfileID(ctx context.Context, profileID uuid.UUID) (int, error) {
+func (r *MediaRepository) CountVideosByProfileID(ctx context.Context, profileID string) (int, error) {
 	query := `SELECT COUNT(*) FROM videos WHERE profile_id = $1`
 
 	var count int
-	err := r.pool.QueryRow(ctx, query, profileID).Scan(&count)
+	err := r.db.QueryRow(ctx, query, profileID).Scan(&count)
 	return count, err
 }
 
+// UpdateVideo saves a video's details. Its status only changes through
+// TransitionVideo.
 func (r *MediaRepository) UpdateVideo(ctx context.Context, video *model.Video) error {
 	query := `
 		UPDATE videos
 		SET title = $2, description = $3, blob_url = $4, thumbnail_url = $5,
-			duration = $6, status = $7, metadata = $8, updated_at = $9
+			duration = $6, metadata = $7, updated_at = $8, content_hash = $9,
+			visibility = $10, share_token = $11
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := r.db.Exec(ctx, query,
 		video.ID,
 		video.Title,
 		video.Description,
 		video.BlobURL,
 		video.ThumbnailURL,
 		video.Duration,
-		video.Status,
 		video.Metadata,
 		video.UpdatedAt,
+		video.ContentHash,
+		video.Visibility,
+		video.ShareToken,
 	)
 
 	if err != nil {
@@ -157,10 +241,10 @@ func (r *MediaRepository) UpdateVideo(ctx context.Context, video *model.Video) e
 	return nil
 }
 
-func (r *MediaRepository) DeleteVideo(ctx context.Context, id uuid.UUID) error {
+func (r *MediaRepository) DeleteVideo(ctx context.Context, id string) error {
 	query := `DELETE FROM videos WHERE id = $1`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -172,16 +256,17 @@ func (r *MediaRepository) DeleteVideo(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *MediaRepository) CreateUpload(ctx context.Context, upload *model.Upload) error {
+// CreateUpload stores the upload of a presigned URL upload. Its ID doubles
+// as the upload ID.
+func (r *MediaRepository) CreateUpload(ctx context.Context, upload *model.VideoUpload) error {
 	query := `
 		INSERT INTO uploads (id, video_id, upload_id, status, progress, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $1, $3, $4, $5, $6)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := r.db.Exec(ctx, query,
 		upload.ID,
 		upload.VideoID,
-		upload.UploadID,
 		upload.Status,
 		upload.Progress,
 		upload.CreatedAt,
@@ -191,46 +276,89 @@ func (r *MediaRepository) CreateUpload(ctx context.Context, upload *model.Upload
 	return err
 }
 
-func (r *MediaRepository) GetUploadByID(ctx context.Context, id uuid.UUID) (*model.Upload, error) {
+// ListPublicVideosByProfile returns published videos with public
+// visibility, newest first.
+func (r *MediaRepository) ListPublicVideosByProfile(ctx context.Context, profileID string, limit int) ([]*model.Video, error) {
 	query := `
-		SELECT id, video_id, upload_id, status, progress, created_at, updated_at
-		FROM uploads
-		WHERE id = $1
+		SELECT id, profile_id, title, COALESCE(description, ''), thumbnail_url,
+			duration, visibility, created_at
+		FROM videos
+		WHERE profile_id = $1 AND visibility = 'public' AND status = 'published'
+			AND NOT profile_hidden
+		ORDER BY created_at DESC
+		LIMIT $2
 	`
 
-	var upload model.Upload
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&upload.ID,
-		&upload.VideoID,
-		&upload.UploadID,
-		&upload.Status,
-		&upload.Progress,
-		&upload.CreatedAt,
-		&upload.UpdatedAt,
-	)
-
+	rows, err := r.db.Query(ctx, query, profileID, limit)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	videos := []*model.Video{}
+	for rows.Next() {
+		var video model.Video
+		err := rows.Scan(
+			&video.ID,
+			&video.ProfileID,
+			&video.Title,
+			&video.Description,
+			&video.ThumbnailURL,
+			&video.Duration,
+			&video.Visibility,
+			&video.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		videos = append(videos, &video)
+	}
 
-	return &upload, nil
+	return videos, rows.Err()
 }
 
-func (r *MediaRepository) UpdateUploadProgress(ctx context.Context, id uuid.UUID, progress int, status model.VideoStatus) error {
+// SetProfileVideosHidden hides or shows every video of a profile.
+func (r *MediaRepository) SetProfileVideosHidden(ctx context.Context, profileID string, hidden bool) error {
 	query := `
-		UPDATE uploads
-		SET progress = $2, status = $3, updated_at = NOW()
-		WHERE id = $1
+		UPDATE videos
+		SET profile_hidden = $2, updated_at = NOW()
+		WHERE profile_id = $1 AND profile_hidden <> $2
 	`
 
-	result, err := r.pool.Exec(ctx, query, id, progress, status)
+	_, err := r.db.Exec(ctx, query, profileID, hidden)
+	return err
+}
+
+// ListVideoFilesByProfile returns the ID, title and file name of every
+// video of a profile, for removing their blobs and announcing their
+// deletion.
+func (r *MediaRepository) ListVideoFilesByProfile(ctx context.Context, profileID string) ([]*model.Video, error) {
+	query := `SELECT id, profile_id, title, file_name FROM videos WHERE profile_id = $1`
+
+	rows, err := r.db.Query(ctx, query, profileID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("upload not found")
+	videos := []*model.Video{}
+	for rows.Next() {
+		var video model.Video
+		if err := rows.Scan(&video.ID, &video.ProfileID, &video.Title, &video.FileName); err != nil {
+			return nil, err
+		}
+		videos = append(videos, &video)
 	}
 
-	return nil
-}
\ No newline at end of file
+	return videos, rows.Err()
+}
+
+// DeleteVideosByProfile removes every video of a profile. Uploads cascade.
+func (r *MediaRepository) DeleteVideosByProfile(ctx context.Context, profileID string) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM videos WHERE profile_id = $1`, profileID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
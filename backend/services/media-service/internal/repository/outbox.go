@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+// EnqueueVideoEvent writes an event about video to the outbox. Call it on a
+// transaction-bound repository (see InTx) so the event commits or rolls
+// back with the change.
+func (r *MediaRepository) EnqueueVideoEvent(ctx context.Context, eventType string, video *model.Video) error {
+	now := time.Now().UTC()
+	event := &model.VideoEvent{
+		ID:        uuid.New().String(),
+		EventType: eventType,
+		Version:   model.EventSchemaVersion,
+		VideoID:   video.ID,
+		ProfileID: video.ProfileID,
+		Title:     video.Title,
+		Status:    video.Status,
+		Timestamp: now.Unix(),
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox_events (event_id, subject, aggregate_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err = r.db.Exec(ctx, query, event.ID, eventType, video.ID, payload, now)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue event: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxEvents locks up to limit unpublished events in insertion order.
+// Rows locked by another relay instance are skipped.
+func (r *MediaRepository) ClaimOutboxEvents(ctx context.Context, limit int) ([]*model.OutboxEvent, error) {
+	query := `
+		SELECT id, event_id, subject, payload, attempts
+		FROM outbox_events
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*model.OutboxEvent{}
+	for rows.Next() {
+		var e model.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.EventID, &e.Subject, &e.Payload, &e.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *MediaRepository) MarkOutboxEventsPublished(ctx context.Context, ids []int64, publishedAt time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE outbox_events SET published_at = $1, last_error = NULL WHERE id = ANY($2)`,
+		publishedAt, ids,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox events published: %w", err)
+	}
+	return nil
+}
+
+func (r *MediaRepository) MarkOutboxEventFailed(ctx context.Context, id int64, cause error) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE outbox_events SET attempts = attempts + 1, last_error = $1 WHERE id = $2`,
+		cause.Error(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// PurgePublishedEvents deletes events published before the given time.
+func (r *MediaRepository) PurgePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM outbox_events WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+// ErrInvalidTransition is returned for a status change the state machine
+// does not allow.
+var ErrInvalidTransition = errors.New("invalid video status transition")
+
+// TransitionVideo moves a video from status from to status to and records
+// the change in its history. It compares and sets: it reports false,
+// changing nothing, when the video is gone or no longer in from. reason is
+// kept in the history, and as the failure_reason shown to the owner when
+// the video fails or is rejected.
+func (r *MediaRepository) TransitionVideo(ctx context.Context, videoID string, from, to model.VideoStatus, reason string) (bool, error) {
+	if !from.CanTransitionTo(to) {
+		return false, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+
+	query := `
+		WITH changed AS (
+			UPDATE videos
+			SET status = $3::VARCHAR,
+				failure_reason = CASE WHEN $3::VARCHAR IN ('failed', 'rejected') THEN NULLIF($4::TEXT, '') ELSE failure_reason END,
+				updated_at = NOW()
+			WHERE id = $1 AND status = $2::VARCHAR
+			RETURNING id
+		)
+		INSERT INTO video_status_history (video_id, from_status, to_status, reason)
+		SELECT id, $2::VARCHAR, $3::VARCHAR, NULLIF($4::TEXT, '') FROM changed
+	`
+
+	tag, err := r.db.Exec(ctx, query, videoID, from, to, reason)
+	if err != nil {
+		return false, fmt.Errorf("failed to update video status: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListVideoStatusHistory returns a video's status changes, oldest first.
+func (r *MediaRepository) ListVideoStatusHistory(ctx context.Context, videoID string) ([]model.VideoStatusChange, error) {
+	query := `
+		SELECT id, video_id, COALESCE(from_status, ''), to_status, COALESCE(reason, ''), created_at
+		FROM video_status_history
+		WHERE video_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.Query(ctx, query, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []model.VideoStatusChange{}
+	for rows.Next() {
+		var c model.VideoStatusChange
+		if err := rows.Scan(&c.ID, &c.VideoID, &c.FromStatus, &c.ToStatus, &c.Reason, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
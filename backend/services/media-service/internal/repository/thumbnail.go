@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+var ErrThumbnailNotFound = errors.New("thumbnail not found")
+
+const thumbnailColumns = `id, video_id, kind, image_key, url, offset_ms, width, height, created_at`
+
+func scanThumbnail(row pgx.Row) (*model.Thumbnail, error) {
+	var thumbnail model.Thumbnail
+	err := row.Scan(
+		&thumbnail.ID,
+		&thumbnail.VideoID,
+		&thumbnail.Kind,
+		&thumbnail.ImageKey,
+		&thumbnail.URL,
+		&thumbnail.OffsetMS,
+		&thumbnail.Width,
+		&thumbnail.Height,
+		&thumbnail.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &thumbnail, nil
+}
+
+func (r *MediaRepository) CreateThumbnail(ctx context.Context, thumbnail *model.Thumbnail) error {
+	query := `
+		INSERT INTO video_thumbnails (video_id, kind, image_key, url, offset_ms, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		thumbnail.VideoID,
+		thumbnail.Kind,
+		thumbnail.ImageKey,
+		thumbnail.URL,
+		thumbnail.OffsetMS,
+		thumbnail.Width,
+		thumbnail.Height,
+	).Scan(&thumbnail.ID, &thumbnail.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create thumbnail: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceThumbnailCandidates stores a video's candidate thumbnails in place
+// of any it had. Uploaded thumbnails are kept.
+func (r *MediaRepository) ReplaceThumbnailCandidates(ctx context.Context, videoID string, candidates []model.Thumbnail) error {
+	query := `DELETE FROM video_thumbnails WHERE video_id = $1 AND kind = 'candidate'`
+	if _, err := r.db.Exec(ctx, query, videoID); err != nil {
+		return fmt.Errorf("failed to delete thumbnail candidates: %w", err)
+	}
+
+	for i := range candidates {
+		candidates[i].VideoID = videoID
+		candidates[i].Kind = model.ThumbnailKindCandidate
+		if err := r.CreateThumbnail(ctx, &candidates[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteCustomThumbnails removes a video's uploaded thumbnails and returns
+// them so their images can be deleted.
+func (r *MediaRepository) DeleteCustomThumbnails(ctx context.Context, videoID string) ([]model.Thumbnail, error) {
+	query := `
+		DELETE FROM video_thumbnails
+		WHERE video_id = $1 AND kind = 'custom'
+		RETURNING ` + thumbnailColumns
+
+	rows, err := r.db.Query(ctx, query, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete custom thumbnails: %w", err)
+	}
+	defer rows.Close()
+
+	return collectThumbnails(rows)
+}
+
+// ListThumbnails returns a video's thumbnails, candidates in video order
+// and then any uploaded one.
+func (r *MediaRepository) ListThumbnails(ctx context.Context, videoID string) ([]model.Thumbnail, error) {
+	query := `
+		SELECT ` + thumbnailColumns + `
+		FROM video_thumbnails
+		WHERE video_id = $1
+		ORDER BY kind, offset_ms, created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list thumbnails: %w", err)
+	}
+	defer rows.Close()
+
+	return collectThumbnails(rows)
+}
+
+func (r *MediaRepository) GetThumbnail(ctx context.Context, videoID, id string) (*model.Thumbnail, error) {
+	query := `SELECT ` + thumbnailColumns + ` FROM video_thumbnails WHERE id = $1 AND video_id = $2`
+
+	thumbnail, err := scanThumbnail(r.db.QueryRow(ctx, query, id, videoID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrThumbnailNotFound
+		}
+		return nil, fmt.Errorf("failed to get thumbnail: %w", err)
+	}
+
+	return thumbnail, nil
+}
+
+// SetVideoThumbnail shows url as the video's thumbnail.
+func (r *MediaRepository) SetVideoThumbnail(ctx context.Context, videoID, url string) error {
+	query := `UPDATE videos SET thumbnail_url = $2, updated_at = NOW() WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, videoID, url); err != nil {
+		return fmt.Errorf("failed to set video thumbnail: %w", err)
+	}
+
+	return nil
+}
+
+// SetVideoImages records the images transcoding made. The first candidate
+// becomes the thumbnail unless the owner already chose one.
+func (r *MediaRepository) SetVideoImages(ctx context.Context, videoID string, images *model.VideoImages) error {
+	var thumbnailURL *string
+	if len(images.Candidates) > 0 {
+		thumbnailURL = &images.Candidates[0].URL
+	}
+
+	query := `
+		UPDATE videos
+		SET poster_url = $2, seek_preview_url = NULLIF($3, ''),
+			thumbnail_url = COALESCE(thumbnail_url, $4), updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, videoID, images.PosterURL, images.SeekPreviewURL, thumbnailURL); err != nil {
+		return fmt.Errorf("failed to set video images: %w", err)
+	}
+
+	return nil
+}
+
+func collectThumbnails(rows pgx.Rows) ([]model.Thumbnail, error) {
+	thumbnails := []model.Thumbnail{}
+	for rows.Next() {
+		thumbnail, err := scanThumbnail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan thumbnail: %w", err)
+		}
+		thumbnails = append(thumbnails, *thumbnail)
+	}
+	return thumbnails, rows.Err()
+}
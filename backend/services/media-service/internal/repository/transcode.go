@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+var ErrTranscodeJobNotFound = errors.New("transcode job not found")
+
+const transcodeJobColumns = `id, video_id, status, attempts, max_attempts, progress,
+	error_msg, next_attempt_at, locked_until, started_at, completed_at, created_at, updated_at`
+
+// transcodeJobDue matches jobs a worker may claim: queued jobs whose
+// backoff has passed and running jobs whose worker stopped renewing its
+// lease.
+const transcodeJobDue = `((status = 'queued' AND next_attempt_at <= NOW())
+	OR (status = 'running' AND locked_until < NOW()))`
+
+func scanTranscodeJob(row pgx.Row) (*model.TranscodeJob, error) {
+	var job model.TranscodeJob
+	err := row.Scan(
+		&job.ID,
+		&job.VideoID,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.Progress,
+		&job.ErrorMsg,
+		&job.NextAttemptAt,
+		&job.LockedUntil,
+		&job.StartedAt,
+		&job.CompletedAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// EnqueueTranscodeJob queues the video for transcoding, restarting any
+// earlier job for it. It returns the job's ID.
+func (r *MediaRepository) EnqueueTranscodeJob(ctx context.Context, videoID string, maxAttempts int) (string, error) {
+	query := `
+		INSERT INTO transcode_jobs (video_id, status, max_attempts, next_attempt_at)
+		VALUES ($1, 'queued', $2, NOW())
+		ON CONFLICT (video_id) DO UPDATE
+		SET status = 'queued', attempts = 0, max_attempts = EXCLUDED.max_attempts,
+			progress = 0, error_msg = NULL, next_attempt_at = NOW(), locked_until = NULL,
+			started_at = NULL, completed_at = NULL, updated_at = NOW()
+		RETURNING id
+	`
+
+	var id string
+	if err := r.db.QueryRow(ctx, query, videoID, maxAttempts).Scan(&id); err != nil {
+		return "", fmt.Errorf("failed to enqueue transcode job: %w", err)
+	}
+
+	return id, nil
+}
+
+// ClaimTranscodeJob marks a due job running until lockedUntil and counts
+// the attempt. It returns nil when the job is not due, e.g. because another
+// worker claimed it.
+func (r *MediaRepository) ClaimTranscodeJob(ctx context.Context, id string, lockedUntil time.Time) (*model.TranscodeJob, error) {
+	query := `
+		UPDATE transcode_jobs
+		SET status = 'running', attempts = attempts + 1, progress = 0,
+			locked_until = $2, started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+		WHERE id = $1 AND ` + transcodeJobDue + `
+		RETURNING ` + transcodeJobColumns
+
+	job, err := scanTranscodeJob(r.db.QueryRow(ctx, query, id, lockedUntil))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim transcode job: %w", err)
+	}
+
+	return job, nil
+}
+
+// UpdateTranscodeProgress records progress and renews the lease of a
+// running attempt. It reports false when the attempt no longer holds the
+// job.
+func (r *MediaRepository) UpdateTranscodeProgress(ctx context.Context, id string, attempt, progress int, lockedUntil time.Time) (bool, error) {
+	query := `
+		UPDATE transcode_jobs
+		SET progress = $3, locked_until = $4, updated_at = NOW()
+		WHERE id = $1 AND attempts = $2 AND status = 'running'
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, attempt, progress, lockedUntil)
+	if err != nil {
+		return false, fmt.Errorf("failed to update transcode progress: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RetryTranscodeJob queues a failed attempt to run again at nextAttemptAt.
+func (r *MediaRepository) RetryTranscodeJob(ctx context.Context, id string, attempt int, errorMsg string, nextAttemptAt time.Time) (bool, error) {
+	query := `
+		UPDATE transcode_jobs
+		SET status = 'queued', error_msg = $3, next_attempt_at = $4,
+			locked_until = NULL, updated_at = NOW()
+		WHERE id = $1 AND attempts = $2 AND status = 'running'
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, attempt, errorMsg, nextAttemptAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to retry transcode job: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ReleaseTranscodeJob hands a running attempt back without counting it,
+// for a worker that is shutting down.
+func (r *MediaRepository) ReleaseTranscodeJob(ctx context.Context, id string, attempt int) error {
+	query := `
+		UPDATE transcode_jobs
+		SET status = 'queued', attempts = attempts - 1, next_attempt_at = NOW(),
+			locked_until = NULL, updated_at = NOW()
+		WHERE id = $1 AND attempts = $2 AND status = 'running'
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, attempt); err != nil {
+		return fmt.Errorf("failed to release transcode job: %w", err)
+	}
+
+	return nil
+}
+
+// FinishTranscodeJob ends a running attempt as completed or failed. It
+// reports false when the attempt no longer holds the job.
+func (r *MediaRepository) FinishTranscodeJob(ctx context.Context, id string, attempt int, status model.TranscodeStatus, errorMsg *string) (bool, error) {
+	query := `
+		UPDATE transcode_jobs
+		SET status = $3, error_msg = $4, locked_until = NULL, updated_at = NOW(),
+			progress = CASE WHEN $3::VARCHAR = 'completed' THEN 100 ELSE progress END,
+			completed_at = NOW()
+		WHERE id = $1 AND attempts = $2 AND status = 'running'
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, attempt, status, errorMsg)
+	if err != nil {
+		return false, fmt.Errorf("failed to finish transcode job: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListDueTranscodeJobs returns the IDs of jobs a worker may claim, oldest
+// first.
+func (r *MediaRepository) ListDueTranscodeJobs(ctx context.Context, limit int) ([]string, error) {
+	query := `
+		SELECT id FROM transcode_jobs
+		WHERE ` + transcodeJobDue + `
+		ORDER BY next_attempt_at
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due transcode jobs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan transcode job: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *MediaRepository) GetTranscodeJobByVideo(ctx context.Context, videoID string) (*model.TranscodeJob, error) {
+	query := `SELECT ` + transcodeJobColumns + ` FROM transcode_jobs WHERE video_id = $1`
+
+	job, err := scanTranscodeJob(r.db.QueryRow(ctx, query, videoID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTranscodeJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get transcode job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ReplaceRenditions stores a video's renditions in place of any it had.
+func (r *MediaRepository) ReplaceRenditions(ctx context.Context, videoID string, renditions []model.Rendition) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM video_renditions WHERE video_id = $1`, videoID); err != nil {
+		return fmt.Errorf("failed to delete renditions: %w", err)
+	}
+
+	query := `
+		INSERT INTO video_renditions (video_id, name, width, height, video_bitrate, audio_bitrate,
+			bandwidth, average_bandwidth, codecs, playlist_key, segment_count, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	for _, rendition := range renditions {
+		_, err := r.db.Exec(ctx, query,
+			videoID,
+			rendition.Name,
+			rendition.Width,
+			rendition.Height,
+			rendition.VideoBitrate,
+			rendition.AudioBitrate,
+			rendition.Bandwidth,
+			rendition.AverageBandwidth,
+			rendition.Codecs,
+			rendition.PlaylistKey,
+			rendition.SegmentCount,
+			rendition.SizeBytes,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create rendition %s: %w", rendition.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ListRenditions returns a video's renditions, lowest resolution first.
+func (r *MediaRepository) ListRenditions(ctx context.Context, videoID string) ([]model.Rendition, error) {
+	query := `
+		SELECT id, video_id, name, width, height, video_bitrate, audio_bitrate, bandwidth,
+			average_bandwidth, codecs, playlist_key, segment_count, size_bytes, created_at
+		FROM video_renditions
+		WHERE video_id = $1
+		ORDER BY bandwidth
+	`
+
+	rows, err := r.db.Query(ctx, query, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list renditions: %w", err)
+	}
+	defer rows.Close()
+
+	renditions := []model.Rendition{}
+	for rows.Next() {
+		var rendition model.Rendition
+		err := rows.Scan(
+			&rendition.ID,
+			&rendition.VideoID,
+			&rendition.Name,
+			&rendition.Width,
+			&rendition.Height,
+			&rendition.VideoBitrate,
+			&rendition.AudioBitrate,
+			&rendition.Bandwidth,
+			&rendition.AverageBandwidth,
+			&rendition.Codecs,
+			&rendition.PlaylistKey,
+			&rendition.SegmentCount,
+			&rendition.SizeBytes,
+			&rendition.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rendition: %w", err)
+		}
+		renditions = append(renditions, rendition)
+	}
+
+	return renditions, rows.Err()
+}
+
+// SetVideoStream points a video at the HLS master playlist at masterKey.
+func (r *MediaRepository) SetVideoStream(ctx context.Context, videoID, masterKey string) error {
+	query := `UPDATE videos SET hls_master_key = $2, updated_at = NOW() WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, videoID, masterKey); err != nil {
+		return fmt.Errorf("failed to update video stream: %w", err)
+	}
+
+	return nil
+}
+
+// SetVideoMetadata stores what was probed from a video's file: its
+// duration, the typed stream fields and the full metadata.
+func (r *MediaRepository) SetVideoMetadata(ctx context.Context, video *model.Video) error {
+	query := `
+		UPDATE videos
+		SET duration = $2, width = $3, height = $4, frame_rate = $5,
+			video_codec = $6, audio_codec = $7, bitrate = $8, rotation = $9,
+			recorded_at = $10, metadata = $11, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		video.ID,
+		video.Duration,
+		video.Width,
+		video.Height,
+		video.FrameRate,
+		video.VideoCodec,
+		video.AudioCodec,
+		video.Bitrate,
+		video.Rotation,
+		video.RecordedAt,
+		video.Metadata,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update video metadata: %w", err)
+	}
+
+	return nil
+}
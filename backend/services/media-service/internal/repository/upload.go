@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+var ErrUploadNotFound = errors.New("upload not found")
+
+const uploadColumns = `id, video_id, status, progress, upload_offset, upload_length,
+	expires_at, error_msg, created_at, updated_at, completed_at`
+
+func scanUpload(row pgx.Row) (*model.VideoUpload, error) {
+	var upload model.VideoUpload
+	err := row.Scan(
+		&upload.ID,
+		&upload.VideoID,
+		&upload.Status,
+		&upload.Progress,
+		&upload.Offset,
+		&upload.Length,
+		&upload.ExpiresAt,
+		&upload.ErrorMsg,
+		&upload.CreatedAt,
+		&upload.UpdatedAt,
+		&upload.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// CreateTusUpload stores a new tus upload. Its ID doubles as the tus upload
+// ID.
+func (r *MediaRepository) CreateTusUpload(ctx context.Context, upload *model.VideoUpload) error {
+	query := `
+		INSERT INTO uploads (id, video_id, upload_id, status, progress, upload_offset,
+			upload_length, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $1, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		upload.ID,
+		upload.VideoID,
+		upload.Status,
+		upload.Progress,
+		upload.Offset,
+		upload.Length,
+		upload.ExpiresAt,
+		upload.CreatedAt,
+		upload.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create upload: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MediaRepository) GetUpload(ctx context.Context, id string) (*model.VideoUpload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE id = $1`
+
+	upload, err := scanUpload(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+
+	return upload, nil
+}
+
+// AdvanceUpload moves an active upload's offset from from to to and pushes
+// its expiry back. It reports false when the offset was no longer from,
+// i.e. another request stored a chunk first.
+func (r *MediaRepository) AdvanceUpload(ctx context.Context, id string, from, to int64, progress int, expiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE uploads
+		SET upload_offset = $3, progress = $4, expires_at = $5,
+			status = 'in_progress', updated_at = NOW()
+		WHERE id = $1 AND upload_offset = $2 AND status IN ('initiated', 'in_progress')
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, from, to, progress, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance upload: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetUploadStatus ends an upload as completed, failed or expired.
+func (r *MediaRepository) SetUploadStatus(ctx context.Context, id string, status model.UploadStatus, errorMsg *string) error {
+	query := `
+		UPDATE uploads
+		SET status = $2, error_msg = $3, updated_at = NOW(),
+			completed_at = CASE WHEN $2 = 'completed' THEN NOW() ELSE completed_at END
+		WHERE id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, status, errorMsg)
+	if err != nil {
+		return fmt.Errorf("failed to update upload status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUploadNotFound
+	}
+
+	return nil
+}
+
+// ListStaleUploads returns unfinished tus uploads whose expiry has passed,
+// oldest first.
+func (r *MediaRepository) ListStaleUploads(ctx context.Context, now time.Time, limit int) ([]*model.VideoUpload, error) {
+	query := `
+		SELECT ` + uploadColumns + `
+		FROM uploads
+		WHERE status IN ('initiated', 'in_progress') AND expires_at < $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale uploads: %w", err)
+	}
+	defer rows.Close()
+
+	uploads := []*model.VideoUpload{}
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, rows.Err()
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+// ListVideosByProfile returns a page of a profile's videos, newest first,
+// and how many there are in all. With nil visibilities it lists every video
+// for the profile's owner; otherwise only published videos with one of the
+// visibilities that are not hidden with their profile.
+func (r *MediaRepository) ListVideosByProfile(ctx context.Context, profileID string, visibilities []string, limit, offset int) ([]*model.Video, int, error) {
+	where := `profile_id = $1`
+	args := []any{profileID}
+	if visibilities != nil {
+		where += ` AND visibility = ANY($2) AND status = 'published' AND NOT profile_hidden`
+		args = append(args, visibilities)
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM videos WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count videos: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, profile_id, title, COALESCE(description, ''), blob_url, thumbnail_url,
+			duration, file_size, mime_type, status, metadata, created_at, updated_at,
+			file_name, failure_reason, hls_master_key, poster_url, seek_preview_url,
+			width, height, frame_rate, video_codec, audio_codec, bitrate, rotation, recorded_at,
+			visibility, share_token, profile_hidden
+		FROM videos
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list videos: %w", err)
+	}
+	defer rows.Close()
+
+	videos := []*model.Video{}
+	for rows.Next() {
+		var video model.Video
+		err := rows.Scan(
+			&video.ID,
+			&video.ProfileID,
+			&video.Title,
+			&video.Description,
+			&video.BlobURL,
+			&video.ThumbnailURL,
+			&video.Duration,
+			&video.FileSize,
+			&video.MimeType,
+			&video.Status,
+			&video.Metadata,
+			&video.CreatedAt,
+			&video.UpdatedAt,
+			&video.FileName,
+			&video.FailureReason,
+			&video.HLSMasterKey,
+			&video.PosterURL,
+			&video.SeekPreviewURL,
+			&video.Width,
+			&video.Height,
+			&video.FrameRate,
+			&video.VideoCodec,
+			&video.AudioCodec,
+			&video.Bitrate,
+			&video.Rotation,
+			&video.RecordedAt,
+			&video.Visibility,
+			&video.ShareToken,
+			&video.ProfileHidden,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan video: %w", err)
+		}
+		videos = append(videos, &video)
+	}
+
+	return videos, total, rows.Err()
+}
+
+// GetVideoByShareToken returns the video a share link opens.
+func (r *MediaRepository) GetVideoByShareToken(ctx context.Context, token string) (*model.Video, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `SELECT id FROM videos WHERE share_token = $1`, token).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrVideoNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared video: %w", err)
+	}
+
+	return r.GetVideoByID(ctx, id)
+}
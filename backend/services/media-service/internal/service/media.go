@@ -2,39 +2,84 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/scouttalent/services/media-service/internal/model"
-	"github.com/scouttalent/services/media-service/internal/repository"
-	"github.com/scouttalent/services/media-service/internal/storage"
+	"github.com/nats-io/nats.go"
+	"github.com/scouttalent/media-service/internal/client"
+	"github.com/scouttalent/media-service/internal/hls"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/storage"
 )
 
 type MediaService struct {
-	repo    *repository.MediaRepository
-	storage *storage.BlobStorage
+	repo                 *repository.MediaRepository
+	storage              storage.Backend
+	nats                 *nats.Conn
+	profiles             *client.ProfileClient
+	limits               map[string]model.UploadLimits
+	transcode            bool
+	transcodeMaxAttempts int
+	streamBaseURL        string
+	streams              *hls.Signer
 }
 
-func NewMediaService(repo *repository.MediaRepository, storage *storage.BlobStorage) *MediaService {
+// Options configure how MediaService checks, processes and serves videos.
+type Options struct {
+	UploadLimits map[string]model.UploadLimits
+	// Transcode hands completed uploads to the transcoder. Without it they
+	// are ready at once and streamed as uploaded.
+	Transcode            bool
+	TranscodeMaxAttempts int
+	// StreamBaseURL is where clients reach this service for HLS playlists,
+	// which are signed with StreamSigningKey.
+	StreamBaseURL    string
+	StreamSigningKey string
+}
+
+func NewMediaService(repo *repository.MediaRepository, storage storage.Backend, nc *nats.Conn, profiles *client.ProfileClient, opts Options) *MediaService {
 	return &MediaService{
-		repo:    repo,
-		storage: storage,
+		repo:                 repo,
+		storage:              storage,
+		nats:                 nc,
+		profiles:             profiles,
+		limits:               opts.UploadLimits,
+		transcode:            opts.Transcode,
+		transcodeMaxAttempts: opts.TranscodeMaxAttempts,
+		streamBaseURL:        strings.TrimSuffix(opts.StreamBaseURL, "/"),
+		streams:              hls.NewSigner(opts.StreamSigningKey),
 	}
 }
 
 // InitiateUpload creates a new video record and returns upload URL
 func (s *MediaService) InitiateUpload(ctx context.Context, req *model.VideoUploadRequest) (*model.VideoUploadResponse, error) {
+	fileName, ok := storage.BaseFileName(req.FileName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not a file name", ErrInvalidUpload, req.FileName)
+	}
+	if err := s.checkUploadSize(ctx, req.ProfileID, req.FileSize); err != nil {
+		return nil, err
+	}
+	visibility, err := s.defaultVisibility(ctx, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create video record
 	video := &model.Video{
 		ID:          uuid.New().String(),
 		ProfileID:   req.ProfileID,
 		Title:       req.Title,
 		Description: req.Description,
-		FileName:    req.FileName,
+		FileName:    fileName,
 		FileSize:    req.FileSize,
 		MimeType:    req.MimeType,
-		Status:      model.VideoStatusPending,
+		Status:      model.VideoStatusUploading,
+		Visibility:  visibility,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -58,117 +103,171 @@ func (s *MediaService) InitiateUpload(ctx context.Context, req *model.VideoUploa
 	}
 
 	// Generate upload URL
-	uploadURL, err := s.storage.GenerateUploadURL(ctx, video.ID, req.FileName)
+	presigned, err := s.storage.PresignUpload(ctx, storage.VideoKey(video.ID, video.FileName), storage.PresignOptions{
+		ContentType:   req.MimeType,
+		ContentLength: req.FileSize,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate upload URL: %w", err)
 	}
 
-	// Check if we're in test mode
-	testMode := s.storage.IsTestMode()
-
 	return &model.VideoUploadResponse{
-		VideoID:   video.ID,
-		UploadID:  upload.ID,
-		UploadURL: uploadURL,
-		ExpiresAt: time.Now().Add(1 * time.Hour),
-		TestMode:  testMode,
+		VideoID:       video.ID,
+		UploadID:      upload.ID,
+		UploadURL:     presigned.URL,
+		UploadMethod:  presigned.Method,
+		UploadHeaders: presigned.Headers,
+		ExpiresAt:     presigned.ExpiresAt,
+		TestMode:      s.isTestMode(),
 	}, nil
 }
 
-// UpdateUploadProgress updates the upload progress
-func (s *MediaService) UpdateUploadProgress(ctx context.Context, uploadID string, progress int) error {
-	upload, err := s.repo.GetUploadByID(ctx, uploadID)
+// CompleteUpload completes the upload of one of the owner's videos.
+func (s *MediaService) CompleteUpload(ctx context.Context, profileID, videoID string) error {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
 	if err != nil {
-		return fmt.Errorf("failed to get upload: %w", err)
+		return err
 	}
+	return s.completeUpload(ctx, video)
+}
 
-	upload.Progress = progress
-	upload.UpdatedAt = time.Now()
-
-	if progress >= 100 {
-		upload.Status = model.UploadStatusCompleted
-		upload.CompletedAt = &upload.UpdatedAt
-	} else {
-		upload.Status = model.UploadStatusInProgress
+// completeUpload verifies the uploaded file and queues the video for
+// transcoding. A file that fails verification fails the video with
+// ErrUploadRejected.
+func (s *MediaService) completeUpload(ctx context.Context, video *model.Video) error {
+	if video.Status != model.VideoStatusUploading {
+		return fmt.Errorf("%w: video is %s", ErrInvalidStatus, video.Status)
 	}
 
-	return s.repo.UpdateUpload(ctx, upload)
-}
-
-// CompleteUpload marks the upload as complete and updates video status
-func (s *MediaService) CompleteUpload(ctx context.Context, videoID string) error {
-	video, err := s.repo.GetVideoByID(ctx, videoID)
+	reason, err := s.verifyUpload(ctx, video)
 	if err != nil {
-		return fmt.Errorf("failed to get video: %w", err)
+		return fmt.Errorf("failed to verify upload: %w", err)
+	}
+	if reason != "" {
+		return s.rejectUpload(ctx, video, reason)
 	}
 
 	// Generate blob URL
-	video.BlobURL = s.storage.GetBlobURL(videoID, video.FileName)
-	video.Status = model.VideoStatusProcessing
+	video.BlobURL = s.storage.URL(storage.VideoKey(video.ID, video.FileName))
 	video.UpdatedAt = time.Now()
 
-	if err := s.repo.UpdateVideo(ctx, video); err != nil {
-		return fmt.Errorf("failed to update video: %w", err)
-	}
+	var jobID string
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		if err := repo.UpdateVideo(ctx, video); err != nil {
+			return fmt.Errorf("failed to update video: %w", err)
+		}
+		if err := transition(ctx, repo, video, model.VideoStatusUploaded, ""); err != nil {
+			return err
+		}
+		if err := repo.EnqueueVideoEvent(ctx, model.EventVideoUploaded, video); err != nil {
+			return err
+		}
+
+		// Without transcoding the upload is streamed as it is
+		if !s.transcode {
+			if err := transition(ctx, repo, video, model.VideoStatusAwaitingModeration, ""); err != nil {
+				return err
+			}
+			return repo.EnqueueVideoEvent(ctx, model.EventVideoProcessed, video)
+		}
 
-	// In test mode, immediately mark as ready since we're not actually processing
-	if s.storage.IsTestMode() {
-		video.Status = model.VideoStatusReady
-		video.UpdatedAt = time.Now()
-		if err := s.repo.UpdateVideo(ctx, video); err != nil {
-			return fmt.Errorf("failed to update video status: %w", err)
+		if err := transition(ctx, repo, video, model.VideoStatusProcessing, ""); err != nil {
+			return err
 		}
+		jobID, err = repo.EnqueueTranscodeJob(ctx, video.ID, s.transcodeMaxAttempts)
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
-	// TODO: Publish event to NATS for AI moderation
-	// For now, we'll skip this in test mode
+	// The transcoder also polls for queued jobs, so a request that is not
+	// delivered only delays it
+	if jobID != "" {
+		s.requestTranscode(jobID, video.ID)
+	}
 
 	return nil
 }
 
-// GetVideo retrieves a video by ID
-func (s *MediaService) GetVideo(ctx context.Context, videoID string) (*model.Video, error) {
+// GetVideo retrieves a video the viewer may watch. Videos hidden from the
+// viewer by their status or visibility return ErrForbidden.
+func (s *MediaService) GetVideo(ctx context.Context, viewer model.Viewer, videoID string) (*model.Video, error) {
 	video, err := s.repo.GetVideoByID(ctx, videoID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get video: %w", err)
 	}
+	if !canView(viewer, video) {
+		return nil, ErrForbidden
+	}
+	if viewer.ProfileID != video.ProfileID {
+		video.ShareToken = nil
+	}
 
-	// Generate download URL if video is ready
-	if video.Status == model.VideoStatusReady && video.BlobURL != "" {
-		downloadURL, err := s.storage.GenerateDownloadURL(ctx, video.ID, video.FileName)
+	if err := s.addPlayback(ctx, video); err != nil {
+		return nil, err
+	}
+	return video, nil
+}
+
+// addPlayback fills in how a published video is played, or how far along
+// a processing one is.
+func (s *MediaService) addPlayback(ctx context.Context, video *model.Video) error {
+	switch video.Status {
+	case model.VideoStatusPublished:
+		video.StreamURL = s.streamURL(ctx, video)
+		if video.HLSMasterKey != nil {
+			renditions, err := s.repo.ListRenditions(ctx, video.ID)
+			if err != nil {
+				return err
+			}
+			video.Renditions = renditions
+		}
+	case model.VideoStatusProcessing:
+		job, err := s.repo.GetTranscodeJobByVideo(ctx, video.ID)
 		if err == nil {
-			video.StreamURL = downloadURL
+			video.ProcessingProgress = &job.Progress
+		} else if !errors.Is(err, repository.ErrTranscodeJobNotFound) {
+			return err
 		}
 	}
 
-	return video, nil
+	return nil
 }
 
-// ListProfileVideos retrieves all videos for a profile
-func (s *MediaService) ListProfileVideos(ctx context.Context, profileID string, limit, offset int) ([]*model.Video, int, error) {
-	videos, total, err := s.repo.ListVideosByProfile(ctx, profileID, limit, offset)
+// ListProfileVideos lists a profile's videos the viewer may find: every
+// video for the owner, and published ones the viewer's role may see for
+// anyone else. Unlisted videos are only listed for the owner.
+func (s *MediaService) ListProfileVideos(ctx context.Context, viewer model.Viewer, profileID string, limit, offset int) ([]*model.Video, int, error) {
+	owner := viewer.ProfileID == profileID
+	var visibilities []string
+	if !owner {
+		visibilities = viewer.Visibilities()
+	}
+
+	videos, total, err := s.repo.ListVideosByProfile(ctx, profileID, visibilities, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list videos: %w", err)
 	}
 
-	// Generate download URLs for ready videos
+	// Generate stream URLs for published videos
 	for _, video := range videos {
-		if video.Status == model.VideoStatusReady && video.BlobURL != "" {
-			downloadURL, err := s.storage.GenerateDownloadURL(ctx, video.ID, video.FileName)
-			if err == nil {
-				video.StreamURL = downloadURL
-			}
+		if !owner {
+			video.ShareToken = nil
+		}
+		if video.Status == model.VideoStatusPublished {
+			video.StreamURL = s.streamURL(ctx, video)
 		}
 	}
 
 	return videos, total, nil
 }
 
-// UpdateVideo updates video metadata
-func (s *MediaService) UpdateVideo(ctx context.Context, videoID string, req *model.VideoUpdateRequest) error {
-	video, err := s.repo.GetVideoByID(ctx, videoID)
+// UpdateVideo updates the metadata of one of the owner's videos
+func (s *MediaService) UpdateVideo(ctx context.Context, profileID, videoID string, req *model.VideoUpdateRequest) (*model.Video, error) {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
 	if err != nil {
-		return fmt.Errorf("failed to get video: %w", err)
+		return nil, err
 	}
 
 	if req.Title != "" {
@@ -178,30 +277,134 @@ func (s *MediaService) UpdateVideo(ctx context.Context, videoID string, req *mod
 		video.Description = req.Description
 	}
 	if req.Visibility != "" {
-		video.Visibility = req.Visibility
+		if err := setVisibility(video, req.Visibility); err != nil {
+			return nil, err
+		}
 	}
 
 	video.UpdatedAt = time.Now()
 
-	return s.repo.UpdateVideo(ctx, video)
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		if err := repo.UpdateVideo(ctx, video); err != nil {
+			return err
+		}
+		return repo.EnqueueVideoEvent(ctx, model.EventVideoUpdated, video)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return video, nil
 }
 
-// DeleteVideo deletes a video and its blob
-func (s *MediaService) DeleteVideo(ctx context.Context, videoID string) error {
-	video, err := s.repo.GetVideoByID(ctx, videoID)
+// DeleteVideo deletes one of the owner's videos and its blob
+func (s *MediaService) DeleteVideo(ctx context.Context, profileID, videoID string) error {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
 	if err != nil {
-		return fmt.Errorf("failed to get video: %w", err)
+		return err
 	}
 
-	// Delete from blob storage (will be no-op in test mode)
-	if err := s.storage.DeleteVideo(ctx, video.ID, video.FileName); err != nil {
+	// Delete from blob storage
+	if err := s.deleteBlob(ctx, video); err != nil {
 		return fmt.Errorf("failed to delete blob: %w", err)
 	}
 
 	// Delete from database
-	if err := s.repo.DeleteVideo(ctx, videoID); err != nil {
-		return fmt.Errorf("failed to delete video: %w", err)
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		if err := repo.DeleteVideo(ctx, videoID); err != nil {
+			return fmt.Errorf("failed to delete video: %w", err)
+		}
+		return repo.EnqueueVideoEvent(ctx, model.EventVideoDeleted, video)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListPublicVideos returns a profile's public, ready videos for public
+// profile pages
+func (s *MediaService) ListPublicVideos(ctx context.Context, profileID string, limit int) ([]*model.Video, error) {
+	videos, err := s.repo.ListPublicVideosByProfile(ctx, profileID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public videos: %w", err)
+	}
+
+	return videos, nil
+}
+
+// HideProfileVideos removes a deactivated or taken down profile's videos
+// from every listing until the profile is restored.
+func (s *MediaService) HideProfileVideos(ctx context.Context, profileID string) error {
+	if err := s.repo.SetProfileVideosHidden(ctx, profileID, true); err != nil {
+		return fmt.Errorf("failed to hide profile videos: %w", err)
+	}
+
+	return nil
+}
+
+// ShowProfileVideos undoes HideProfileVideos for a restored profile.
+func (s *MediaService) ShowProfileVideos(ctx context.Context, profileID string) error {
+	if err := s.repo.SetProfileVideosHidden(ctx, profileID, false); err != nil {
+		return fmt.Errorf("failed to show profile videos: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProfileVideos deletes every video of a profile that was deleted in
+// profile-service, blobs first so a failure leaves the rows for a retry.
+func (s *MediaService) DeleteProfileVideos(ctx context.Context, profileID string) (int64, error) {
+	videos, err := s.repo.ListVideoFilesByProfile(ctx, profileID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list profile videos: %w", err)
+	}
+
+	for _, video := range videos {
+		if err := s.deleteBlob(ctx, video); err != nil {
+			return 0, fmt.Errorf("failed to delete blob for video %s: %w", video.ID, err)
+		}
+	}
+
+	var deleted int64
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		for _, video := range videos {
+			if err := repo.EnqueueVideoEvent(ctx, model.EventVideoDeleted, video); err != nil {
+				return err
+			}
+		}
+		deleted, err = repo.DeleteVideosByProfile(ctx, profileID)
+		if err != nil {
+			return fmt.Errorf("failed to delete profile videos: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
+	return deleted, nil
+}
+
+// deleteBlob removes a video's uploaded file and everything made from it.
+// Files that were never stored are not an error.
+func (s *MediaService) deleteBlob(ctx context.Context, video *model.Video) error {
+	objects, err := s.storage.List(ctx, video.ID+"/")
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		err := s.storage.Delete(ctx, object.Key)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// isTestMode reports whether videos are kept on local storage, which is
+// only meant for development.
+func (s *MediaService) isTestMode() bool {
+	return s.storage.Driver() == storage.DriverLocal
+}
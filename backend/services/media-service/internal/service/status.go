@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/repository"
+)
+
+// ErrInvalidStatus is returned for an action the video's status does not
+// allow, including when its status changed while the action ran.
+var ErrInvalidStatus = errors.New("video status does not allow this")
+
+// transition moves video to status to with repo, which should be bound to
+// the transaction making the rest of the change.
+func transition(ctx context.Context, repo *repository.MediaRepository, video *model.Video, to model.VideoStatus, reason string) error {
+	changed, err := repo.TransitionVideo(ctx, video.ID, video.Status, to, reason)
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		return fmt.Errorf("%w: video is %s", ErrInvalidStatus, video.Status)
+	}
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return fmt.Errorf("%w: video is no longer %s", ErrInvalidStatus, video.Status)
+	}
+
+	video.Status = to
+	if to == model.VideoStatusFailed || to == model.VideoStatusRejected {
+		video.FailureReason = &reason
+	}
+	return nil
+}
+
+// ArchiveVideo takes one of the owner's published videos out of every
+// listing without deleting it.
+func (s *MediaService) ArchiveVideo(ctx context.Context, profileID, videoID string) (*model.Video, error) {
+	return s.setArchived(ctx, profileID, videoID, model.VideoStatusArchived)
+}
+
+// RestoreVideo publishes an archived video again.
+func (s *MediaService) RestoreVideo(ctx context.Context, profileID, videoID string) (*model.Video, error) {
+	return s.setArchived(ctx, profileID, videoID, model.VideoStatusPublished)
+}
+
+func (s *MediaService) setArchived(ctx context.Context, profileID, videoID string, to model.VideoStatus) (*model.Video, error) {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		if err := transition(ctx, repo, video, to, "changed by owner"); err != nil {
+			return err
+		}
+		return repo.EnqueueVideoEvent(ctx, model.EventVideoUpdated, video)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// VideoStatusHistory returns every status one of the owner's videos has
+// been in.
+func (s *MediaService) VideoStatusHistory(ctx context.Context, profileID, videoID string) ([]model.VideoStatusChange, error) {
+	if _, err := s.ownedVideo(ctx, profileID, videoID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListVideoStatusHistory(ctx, videoID)
+}
+
+// ApplyModeration publishes or rejects a video awaiting moderation. A
+// decision on a video that is gone or already decided is ignored, so
+// redelivered decisions are harmless, and so is one whose video ID is not
+// a UUID, which no retry can fix.
+func (s *MediaService) ApplyModeration(ctx context.Context, event model.VideoModeratedEvent) error {
+	if err := uuid.Validate(event.VideoID); err != nil {
+		return nil
+	}
+	video, err := s.repo.GetVideoByID(ctx, event.VideoID)
+	if errors.Is(err, repository.ErrVideoNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+	if video.Status != model.VideoStatusAwaitingModeration {
+		return nil
+	}
+
+	var to model.VideoStatus
+	eventType := model.EventVideoUpdated
+	reason := event.Reason
+	switch event.Decision {
+	case model.ModerationApproved:
+		to, eventType = model.VideoStatusPublished, model.EventVideoReady
+	case model.ModerationRejected:
+		to = model.VideoStatusRejected
+	case model.ModerationFailed:
+		to, reason = model.VideoStatusFailed, "video could not be moderated"
+	default:
+		return fmt.Errorf("unknown moderation decision %q", event.Decision)
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		if err := transition(ctx, repo, video, to, reason); err != nil {
+			return err
+		}
+		return repo.EnqueueVideoEvent(ctx, eventType, video)
+	})
+	if errors.Is(err, ErrInvalidStatus) {
+		// Decided by another delivery of the same event
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/hls"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/storage"
+)
+
+// ErrPlaylistNotFound is returned for a playlist a video does not have.
+var ErrPlaylistNotFound = errors.New("playlist not found")
+
+const (
+	// streamTokenExpiry is how long a stream URL can be played from
+	streamTokenExpiry = 6 * time.Hour
+	maxPlaylistSize   = 1 << 20
+)
+
+// playlistPattern matches the playlists a player may ask for: the master
+// playlist and each rendition's.
+var playlistPattern = regexp.MustCompile(`^(master|[a-z0-9]+/index)\.m3u8$`)
+
+// streamURL returns where a ready video is played from: its signed HLS
+// master playlist once transcoded, or else a presigned URL of the upload.
+func (s *MediaService) streamURL(ctx context.Context, video *model.Video) string {
+	if video.HLSMasterKey != nil {
+		token := s.streams.Sign(video.ID, time.Now().Add(streamTokenExpiry))
+		return fmt.Sprintf("%s/api/v1/videos/%s/hls/%s?%s", s.streamBaseURL, video.ID, hls.MasterPlaylist, token.Encode())
+	}
+
+	presigned, err := s.storage.PresignDownload(ctx, storage.VideoKey(video.ID, video.FileName), storage.PresignOptions{
+		ContentType: video.MimeType,
+	})
+	if err != nil {
+		return ""
+	}
+	return presigned.URL
+}
+
+// StreamPlaylist returns one of a video's HLS playlists to a player holding
+// the token of its stream URL. The master playlist's renditions carry the
+// token on, and a rendition's segments become presigned storage URLs valid
+// as long as the token.
+func (s *MediaService) StreamPlaylist(ctx context.Context, videoID, file string, query url.Values) ([]byte, error) {
+	expires, err := s.streams.Verify(videoID, query)
+	if err != nil {
+		return nil, err
+	}
+	if !playlistPattern.MatchString(file) {
+		return nil, ErrPlaylistNotFound
+	}
+
+	body, _, err := s.storage.Get(ctx, hls.Key(videoID, file), nil)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrPlaylistNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlist: %w", err)
+	}
+	defer body.Close()
+
+	playlist, err := io.ReadAll(io.LimitReader(body, maxPlaylistSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	if file == hls.MasterPlaylist {
+		token := url.Values{"expires": {query.Get("expires")}, "sig": {query.Get("sig")}}.Encode()
+		return hls.Rewrite(playlist, func(uri string) (string, error) {
+			return uri + "?" + token, nil
+		})
+	}
+
+	dir := path.Dir(file)
+	return hls.Rewrite(playlist, func(uri string) (string, error) {
+		presigned, err := s.storage.PresignDownload(ctx, hls.Key(videoID, dir+"/"+path.Base(uri)), storage.PresignOptions{
+			Expiry:      time.Until(expires),
+			ContentType: hls.SegmentContentType,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to sign segment: %w", err)
+		}
+		return presigned.URL, nil
+	})
+}
+
+// requestTranscode asks a transcoder to pick up a queued job now.
+func (s *MediaService) requestTranscode(jobID, videoID string) {
+	if s.nats == nil {
+		return
+	}
+	data, err := json.Marshal(model.TranscodeRequest{JobID: jobID, VideoID: videoID})
+	if err != nil {
+		return
+	}
+	s.nats.Publish(model.TranscodeSubject, data)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/media-service/internal/images"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/storage"
+)
+
+var (
+	ErrInvalidThumbnail = errors.New("invalid thumbnail")
+	ErrImageNotFound    = errors.New("image not found")
+)
+
+const (
+	MaxThumbnailSize = 5 << 20
+	minThumbnailSide = 160
+	maxThumbnailSide = 4096
+	// imageURLExpiry is how long the storage URL an image redirects to
+	// lasts. Clients keep the stable URL, so it only needs to outlive
+	// the download.
+	imageURLExpiry = 1 * time.Hour
+)
+
+// ownedVideo returns the video if it belongs to profileID. An ID that is
+// not a UUID cannot name a video, so it is not found rather than a query
+// error.
+func (s *MediaService) ownedVideo(ctx context.Context, profileID, videoID string) (*model.Video, error) {
+	if err := uuid.Validate(videoID); err != nil {
+		return nil, repository.ErrVideoNotFound
+	}
+	video, err := s.repo.GetVideoByID(ctx, videoID)
+	if errors.Is(err, repository.ErrVideoNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get video: %w", err)
+	}
+	if video.ProfileID != profileID {
+		return nil, ErrForbidden
+	}
+	return video, nil
+}
+
+// ListThumbnails returns the thumbnails the owner can choose from, marking
+// the one shown.
+func (s *MediaService) ListThumbnails(ctx context.Context, profileID, videoID string) ([]model.Thumbnail, error) {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails, err := s.repo.ListThumbnails(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range thumbnails {
+		thumbnails[i].Selected = video.ThumbnailURL != nil && thumbnails[i].URL == *video.ThumbnailURL
+	}
+
+	return thumbnails, nil
+}
+
+// SelectThumbnail shows one of the video's thumbnails.
+func (s *MediaService) SelectThumbnail(ctx context.Context, profileID, videoID, thumbnailID string) (*model.Thumbnail, error) {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, err := s.repo.GetThumbnail(ctx, videoID, thumbnailID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		if err := repo.SetVideoThumbnail(ctx, videoID, thumbnail.URL); err != nil {
+			return err
+		}
+		return repo.EnqueueVideoEvent(ctx, model.EventVideoUpdated, video)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail.Selected = true
+	return thumbnail, nil
+}
+
+// UploadThumbnail stores a JPEG or PNG image of at most MaxThumbnailSize
+// bytes as the video's thumbnail, replacing any uploaded before.
+func (s *MediaService) UploadThumbnail(ctx context.Context, profileID, videoID string, body io.Reader) (*model.Thumbnail, error) {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, MaxThumbnailSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read thumbnail: %w", err)
+	}
+	if len(data) > MaxThumbnailSize {
+		return nil, fmt.Errorf("%w: thumbnails are limited to %d bytes", ErrUploadTooLarge, MaxThumbnailSize)
+	}
+
+	// Only the header is decoded; the image is stored as sent
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a JPEG or PNG image", ErrInvalidThumbnail)
+	}
+	if config.Width < minThumbnailSide || config.Height < minThumbnailSide ||
+		config.Width > maxThumbnailSide || config.Height > maxThumbnailSide {
+		return nil, fmt.Errorf("%w: sides must be between %d and %d pixels", ErrInvalidThumbnail, minThumbnailSide, maxThumbnailSide)
+	}
+
+	ext := ".jpg"
+	if format == "png" {
+		ext = ".png"
+	}
+	file := images.CustomFile(ext)
+	key := images.Key(videoID, file)
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), images.ContentType(file)); err != nil {
+		return nil, fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	thumbnail := &model.Thumbnail{
+		VideoID:  videoID,
+		Kind:     model.ThumbnailKindCustom,
+		ImageKey: key,
+		URL:      images.URL(s.streamBaseURL, videoID, file),
+		Width:    config.Width,
+		Height:   config.Height,
+		Selected: true,
+	}
+	var replaced []model.Thumbnail
+	err = s.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		var err error
+		if replaced, err = repo.DeleteCustomThumbnails(ctx, videoID); err != nil {
+			return err
+		}
+		if err := repo.CreateThumbnail(ctx, thumbnail); err != nil {
+			return err
+		}
+		if err := repo.SetVideoThumbnail(ctx, videoID, thumbnail.URL); err != nil {
+			return err
+		}
+		return repo.EnqueueVideoEvent(ctx, model.EventVideoUpdated, video)
+	})
+	if err != nil {
+		s.storage.Delete(ctx, key)
+		return nil, err
+	}
+
+	// The old image is unreferenced now; one left behind is removed with
+	// the video
+	for _, old := range replaced {
+		s.storage.Delete(ctx, old.ImageKey)
+	}
+
+	return thumbnail, nil
+}
+
+// ImageURL returns a signed storage URL for one of a video's images.
+func (s *MediaService) ImageURL(ctx context.Context, videoID, file string) (string, error) {
+	if !images.ValidFile(file) {
+		return "", ErrImageNotFound
+	}
+
+	presigned, err := s.storage.PresignDownload(ctx, images.Key(videoID, file), storage.PresignOptions{
+		Expiry:      imageURLExpiry,
+		ContentType: images.ContentType(file),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign image URL: %w", err)
+	}
+	return presigned.URL, nil
+}
+
+// SeekPreviewTrack returns a video's WebVTT seek preview track. It is
+// served rather than redirected to so the sprite it references resolves
+// against the image route.
+func (s *MediaService) SeekPreviewTrack(ctx context.Context, videoID string) ([]byte, error) {
+	body, _, err := s.storage.Get(ctx, images.Key(videoID, images.SpriteTrack), nil)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seek preview track: %w", err)
+	}
+	defer body.Close()
+
+	return io.ReadAll(io.LimitReader(body, maxPlaylistSize))
+}
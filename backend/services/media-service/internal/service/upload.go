@@ -0,0 +1,383 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/storage"
+)
+
+var (
+	ErrForbidden            = errors.New("forbidden")
+	ErrInvalidUpload        = errors.New("invalid upload")
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	ErrUploadTooLarge       = errors.New("upload too large")
+	ErrUploadClosed         = errors.New("upload is no longer active")
+	ErrChecksumMismatch     = errors.New("checksum mismatch")
+	ErrUnsupportedChecksum  = errors.New("unsupported checksum algorithm")
+)
+
+const (
+	// TusMaxSize is the largest file a tus upload may declare.
+	TusMaxSize = 2 << 30
+
+	// tusUploadExpiry is how long an upload may go without receiving a
+	// chunk before it is abandoned.
+	tusUploadExpiry  = 24 * time.Hour
+	staleUploadBatch = 50
+)
+
+// TusChecksumAlgorithms are the Upload-Checksum algorithms accepted.
+var TusChecksumAlgorithms = []string{"sha1", "md5", "sha256"}
+
+// UploadChecksum is a decoded Upload-Checksum header.
+type UploadChecksum struct {
+	Algorithm string
+	Digest    []byte
+}
+
+func newChecksumHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "sha1":
+		return sha1.New(), nil
+	case "md5":
+		return md5.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedChecksum, algorithm)
+	}
+}
+
+// CreateTusUpload creates a pending video and the tus upload its file will
+// be sent through.
+func (s *MediaService) CreateTusUpload(ctx context.Context, req *model.TusUploadRequest) (*model.VideoUpload, error) {
+	if req.Length <= 0 {
+		return nil, fmt.Errorf("%w: Upload-Length must be positive", ErrInvalidUpload)
+	}
+	if req.Length > TusMaxSize {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrUploadTooLarge, req.Length, int64(TusMaxSize))
+	}
+	if err := s.checkUploadSize(ctx, req.ProfileID, req.Length); err != nil {
+		return nil, err
+	}
+	visibility, err := s.defaultVisibility(ctx, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName, ok := storage.BaseFileName(req.Metadata["filename"])
+	if !ok {
+		return nil, fmt.Errorf("%w: filename metadata is required", ErrInvalidUpload)
+	}
+
+	mimeType := req.Metadata["filetype"]
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(path.Ext(fileName))
+	}
+	if !strings.HasPrefix(mimeType, "video/") {
+		return nil, fmt.Errorf("%w: %q is not a video type", ErrInvalidUpload, mimeType)
+	}
+
+	title := req.Metadata["title"]
+	if title == "" {
+		title = strings.TrimSuffix(fileName, path.Ext(fileName))
+	}
+
+	now := time.Now()
+	video := &model.Video{
+		ID:          uuid.New().String(),
+		ProfileID:   req.ProfileID,
+		Title:       title,
+		Description: req.Metadata["description"],
+		FileName:    fileName,
+		FileSize:    req.Length,
+		MimeType:    mimeType,
+		Status:      model.VideoStatusUploading,
+		Visibility:  visibility,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.CreateVideo(ctx, video); err != nil {
+		return nil, fmt.Errorf("failed to create video: %w", err)
+	}
+
+	expiresAt := now.Add(tusUploadExpiry)
+	upload := &model.VideoUpload{
+		ID:        uuid.New().String(),
+		VideoID:   video.ID,
+		Status:    model.UploadStatusInitiated,
+		Length:    &req.Length,
+		ExpiresAt: &expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.CreateTusUpload(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// GetTusUpload returns one of the profile's uploads.
+func (s *MediaService) GetTusUpload(ctx context.Context, profileID, uploadID string) (*model.VideoUpload, error) {
+	upload, _, err := s.tusUpload(ctx, profileID, uploadID)
+	return upload, err
+}
+
+// WriteTusChunk stores size bytes of body at offset. When a checksum is
+// given the chunk is discarded unless it matches. The chunk that completes
+// the file finishes the upload and hands the video to completeUpload.
+func (s *MediaService) WriteTusChunk(ctx context.Context, profileID, uploadID string, offset int64, body io.Reader, size int64, checksum *UploadChecksum) (*model.VideoUpload, error) {
+	upload, video, err := s.tusUpload(ctx, profileID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if !uploadActive(upload) {
+		return nil, ErrUploadClosed
+	}
+	if offset != upload.Offset {
+		return nil, fmt.Errorf("%w: upload is at %d, chunk starts at %d", ErrUploadOffsetMismatch, upload.Offset, offset)
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("%w: chunk length is required", ErrInvalidUpload)
+	}
+	length := *upload.Length
+	if offset+size > length {
+		return nil, fmt.Errorf("%w: chunk ends at %d, upload is %d bytes", ErrUploadTooLarge, offset+size, length)
+	}
+
+	if size > 0 {
+		reader := body
+		var hasher hash.Hash
+		if checksum != nil {
+			if hasher, err = newChecksumHash(checksum.Algorithm); err != nil {
+				return nil, err
+			}
+			reader = io.TeeReader(body, hasher)
+		}
+
+		key := uploadPartKey(upload.ID, offset)
+		if err := s.storage.Put(ctx, key, reader, size, "application/offset+octet-stream"); err != nil {
+			return nil, fmt.Errorf("failed to store chunk: %w", err)
+		}
+		if hasher != nil && !bytes.Equal(hasher.Sum(nil), checksum.Digest) {
+			s.storage.Delete(ctx, key)
+			return nil, ErrChecksumMismatch
+		}
+
+		to := offset + size
+		expiresAt := time.Now().Add(tusUploadExpiry)
+		advanced, err := s.repo.AdvanceUpload(ctx, upload.ID, offset, to, uploadProgress(to, length), expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		if !advanced {
+			return nil, fmt.Errorf("%w: another chunk was stored at %d first", ErrUploadOffsetMismatch, offset)
+		}
+
+		upload.Offset = to
+		upload.Progress = uploadProgress(to, length)
+		upload.ExpiresAt = &expiresAt
+		upload.Status = model.UploadStatusInProgress
+	}
+
+	if upload.Offset == length {
+		// Finish even if the client goes away; it has sent everything
+		if err := s.finishTusUpload(context.WithoutCancel(ctx), upload, video); err != nil {
+			return nil, err
+		}
+	}
+
+	return upload, nil
+}
+
+// ExpireUploads abandons tus uploads that went past their expiry without
+// receiving their last chunk and deletes the chunks they stored. Uploads
+// that received every byte but failed to finish get one more attempt. It
+// returns the number of uploads handled.
+func (s *MediaService) ExpireUploads(ctx context.Context) (int, error) {
+	uploads, err := s.repo.ListStaleUploads(ctx, time.Now(), staleUploadBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, upload := range uploads {
+		video, err := s.repo.GetVideoByID(ctx, upload.VideoID)
+		if err != nil {
+			return i, fmt.Errorf("failed to get video: %w", err)
+		}
+
+		status, reason := model.UploadStatusExpired, "upload expired before it was complete"
+		if upload.Length != nil && upload.Offset == *upload.Length {
+			err := s.finishTusUpload(ctx, upload, video)
+			if err == nil || errors.Is(err, ErrUploadRejected) {
+				continue
+			}
+			status, reason = model.UploadStatusFailed, err.Error()
+		}
+
+		s.deleteUploadParts(ctx, upload.ID)
+		if err := s.repo.SetUploadStatus(ctx, upload.ID, status, &reason); err != nil {
+			return i, err
+		}
+
+		// A video that got past uploading is left as it is
+		err = transition(ctx, s.repo, video, model.VideoStatusFailed, reason)
+		if err != nil && !errors.Is(err, ErrInvalidStatus) {
+			return i, err
+		}
+	}
+
+	return len(uploads), nil
+}
+
+// tusUpload loads an upload and its video, checking the video belongs to
+// profileID.
+func (s *MediaService) tusUpload(ctx context.Context, profileID, uploadID string) (*model.VideoUpload, *model.Video, error) {
+	upload, err := s.repo.GetUpload(ctx, uploadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	video, err := s.repo.GetVideoByID(ctx, upload.VideoID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get video: %w", err)
+	}
+	if video.ProfileID != profileID {
+		return nil, nil, ErrForbidden
+	}
+	return upload, video, nil
+}
+
+// finishTusUpload joins the stored chunks into the video's file and hands
+// it to completeUpload. A file rejected by verification fails the upload
+// with the reason. On other failures the upload is left as it was so the
+// last chunk can be retried.
+func (s *MediaService) finishTusUpload(ctx context.Context, upload *model.VideoUpload, video *model.Video) error {
+	parts, err := s.uploadParts(ctx, upload)
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		for _, part := range parts {
+			body, _, err := s.storage.Get(ctx, part.Key, nil)
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+			_, err = io.Copy(writer, body)
+			body.Close()
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+		}
+		writer.Close()
+	}()
+
+	err = s.storage.Put(ctx, storage.VideoKey(video.ID, video.FileName), reader, *upload.Length, video.MimeType)
+	reader.CloseWithError(err)
+	if err != nil {
+		return fmt.Errorf("failed to assemble upload: %w", err)
+	}
+
+	completeErr := s.completeUpload(ctx, video)
+	if completeErr != nil && !errors.Is(completeErr, ErrUploadRejected) {
+		return completeErr
+	}
+
+	status, errorMsg := model.UploadStatusCompleted, (*string)(nil)
+	if completeErr != nil {
+		reason := completeErr.Error()
+		status, errorMsg = model.UploadStatusFailed, &reason
+	}
+
+	s.deleteUploadParts(ctx, upload.ID)
+	if err := s.repo.SetUploadStatus(ctx, upload.ID, status, errorMsg); err != nil {
+		return err
+	}
+	upload.Status = status
+	upload.ErrorMsg = errorMsg
+	upload.Progress = 100
+
+	return completeErr
+}
+
+// uploadParts returns the chunks that make up the upload in order. Chunks
+// left behind by a retried request at an earlier offset are skipped.
+func (s *MediaService) uploadParts(ctx context.Context, upload *model.VideoUpload) ([]storage.ObjectInfo, error) {
+	objects, err := s.storage.List(ctx, uploadPartPrefix(upload.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upload chunks: %w", err)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+
+	parts := []storage.ObjectInfo{}
+	var next int64
+	for _, object := range objects {
+		offset, err := strconv.ParseInt(path.Base(object.Key), 10, 64)
+		if err != nil || offset != next {
+			continue
+		}
+		parts = append(parts, object)
+		next += object.Size
+	}
+
+	if next != *upload.Length {
+		return nil, fmt.Errorf("upload chunks cover %d of %d bytes", next, *upload.Length)
+	}
+	return parts, nil
+}
+
+// deleteUploadParts removes an upload's chunks. It is best effort: chunks
+// it misses only take up space.
+func (s *MediaService) deleteUploadParts(ctx context.Context, uploadID string) {
+	objects, err := s.storage.List(ctx, uploadPartPrefix(uploadID))
+	if err != nil {
+		return
+	}
+	for _, object := range objects {
+		s.storage.Delete(ctx, object.Key)
+	}
+}
+
+func uploadActive(upload *model.VideoUpload) bool {
+	if upload.Status != model.UploadStatusInitiated && upload.Status != model.UploadStatusInProgress {
+		return false
+	}
+	return upload.Length != nil && (upload.ExpiresAt == nil || time.Now().Before(*upload.ExpiresAt))
+}
+
+func uploadProgress(offset, length int64) int {
+	if length <= 0 {
+		return 0
+	}
+	return int(offset * 100 / length)
+}
+
+// uploadPartPrefix is where a tus upload's chunks are kept until it
+// finishes, keyed by zero-padded offset so they list in order.
+func uploadPartPrefix(uploadID string) string {
+	return "uploads/" + uploadID + "/"
+}
+
+func uploadPartKey(uploadID string, offset int64) string {
+	return fmt.Sprintf("%s%020d", uploadPartPrefix(uploadID), offset)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/probe"
+	"github.com/scouttalent/media-service/internal/storage"
+)
+
+// ErrUploadRejected is returned when an uploaded file fails verification.
+// The video is marked failed with the reason.
+var ErrUploadRejected = errors.New("upload rejected")
+
+// uploadLimits returns the limits for the profile's trust level. Levels
+// without configured limits get the newcomer limits.
+func (s *MediaService) uploadLimits(ctx context.Context, profileID string) (model.UploadLimits, error) {
+	profile, err := s.profiles.GetProfile(ctx, profileID)
+	if err != nil {
+		return model.UploadLimits{}, err
+	}
+
+	limits, ok := s.limits[profile.TrustLevel]
+	if !ok {
+		limits = s.limits[model.TrustLevelNewcomer]
+	}
+	return limits, nil
+}
+
+// checkUploadSize rejects a declared file size over the profile's limit
+// before anything is uploaded.
+func (s *MediaService) checkUploadSize(ctx context.Context, profileID string, size int64) error {
+	limits, err := s.uploadLimits(ctx, profileID)
+	if err != nil {
+		return err
+	}
+	if limits.MaxSize > 0 && size > limits.MaxSize {
+		return fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrUploadTooLarge, size, limits.MaxSize)
+	}
+	return nil
+}
+
+// verifyUpload checks the uploaded file against what the client declared
+// and the uploader's limits, reading only its headers, then hashes it. It
+// fills in the video's duration and content hash. A non-empty reason means
+// the file is rejected; an error means the checks could not be run.
+func (s *MediaService) verifyUpload(ctx context.Context, video *model.Video) (string, error) {
+	limits, err := s.uploadLimits(ctx, video.ProfileID)
+	if err != nil {
+		return "", err
+	}
+
+	key := storage.VideoKey(video.ID, video.FileName)
+	info, err := s.storage.Head(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "file was not uploaded", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to stat upload: %w", err)
+	}
+	if info.Size != video.FileSize {
+		return fmt.Sprintf("file is %d bytes, expected %d", info.Size, video.FileSize), nil
+	}
+	if limits.MaxSize > 0 && info.Size > limits.MaxSize {
+		return fmt.Sprintf("file is %d bytes, the limit is %d", info.Size, limits.MaxSize), nil
+	}
+
+	reader := storage.NewReaderAt(ctx, s.storage, key, info.Size)
+	header := make([]byte, probe.SniffLength)
+	n, err := reader.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read upload: %w", err)
+	}
+	container, err := probe.Sniff(header[:n])
+	if err != nil {
+		return "file is not an MP4, MOV, WebM or Matroska video", nil
+	}
+	if !container.Matches(video.MimeType) {
+		return fmt.Sprintf("file is %s but was declared as %s", container, video.MimeType), nil
+	}
+
+	duration, err := probe.Duration(reader, info.Size, container)
+	switch {
+	case errors.Is(err, probe.ErrNoDuration):
+		// Live recordings may not store one; processing measures it
+	case errors.Is(err, probe.ErrMalformed):
+		return fmt.Sprintf("file is not a valid %s: %v", container, err), nil
+	case err != nil:
+		return "", fmt.Errorf("failed to read duration: %w", err)
+	default:
+		if limits.MaxDuration > 0 && duration > limits.MaxDuration {
+			return fmt.Sprintf("video is %s long, the limit is %s", duration.Round(time.Second), limits.MaxDuration), nil
+		}
+		seconds := int(duration.Round(time.Second) / time.Second)
+		video.Duration = &seconds
+	}
+
+	hash, err := s.hashObject(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	video.ContentHash = &hash
+
+	return "", nil
+}
+
+// rejectUpload marks the video failed with reason and removes its file.
+func (s *MediaService) rejectUpload(ctx context.Context, video *model.Video, reason string) error {
+	if err := transition(ctx, s.repo, video, model.VideoStatusFailed, reason); err != nil {
+		return err
+	}
+
+	// Rejected files are not kept; the owner uploads a new one
+	if err := s.deleteBlob(ctx, video); err != nil {
+		return fmt.Errorf("failed to delete rejected file: %w", err)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUploadRejected, reason)
+}
+
+// hashObject returns the hex SHA-256 of a stored object.
+func (s *MediaService) hashObject(ctx context.Context, key string) (string, error) {
+	body, _, err := s.storage.Get(ctx, key, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to read upload: %w", err)
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, body); err != nil {
+		return "", fmt.Errorf("failed to hash upload: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/repository"
+)
+
+// ErrInvalidVisibility is returned for a visibility videos cannot have, or
+// a share link asked of a video that is not unlisted.
+var ErrInvalidVisibility = errors.New("invalid visibility")
+
+// shareTokenBytes is the randomness in a share link's token.
+const shareTokenBytes = 24
+
+// defaultVisibility is the visibility a profile's new videos start with:
+// scouts-only for minors and for profiles whose age is unknown, public for
+// everyone else.
+func (s *MediaService) defaultVisibility(ctx context.Context, profileID string) (string, error) {
+	profile, err := s.profiles.GetProfile(ctx, profileID)
+	if err != nil {
+		return "", err
+	}
+	if profile.Minor {
+		return model.VisibilityScoutsOnly, nil
+	}
+	return model.VisibilityPublic, nil
+}
+
+// canView reports whether viewer may watch video. Admins see every video and
+// owners every video of a profile that is not hidden; anyone else only
+// published videos their role may see. Unlisted videos are only opened
+// through their share link.
+func canView(viewer model.Viewer, video *model.Video) bool {
+	if viewer.Role == model.RoleAdmin {
+		return true
+	}
+	if video.ProfileHidden {
+		return false
+	}
+	if viewer.ProfileID != "" && viewer.ProfileID == video.ProfileID {
+		return true
+	}
+	if video.Status != model.VideoStatusPublished {
+		return false
+	}
+
+	switch video.Visibility {
+	case model.VisibilityPublic:
+		return true
+	case model.VisibilityScoutsOnly:
+		return viewer.CanSeeScoutsOnly()
+	default:
+		return false
+	}
+}
+
+// setVisibility changes a video's visibility. Becoming unlisted gives it a
+// share link; leaving unlisted revokes it.
+func setVisibility(video *model.Video, visibility string) error {
+	switch visibility {
+	case model.VisibilityPublic, model.VisibilityScoutsOnly, model.VisibilityPrivate:
+		video.ShareToken = nil
+	case model.VisibilityUnlisted:
+		if video.ShareToken == nil {
+			token, err := newShareToken()
+			if err != nil {
+				return err
+			}
+			video.ShareToken = &token
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidVisibility, visibility)
+	}
+
+	video.Visibility = visibility
+	return nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GetSharedVideo returns the unlisted video a share link opens to anyone
+// holding it.
+func (s *MediaService) GetSharedVideo(ctx context.Context, token string) (*model.Video, error) {
+	video, err := s.repo.GetVideoByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if video.Visibility != model.VisibilityUnlisted || video.Status != model.VideoStatusPublished || video.ProfileHidden {
+		return nil, repository.ErrVideoNotFound
+	}
+	video.ShareToken = nil
+
+	if err := s.addPlayback(ctx, video); err != nil {
+		return nil, err
+	}
+	return video, nil
+}
+
+// ResetShareLink gives one of the owner's unlisted videos a new share link,
+// so the old one stops working.
+func (s *MediaService) ResetShareLink(ctx context.Context, profileID, videoID string) (*model.Video, error) {
+	video, err := s.ownedVideo(ctx, profileID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.Visibility != model.VisibilityUnlisted {
+		return nil, fmt.Errorf("%w: only unlisted videos have a share link", ErrInvalidVisibility)
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+	video.ShareToken = &token
+	video.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateVideo(ctx, video); err != nil {
+		return nil, fmt.Errorf("failed to update video: %w", err)
+	}
+	return video, nil
+}
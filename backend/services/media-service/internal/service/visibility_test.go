@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/scouttalent/media-service/internal/model"
+)
+
+func TestCanView(t *testing.T) {
+	const owner = "3f6c1e2a-8b4d-4c7e-9a15-0d2b7e9f4c11"
+
+	owned := model.Viewer{ProfileID: owner, Role: "player"}
+	player := model.Viewer{ProfileID: "a7d90c34-52e1-4b8f-b6a0-9c1e3f5d7b22", Role: "player"}
+	scout := model.Viewer{ProfileID: "c2e8b4f6-1a3d-4e5f-8b7c-6d9a0e1f2b33", Role: model.RoleScout}
+	admin := model.Viewer{Role: model.RoleAdmin}
+	anonymous := model.Viewer{}
+
+	tests := []struct {
+		name       string
+		viewer     model.Viewer
+		visibility string
+		status     model.VideoStatus
+		hidden     bool
+		want       bool
+	}{
+		{name: "public", viewer: anonymous, visibility: model.VisibilityPublic, status: model.VideoStatusPublished, want: true},
+		{name: "public unpublished", viewer: player, visibility: model.VisibilityPublic, status: model.VideoStatusProcessing, want: false},
+		{name: "scouts only as player", viewer: player, visibility: model.VisibilityScoutsOnly, status: model.VideoStatusPublished, want: false},
+		{name: "scouts only as scout", viewer: scout, visibility: model.VisibilityScoutsOnly, status: model.VideoStatusPublished, want: true},
+		{name: "private", viewer: scout, visibility: model.VisibilityPrivate, status: model.VideoStatusPublished, want: false},
+		{name: "unlisted", viewer: scout, visibility: model.VisibilityUnlisted, status: model.VideoStatusPublished, want: false},
+		{name: "owner private", viewer: owned, visibility: model.VisibilityPrivate, status: model.VideoStatusProcessing, want: true},
+		{name: "admin private", viewer: admin, visibility: model.VisibilityPrivate, status: model.VideoStatusProcessing, want: true},
+		{name: "hidden public", viewer: anonymous, visibility: model.VisibilityPublic, status: model.VideoStatusPublished, hidden: true, want: false},
+		{name: "hidden as scout", viewer: scout, visibility: model.VisibilityScoutsOnly, status: model.VideoStatusPublished, hidden: true, want: false},
+		{name: "hidden as owner", viewer: owned, visibility: model.VisibilityPublic, status: model.VideoStatusPublished, hidden: true, want: false},
+		{name: "hidden as admin", viewer: admin, visibility: model.VisibilityPublic, status: model.VideoStatusPublished, hidden: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			video := &model.Video{
+				ProfileID:     owner,
+				Visibility:    tt.visibility,
+				Status:        tt.status,
+				ProfileHidden: tt.hidden,
+			}
+			if got := canView(tt.viewer, video); got != tt.want {
+				t.Errorf("canView = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
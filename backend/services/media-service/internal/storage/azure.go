@@ -0,0 +1,342 @@
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scouttalent/pkg/azure"
+)
+
+// azureAPIVersion is the Blob service REST version requests and SAS tokens
+// are made with.
+const azureAPIVersion = "2021-08-06"
+
+// sasClockSkew backdates SAS start times so clients with slow clocks can
+// use them straight away.
+const sasClockSkew = 5 * time.Minute
+
+// AzureBackend stores objects as block blobs in one Azure Blob Storage
+// container. With an account key it signs requests with Shared Key and
+// hands out service SAS URLs; with an Azure AD application it uses bearer
+// tokens and user delegation SAS URLs. Point BlobConfig.Endpoint at
+// Azurite to run it locally.
+type AzureBackend struct {
+	config     azure.BlobConfig
+	credential azureCredential
+	endpoint   string
+	client     *http.Client
+}
+
+func NewAzureBackend(config azure.BlobConfig) (*AzureBackend, error) {
+	if config.AccountName == "" || config.ContainerName == "" {
+		return nil, errors.New("azure storage requires an account name and container name")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.AccountName)
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	client := &http.Client{}
+
+	var credential azureCredential
+	switch {
+	case config.AccountKey != "":
+		sharedKey, err := newSharedKeyCredential(config.AccountName, config.AccountKey)
+		if err != nil {
+			return nil, err
+		}
+		credential = sharedKey
+	case config.TenantID != "" && config.ClientID != "" && config.ClientSecret != "":
+		credential = &tokenCredential{
+			endpoint:     endpoint,
+			tenantID:     config.TenantID,
+			clientID:     config.ClientID,
+			clientSecret: config.ClientSecret,
+			client:       client,
+		}
+	default:
+		return nil, errors.New("azure storage requires an account key or an Azure AD tenant, client ID and client secret")
+	}
+
+	return &AzureBackend{
+		config:     config,
+		credential: credential,
+		endpoint:   endpoint,
+		client:     client,
+	}, nil
+}
+
+func (b *AzureBackend) Driver() string {
+	return DriverAzure
+}
+
+func (b *AzureBackend) URL(key string) string {
+	return b.endpoint + "/" + b.config.ContainerName + "/" + escapeKey(key)
+}
+
+// PresignUpload signs a create and write SAS for key. A SAS cannot bind the
+// request's content type or length, so they are returned as headers for the
+// client to send and must be checked once the upload completes.
+func (b *AzureBackend) PresignUpload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error) {
+	opts = withDefaults(opts, defaultUploadExpiry)
+	presigned, err := b.presign(ctx, key, "cw", opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	presigned.Method = http.MethodPut
+	presigned.Headers = map[string]string{"x-ms-blob-type": "BlockBlob"}
+	if opts.ContentType != "" {
+		presigned.Headers["Content-Type"] = opts.ContentType
+	}
+	if opts.ContentLength > 0 {
+		presigned.Headers["Content-Length"] = strconv.FormatInt(opts.ContentLength, 10)
+	}
+	return presigned, nil
+}
+
+// PresignDownload signs a read SAS for key. The content type, when given,
+// is signed in and served as the response Content-Type.
+func (b *AzureBackend) PresignDownload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error) {
+	opts = withDefaults(opts, defaultDownloadExpiry)
+	presigned, err := b.presign(ctx, key, "r", opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	presigned.Method = http.MethodGet
+	return presigned, nil
+}
+
+// presign signs a blob SAS for key. Plain HTTP is only allowed when the
+// endpoint itself is HTTP, as with Azurite.
+func (b *AzureBackend) presign(ctx context.Context, key, permissions string, opts PresignOptions, overrideHeaders bool) (*PresignedURL, error) {
+	now := time.Now()
+	values := sasValues{
+		Permissions: permissions,
+		Start:       now.Add(-sasClockSkew),
+		Expiry:      now.Add(opts.Expiry),
+		Resource:    fmt.Sprintf("/blob/%s/%s/%s", b.config.AccountName, b.config.ContainerName, key),
+		IPRange:     b.config.SASIPRange,
+		Protocol:    "https",
+	}
+	if strings.HasPrefix(b.endpoint, "http://") {
+		values.Protocol = "https,http"
+	}
+	if overrideHeaders {
+		values.ContentType = opts.ContentType
+	}
+
+	query, err := b.credential.signSAS(ctx, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign SAS for %s: %w", key, err)
+	}
+
+	return &PresignedURL{
+		URL:       b.URL(key) + "?" + query.Encode(),
+		ExpiresAt: values.Expiry,
+	}, nil
+}
+
+func (b *AzureBackend) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := b.newRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put blob %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "put blob", key); err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (b *AzureBackend) Get(ctx context.Context, key string, rng *ByteRange) (io.ReadCloser, *ObjectInfo, error) {
+	req, err := b.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rng != nil {
+		if err := rng.validate(); err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("x-ms-range", rng.Header())
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blob %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "get blob", key); err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, objectInfoFromHeaders(key, resp.Header, objectSize(resp)), nil
+}
+
+func (b *AzureBackend) Delete(ctx context.Context, key string) error {
+	req, err := b.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-delete-snapshots", "include")
+
+	resp, err := b.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "delete blob", key); err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (b *AzureBackend) Head(ctx context.Context, key string) (*ObjectInfo, error) {
+	req, err := b.newRequest(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob properties %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "get blob properties", key); err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return objectInfoFromHeaders(key, resp.Header, resp.ContentLength), nil
+}
+
+type azureBlobList struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			ETag          string `xml:"Etag"`
+			ContentLength int64  `xml:"Content-Length"`
+			ContentType   string `xml:"Content-Type"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (b *AzureBackend) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+	marker := ""
+
+	for {
+		query := url.Values{}
+		query.Set("restype", "container")
+		query.Set("comp", "list")
+		query.Set("prefix", prefix)
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		req, err := b.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := b.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blobs: %w", err)
+		}
+		if err := checkResponse(resp, "list blobs", prefix); err != nil {
+			return nil, err
+		}
+
+		var page azureBlobList
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode blob list: %w", err)
+		}
+
+		for _, blob := range page.Blobs {
+			info := ObjectInfo{
+				Key:         blob.Name,
+				Size:        blob.Properties.ContentLength,
+				ContentType: blob.Properties.ContentType,
+				ETag:        blob.Properties.ETag,
+			}
+			if modified, err := http.ParseTime(blob.Properties.LastModified); err == nil {
+				info.LastModified = modified
+			}
+			objects = append(objects, info)
+		}
+
+		if page.NextMarker == "" {
+			return objects, nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+// newRequest builds a request for a blob, or for the container when key is
+// empty.
+func (b *AzureBackend) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := b.endpoint + "/" + b.config.ContainerName
+	if key != "" {
+		target = b.URL(key)
+	}
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build azure request: %w", err)
+	}
+	return req, nil
+}
+
+// do authorizes req with the backend's credential and sends it.
+func (b *AzureBackend) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	if err := b.credential.authorize(req.Context(), req); err != nil {
+		return nil, err
+	}
+	return b.client.Do(req)
+}
+
+// escapeKey percent-encodes each segment of a key for use in a URL path.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// objectSize returns the full size of the object behind a GET response,
+// which for a range read is the total in Content-Range.
+func objectSize(resp *http.Response) int64 {
+	if contentRange := resp.Header.Get("Content-Range"); contentRange != "" {
+		if _, total, ok := strings.Cut(contentRange, "/"); ok {
+			if size, err := strconv.ParseInt(total, 10, 64); err == nil {
+				return size
+			}
+		}
+	}
+	return resp.ContentLength
+}
@@ -0,0 +1,370 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sasTimeFormat         = "2006-01-02T15:04:05Z"
+	azureStorageScope     = "https://storage.azure.com/.default"
+	azureAuthorityHost    = "https://login.microsoftonline.com"
+	delegationKeyLifetime = 48 * time.Hour
+	tokenRefreshMargin    = 5 * time.Minute
+)
+
+// sasValues are the signed fields of a blob SAS. Empty fields are left out
+// of the token but keep their place in the string-to-sign.
+type sasValues struct {
+	Permissions string
+	Start       time.Time
+	Expiry      time.Time
+	// Resource is the canonicalized resource, /blob/<account>/<container>/<blob>
+	Resource string
+	IPRange  string
+	Protocol string
+	// ContentType and ContentDisposition override the response headers of
+	// reads made with the SAS (rsct and rscd).
+	ContentType        string
+	ContentDisposition string
+}
+
+// query returns the SAS parameters shared by service and user delegation
+// tokens, without the signature.
+func (v sasValues) query() url.Values {
+	query := url.Values{}
+	query.Set("sv", azureAPIVersion)
+	query.Set("sr", "b")
+	query.Set("sp", v.Permissions)
+	query.Set("st", v.Start.UTC().Format(sasTimeFormat))
+	query.Set("se", v.Expiry.UTC().Format(sasTimeFormat))
+	query.Set("spr", v.Protocol)
+	if v.IPRange != "" {
+		query.Set("sip", v.IPRange)
+	}
+	if v.ContentType != "" {
+		query.Set("rsct", v.ContentType)
+	}
+	if v.ContentDisposition != "" {
+		query.Set("rscd", v.ContentDisposition)
+	}
+	return query
+}
+
+// serviceStringToSign is the string-to-sign of a service SAS.
+func (v sasValues) serviceStringToSign() string {
+	return strings.Join([]string{
+		v.Permissions,
+		v.Start.UTC().Format(sasTimeFormat),
+		v.Expiry.UTC().Format(sasTimeFormat),
+		v.Resource,
+		"", // signed identifier
+		v.IPRange,
+		v.Protocol,
+		azureAPIVersion,
+		"b", // signed resource: blob
+		"",  // snapshot time
+		"",  // encryption scope
+		"",  // rscc
+		v.ContentDisposition,
+		"", // rsce
+		"", // rscl
+		v.ContentType,
+	}, "\n")
+}
+
+// userDelegationStringToSign is the string-to-sign of a user delegation SAS
+// made with key.
+func (v sasValues) userDelegationStringToSign(key *userDelegationKey) string {
+	return strings.Join([]string{
+		v.Permissions,
+		v.Start.UTC().Format(sasTimeFormat),
+		v.Expiry.UTC().Format(sasTimeFormat),
+		v.Resource,
+		key.SignedOID,
+		key.SignedTID,
+		key.SignedStart,
+		key.SignedExpiry,
+		key.SignedService,
+		key.SignedVersion,
+		"", // authorized user object ID
+		"", // unauthorized user object ID
+		"", // correlation ID
+		v.IPRange,
+		v.Protocol,
+		azureAPIVersion,
+		"b", // signed resource: blob
+		"",  // snapshot time
+		"",  // encryption scope
+		"",  // rscc
+		v.ContentDisposition,
+		"", // rsce
+		"", // rscl
+		v.ContentType,
+	}, "\n")
+}
+
+// azureCredential authenticates REST requests and signs SAS tokens.
+type azureCredential interface {
+	authorize(ctx context.Context, req *http.Request) error
+	signSAS(ctx context.Context, values sasValues) (url.Values, error)
+}
+
+// sharedKeyCredential uses the storage account key: Shared Key requests
+// and service SAS tokens.
+type sharedKeyCredential struct {
+	account string
+	key     []byte
+}
+
+func newSharedKeyCredential(account, accountKey string) (*sharedKeyCredential, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid azure account key: %w", err)
+	}
+	return &sharedKeyCredential{account: account, key: key}, nil
+}
+
+func (c *sharedKeyCredential) authorize(ctx context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "SharedKey "+c.account+":"+hmacBase64(c.key, c.stringToSign(req)))
+	return nil
+}
+
+func (c *sharedKeyCredential) signSAS(ctx context.Context, values sasValues) (url.Values, error) {
+	query := values.query()
+	query.Set("sig", hmacBase64(c.key, values.serviceStringToSign()))
+	return query, nil
+}
+
+// stringToSign builds the Shared Key string-to-sign of a request.
+func (c *sharedKeyCredential) stringToSign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	headers := []string{}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-ms-") {
+			headers = append(headers, lower+":"+strings.TrimSpace(req.Header.Get(name)))
+		}
+	}
+	sort.Strings(headers)
+
+	resource := "/" + c.account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, strings.ToLower(name))
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		values := query[name]
+		sort.Strings(values)
+		resource += "\n" + name + ":" + strings.Join(values, ",")
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date, sent as x-ms-date
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		"", // Range, sent as x-ms-range
+		strings.Join(headers, "\n"),
+		resource,
+	}, "\n")
+}
+
+// userDelegationKey is the key Azure issues to an Azure AD principal for
+// signing user delegation SAS tokens.
+type userDelegationKey struct {
+	SignedOID     string `xml:"SignedOid"`
+	SignedTID     string `xml:"SignedTid"`
+	SignedStart   string `xml:"SignedStart"`
+	SignedExpiry  string `xml:"SignedExpiry"`
+	SignedService string `xml:"SignedService"`
+	SignedVersion string `xml:"SignedVersion"`
+	Value         string `xml:"Value"`
+
+	expiresAt time.Time
+	key       []byte
+}
+
+// tokenCredential authenticates as an Azure AD application with a client
+// secret: bearer token requests and user delegation SAS tokens. The
+// application needs the Storage Blob Data Contributor role, which includes
+// generating user delegation keys.
+type tokenCredential struct {
+	endpoint     string
+	tenantID     string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+
+	mu            sync.Mutex
+	token         string
+	tokenExpiry   time.Time
+	delegationKey *userDelegationKey
+}
+
+func (c *tokenCredential) authorize(ctx context.Context, req *http.Request) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (c *tokenCredential) signSAS(ctx context.Context, values sasValues) (url.Values, error) {
+	key, err := c.userDelegationKey(ctx, values.Expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	query := values.query()
+	query.Set("skoid", key.SignedOID)
+	query.Set("sktid", key.SignedTID)
+	query.Set("skt", key.SignedStart)
+	query.Set("ske", key.SignedExpiry)
+	query.Set("sks", key.SignedService)
+	query.Set("skv", key.SignedVersion)
+	query.Set("sig", hmacBase64(key.key, values.userDelegationStringToSign(key)))
+	return query, nil
+}
+
+// accessToken returns a cached token for the storage scope, fetching a new
+// one with the client credentials grant when it is about to expire.
+func (c *tokenCredential) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Add(tokenRefreshMargin).Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+	form.Set("scope", azureStorageScope)
+
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", azureAuthorityHost, url.PathEscape(c.tenantID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get azure access token: %w", err)
+	}
+	if err := checkResponse(resp, "get access token for", c.clientID); err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode azure access token: %w", err)
+	}
+
+	c.token = body.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	return c.token, nil
+}
+
+// userDelegationKey returns a cached delegation key valid until at least
+// until, requesting a new one when needed.
+func (c *tokenCredential) userDelegationKey(ctx context.Context, until time.Time) (*userDelegationKey, error) {
+	c.mu.Lock()
+	key := c.delegationKey
+	c.mu.Unlock()
+	if key != nil && until.Before(key.expiresAt) {
+		return key, nil
+	}
+
+	lifetime := delegationKeyLifetime
+	if needed := time.Until(until) + time.Hour; needed > lifetime {
+		lifetime = needed
+	}
+	now := time.Now().UTC()
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"KeyInfo"`
+		Start   string   `xml:"Start"`
+		Expiry  string   `xml:"Expiry"`
+	}{
+		Start:  now.Add(-sasClockSkew).Format(sasTimeFormat),
+		Expiry: now.Add(lifetime).Format(sasTimeFormat),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.endpoint+"/?restype=service&comp=userdelegationkey", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user delegation key request: %w", err)
+	}
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("x-ms-date", now.Format(http.TimeFormat))
+	if err := c.authorize(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user delegation key: %w", err)
+	}
+	if err := checkResponse(resp, "get user delegation key for", c.clientID); err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	key = &userDelegationKey{}
+	if err := xml.NewDecoder(resp.Body).Decode(key); err != nil {
+		return nil, fmt.Errorf("failed to decode user delegation key: %w", err)
+	}
+	if key.key, err = base64.StdEncoding.DecodeString(key.Value); err != nil {
+		return nil, fmt.Errorf("invalid user delegation key: %w", err)
+	}
+	if key.expiresAt, err = time.Parse(time.RFC3339, key.SignedExpiry); err != nil {
+		return nil, fmt.Errorf("invalid user delegation key expiry: %w", err)
+	}
+
+	c.mu.Lock()
+	c.delegationKey = key
+	c.mu.Unlock()
+	return key, nil
+}
+
+func hmacBase64(key []byte, stringToSign string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// devAccountKey is the well-known key of Azurite's devstoreaccount1.
+const devAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+// exampleSAS holds the fields of the blob example in Azure's service SAS
+// documentation.
+func exampleSAS(t *testing.T) sasValues {
+	t.Helper()
+	parse := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	return sasValues{
+		Permissions: "rw",
+		Start:       parse("2015-04-29T22:18:26Z"),
+		Expiry:      parse("2015-04-30T02:23:26Z"),
+		Resource:    "/blob/myaccount/pictures/profile.jpg",
+		IPRange:     "168.1.5.60-168.1.5.70",
+		Protocol:    "https",
+	}
+}
+
+func TestHMACBase64(t *testing.T) {
+	// RFC 4231 test case 2
+	got := hmacBase64([]byte("Jefe"), "what do ya want for nothing?")
+	want := "W9zBRr9gdU5qBCQmCJV1x1oAPwidJzmDnexYuWTsOEM="
+	if got != want {
+		t.Errorf("hmacBase64 = %s, want %s", got, want)
+	}
+}
+
+func TestServiceStringToSign(t *testing.T) {
+	values := exampleSAS(t)
+
+	// Fields in the order api-version 2020-12-06 and later sign them
+	want := strings.Join([]string{
+		"rw",                                   // signedPermissions
+		"2015-04-29T22:18:26Z",                 // signedStart
+		"2015-04-30T02:23:26Z",                 // signedExpiry
+		"/blob/myaccount/pictures/profile.jpg", // canonicalizedResource
+		"",                                     // signedIdentifier
+		"168.1.5.60-168.1.5.70",                // signedIP
+		"https",                                // signedProtocol
+		"2021-08-06",                           // signedVersion
+		"b",                                    // signedResource
+		"",                                     // signedSnapshotTime
+		"",                                     // signedEncryptionScope
+		"",                                     // rscc
+		"",                                     // rscd
+		"",                                     // rsce
+		"",                                     // rscl
+		"",                                     // rsct
+	}, "\n")
+	if got := values.serviceStringToSign(); got != want {
+		t.Errorf("serviceStringToSign =\n%q\nwant\n%q", got, want)
+	}
+
+	cred, err := newSharedKeyCredential("devstoreaccount1", devAccountKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := cred.signSAS(context.Background(), values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := query.Get("sig"), "qj0tGmHX0s41XQOY8m8FQ80dO0AJdYTBiELtsxzY69w="; got != want {
+		t.Errorf("sig = %s, want %s", got, want)
+	}
+	if got := query.Get("sv"); got != "2021-08-06" {
+		t.Errorf("sv = %s, want 2021-08-06", got)
+	}
+}
+
+func TestUserDelegationStringToSign(t *testing.T) {
+	values := exampleSAS(t)
+	values.Permissions = "r"
+	values.IPRange = ""
+	values.ContentType = "image/jpeg"
+	values.ContentDisposition = `attachment; filename="profile.jpg"`
+
+	key := &userDelegationKey{
+		SignedOID:     "00000000-0000-0000-0000-000000000001",
+		SignedTID:     "00000000-0000-0000-0000-000000000002",
+		SignedStart:   "2015-04-29T22:00:00Z",
+		SignedExpiry:  "2015-04-30T04:00:00Z",
+		SignedService: "b",
+		SignedVersion: "2021-08-06",
+	}
+
+	want := strings.Join([]string{
+		"r",                                    // signedPermissions
+		"2015-04-29T22:18:26Z",                 // signedStart
+		"2015-04-30T02:23:26Z",                 // signedExpiry
+		"/blob/myaccount/pictures/profile.jpg", // canonicalizedResource
+		"00000000-0000-0000-0000-000000000001", // signedKeyObjectId
+		"00000000-0000-0000-0000-000000000002", // signedKeyTenantId
+		"2015-04-29T22:00:00Z",                 // signedKeyStart
+		"2015-04-30T04:00:00Z",                 // signedKeyExpiry
+		"b",                                    // signedKeyService
+		"2021-08-06",                           // signedKeyVersion
+		"",                                     // signedAuthorizedUserObjectId
+		"",                                     // signedUnauthorizedUserObjectId
+		"",                                     // signedCorrelationId
+		"",                                     // signedIP
+		"https",                                // signedProtocol
+		"2021-08-06",                           // signedVersion
+		"b",                                    // signedResource
+		"",                                     // signedSnapshotTime
+		"",                                     // signedEncryptionScope
+		"",                                     // rscc
+		`attachment; filename="profile.jpg"`,   // rscd
+		"",                                     // rsce
+		"",                                     // rscl
+		"image/jpeg",                           // rsct
+	}, "\n")
+	got := values.userDelegationStringToSign(key)
+	if got != want {
+		t.Errorf("userDelegationStringToSign =\n%q\nwant\n%q", got, want)
+	}
+
+	keyBytes, err := base64.StdEncoding.DecodeString(devAccountKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig, want := hmacBase64(keyBytes, got), "RaAqjyCBIYYJgKAn10K6Fq3hKAAGK6nvxMNOCmusVzY="; sig != want {
+		t.Errorf("sig = %s, want %s", sig, want)
+	}
+}
@@ -0,0 +1,372 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalRoutePrefix is where media-service serves LocalBackend's signed URLs.
+const LocalRoutePrefix = "/storage"
+
+const localTempPrefix = ".upload-"
+
+// LocalConfig configures filesystem storage. BaseURL is the address clients
+// reach media-service on; SigningKey signs the URLs it serves.
+type LocalConfig struct {
+	Root       string
+	BaseURL    string
+	SigningKey string
+}
+
+// LocalBackend stores objects as files under a root directory, for
+// development and single-node deployments. Presigned URLs point back at
+// media-service, which serves them through ServeHTTP.
+type LocalBackend struct {
+	root    string
+	baseURL string
+	key     []byte
+}
+
+func NewLocalBackend(config LocalConfig) (*LocalBackend, error) {
+	if config.Root == "" || config.BaseURL == "" || config.SigningKey == "" {
+		return nil, errors.New("local storage requires a root directory, base URL and signing key")
+	}
+
+	root, err := filepath.Abs(config.Root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local storage root: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage root: %w", err)
+	}
+
+	return &LocalBackend{
+		root:    root,
+		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+		key:     []byte(config.SigningKey),
+	}, nil
+}
+
+func (b *LocalBackend) Driver() string {
+	return DriverLocal
+}
+
+func (b *LocalBackend) URL(key string) string {
+	return b.baseURL + LocalRoutePrefix + "/" + escapeKey(key)
+}
+
+func (b *LocalBackend) PresignUpload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error) {
+	opts = withDefaults(opts, defaultUploadExpiry)
+	presigned, err := b.presign(http.MethodPut, key, opts)
+	if err != nil {
+		return nil, err
+	}
+	presigned.Headers = map[string]string{}
+	if opts.ContentType != "" {
+		presigned.Headers["Content-Type"] = opts.ContentType
+	}
+	if opts.ContentLength > 0 {
+		presigned.Headers["Content-Length"] = strconv.FormatInt(opts.ContentLength, 10)
+	}
+	return presigned, nil
+}
+
+func (b *LocalBackend) PresignDownload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error) {
+	return b.presign(http.MethodGet, key, withDefaults(opts, defaultDownloadExpiry))
+}
+
+func (b *LocalBackend) presign(method, key string, opts PresignOptions) (*PresignedURL, error) {
+	if _, err := b.path(key); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(opts.Expiry)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	contentLength := ""
+	if method == http.MethodPut && opts.ContentLength > 0 {
+		contentLength = strconv.FormatInt(opts.ContentLength, 10)
+	}
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	if opts.ContentType != "" {
+		query.Set("content_type", opts.ContentType)
+	}
+	if contentLength != "" {
+		query.Set("content_length", contentLength)
+	}
+	query.Set("sig", b.sign(method, key, expires, opts.ContentType, contentLength))
+
+	return &PresignedURL{
+		URL:       b.URL(key) + "?" + query.Encode(),
+		Method:    method,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// sign covers everything a presigned URL grants. GET URLs also allow HEAD.
+func (b *LocalBackend) sign(method, key, expires, contentType, contentLength string) string {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(strings.Join([]string{method, key, expires, contentType, contentLength}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *LocalBackend) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	target, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	// Write to a temporary file first so readers never see a partial object
+	tmp, err := os.CreateTemp(filepath.Dir(target), localTempPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create file for %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("failed to write %s: got %d bytes, expected %d", key, written, size)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return nil
+}
+
+func (b *LocalBackend) Get(ctx context.Context, key string, rng *ByteRange) (io.ReadCloser, *ObjectInfo, error) {
+	target, err := b.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(target)
+	if err != nil {
+		return nil, nil, localError(err, "open", key)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, localError(err, "stat", key)
+	}
+	info := localObjectInfo(key, stat)
+
+	if rng == nil {
+		return file, info, nil
+	}
+	if err := rng.validate(); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if rng.Start >= info.Size {
+		file.Close()
+		return nil, nil, fmt.Errorf("%w for %s", ErrInvalidRange, key)
+	}
+
+	end := rng.End
+	if end < 0 || end >= info.Size {
+		end = info.Size - 1
+	}
+	section := io.NewSectionReader(file, rng.Start, end-rng.Start+1)
+	return struct {
+		io.Reader
+		io.Closer
+	}{section, file}, info, nil
+}
+
+func (b *LocalBackend) Delete(ctx context.Context, key string) error {
+	target, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil {
+		return localError(err, "delete", key)
+	}
+	return nil
+}
+
+func (b *LocalBackend) Head(ctx context.Context, key string) (*ObjectInfo, error) {
+	target, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(target)
+	if err != nil {
+		return nil, localError(err, "stat", key)
+	}
+	if stat.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return localObjectInfo(key, stat), nil
+}
+
+func (b *LocalBackend) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+
+	// Walk the deepest directory the prefix names in full
+	dir := b.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		var err error
+		if dir, err = b.path(prefix[:i]); err != nil {
+			return nil, err
+		}
+	}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), localTempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *localObjectInfo(key, stat))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+
+	return objects, nil
+}
+
+// ServeHTTP serves the URLs made by PresignUpload and PresignDownload.
+// Mount it on LocalRoutePrefix.
+func (b *LocalBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, LocalRoutePrefix+"/")
+	query := r.URL.Query()
+	expires := query.Get("expires")
+	contentType := query.Get("content_type")
+	contentLength := query.Get("content_length")
+
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresUnix {
+		http.Error(w, "signed URL expired", http.StatusForbidden)
+		return
+	}
+	expected := b.sign(method, key, expires, contentType, contentLength)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("sig"))) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		target, err := b.path(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, err := os.Open(target)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer file.Close()
+
+		stat, err := file.Stat()
+		if err != nil || stat.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", localObjectInfo(key, stat).ContentType)
+		http.ServeContent(w, r, path.Base(key), stat.ModTime(), file)
+
+	case http.MethodPut:
+		if contentType != "" && r.Header.Get("Content-Type") != contentType {
+			http.Error(w, "content type does not match signed URL", http.StatusForbidden)
+			return
+		}
+		if contentLength != "" && strconv.FormatInt(r.ContentLength, 10) != contentLength {
+			http.Error(w, "content length does not match signed URL", http.StatusForbidden)
+			return
+		}
+		if err := b.Put(r.Context(), key, r.Body, r.ContentLength, contentType); err != nil {
+			http.Error(w, "failed to store object", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// path maps key to a file under the root, refusing keys that would escape
+// it.
+func (b *LocalBackend) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(b.root, filepath.FromSlash(cleaned)), nil
+}
+
+func localObjectInfo(key string, stat fs.FileInfo) *ObjectInfo {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  contentType,
+		ETag:         fmt.Sprintf(`"%x-%x"`, stat.ModTime().UnixNano(), stat.Size()),
+		LastModified: stat.ModTime(),
+	}
+}
+
+func localError(err error, op, key string) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return fmt.Errorf("failed to %s %s: %w", op, key, err)
+}
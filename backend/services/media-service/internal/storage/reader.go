@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// ReaderAt reads an object of known size through ranged Gets, so a file
+// can be inspected without downloading it. Each ReadAt is one request.
+type ReaderAt struct {
+	ctx     context.Context
+	backend Backend
+	key     string
+	size    int64
+}
+
+func NewReaderAt(ctx context.Context, backend Backend, key string, size int64) *ReaderAt {
+	return &ReaderAt{
+		ctx:     ctx,
+		backend: backend,
+		key:     key,
+		size:    size,
+	}
+}
+
+func (r *ReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrInvalidRange
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	end := min(off+int64(len(p)), r.size)
+	body, _, err := r.backend.Get(r.ctx, r.key, &ByteRange{Start: off, End: end - 1})
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	n, err := io.ReadFull(body, p[:end-off])
+	if err != nil {
+		return n, err
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,422 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3MaxPresignAge   = 7 * 24 * time.Hour
+	s3DateFormat      = "20060102T150405Z"
+)
+
+// S3Config configures an S3-compatible bucket. Set Endpoint and
+// UsePathStyle for MinIO and other non-AWS stores.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool
+}
+
+// S3Backend stores objects in an S3-compatible bucket, signing requests
+// with AWS Signature Version 4.
+type S3Backend struct {
+	config S3Config
+	base   *url.URL
+	client *http.Client
+}
+
+func NewS3Backend(config S3Config) (*S3Backend, error) {
+	if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("s3 storage requires a bucket, access key ID and secret access key")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	base, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", endpoint)
+	}
+
+	return &S3Backend{
+		config: config,
+		base:   base,
+		client: &http.Client{},
+	}, nil
+}
+
+func (b *S3Backend) Driver() string {
+	return DriverS3
+}
+
+func (b *S3Backend) URL(key string) string {
+	return b.objectURL(key).String()
+}
+
+// objectURL addresses key in path style or virtual-hosted style, or the
+// bucket itself when key is empty.
+func (b *S3Backend) objectURL(key string) *url.URL {
+	u := *b.base
+	path, rawPath := "", ""
+	if b.config.UsePathStyle {
+		path, rawPath = "/"+b.config.Bucket, "/"+s3Escape(b.config.Bucket, true)
+	} else {
+		u.Host = b.config.Bucket + "." + u.Host
+	}
+	if key != "" || path == "" {
+		path += "/" + key
+		rawPath += "/" + s3Escape(key, false)
+	}
+
+	u.Path = b.base.Path + path
+	u.RawPath = b.base.EscapedPath() + rawPath
+	return &u
+}
+
+// PresignUpload signs a PUT URL. The content type and length are signed
+// headers, so S3 rejects uploads that differ from them.
+func (b *S3Backend) PresignUpload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error) {
+	opts = withDefaults(opts, defaultUploadExpiry)
+	headers := map[string]string{}
+	if opts.ContentType != "" {
+		headers["Content-Type"] = opts.ContentType
+	}
+	if opts.ContentLength > 0 {
+		headers["Content-Length"] = strconv.FormatInt(opts.ContentLength, 10)
+	}
+	return b.presign(http.MethodPut, key, opts.Expiry, headers, nil)
+}
+
+func (b *S3Backend) PresignDownload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error) {
+	opts = withDefaults(opts, defaultDownloadExpiry)
+	query := url.Values{}
+	if opts.ContentType != "" {
+		query.Set("response-content-type", opts.ContentType)
+	}
+	return b.presign(http.MethodGet, key, opts.Expiry, nil, query)
+}
+
+// presign signs a query-string authenticated URL. Headers are included in
+// the signature and must be sent by the client.
+func (b *S3Backend) presign(method, key string, expiry time.Duration, headers map[string]string, query url.Values) (*PresignedURL, error) {
+	if expiry > s3MaxPresignAge {
+		expiry = s3MaxPresignAge
+	}
+
+	now := time.Now().UTC()
+	u := b.objectURL(key)
+
+	signed := map[string]string{"host": u.Host}
+	for name, value := range headers {
+		signed[strings.ToLower(name)] = value
+	}
+	signedHeaders := s3SignedHeaders(signed)
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", b.config.AccessKeyID+"/"+b.scope(now))
+	query.Set("X-Amz-Date", now.Format(s3DateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+
+	canonical := b.canonicalRequest(method, u.EscapedPath(), query, signed, s3UnsignedPayload)
+	query.Set("X-Amz-Signature", b.signature(now, canonical))
+	u.RawQuery = s3CanonicalQuery(query)
+
+	return &PresignedURL{
+		URL:       u.String(),
+		Method:    method,
+		Headers:   headers,
+		ExpiresAt: now.Add(expiry),
+	}, nil
+}
+
+func (b *S3Backend) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.URL(key), body)
+	if err != nil {
+		return fmt.Errorf("failed to build s3 request: %w", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "put object", key); err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (b *S3Backend) Get(ctx context.Context, key string, rng *ByteRange) (io.ReadCloser, *ObjectInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.URL(key), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build s3 request: %w", err)
+	}
+	if rng != nil {
+		if err := rng.validate(); err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Range", rng.Header())
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "get object", key); err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, objectInfoFromHeaders(key, resp.Header, objectSize(resp)), nil
+}
+
+// Delete removes key. S3 reports success for keys that do not exist, so
+// Delete never returns ErrNotFound.
+func (b *S3Backend) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, b.URL(key), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build s3 request: %w", err)
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "delete object", key); err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (b *S3Backend) Head(ctx context.Context, key string) (*ObjectInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, b.URL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build s3 request: %w", err)
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to head object %s: %w", key, err)
+	}
+	if err := checkResponse(resp, "head object", key); err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return objectInfoFromHeaders(key, resp.Header, resp.ContentLength), nil
+}
+
+type s3ObjectList struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (b *S3Backend) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+	token := ""
+
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		u := b.objectURL("")
+		u.RawQuery = s3CanonicalQuery(query)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build s3 request: %w", err)
+		}
+
+		resp, err := b.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		if err := checkResponse(resp, "list objects", prefix); err != nil {
+			return nil, err
+		}
+
+		var page s3ObjectList
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode object list: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          object.Key,
+				Size:         object.Size,
+				ETag:         object.ETag,
+				LastModified: object.LastModified,
+			})
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// do signs req in the Authorization header and sends it. The payload is
+// left unsigned so bodies can be streamed.
+func (b *S3Backend) do(req *http.Request) (*http.Response, error) {
+	now := time.Now().UTC()
+	req.Header.Set("x-amz-date", now.Format(s3DateFormat))
+	req.Header.Set("x-amz-content-sha256", s3UnsignedPayload)
+
+	signed := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			signed[lower] = req.Header.Get(name)
+		}
+	}
+
+	canonical := b.canonicalRequest(req.Method, req.URL.EscapedPath(), req.URL.Query(), signed, s3UnsignedPayload)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm,
+		b.config.AccessKeyID,
+		b.scope(now),
+		s3SignedHeaders(signed),
+		b.signature(now, canonical),
+	))
+
+	return b.client.Do(req)
+}
+
+func (b *S3Backend) canonicalRequest(method, path string, query url.Values, headers map[string]string, payloadHash string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	return strings.Join([]string{
+		method,
+		path,
+		s3CanonicalQuery(query),
+		canonicalHeaders.String(),
+		strings.Join(names, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+func (b *S3Backend) scope(t time.Time) string {
+	return t.Format("20060102") + "/" + b.config.Region + "/s3/aws4_request"
+}
+
+func (b *S3Backend) signature(t time.Time, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3DateFormat),
+		b.scope(t),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+b.config.SecretAccessKey), t.Format("20060102"))
+	key = hmacSHA256(key, b.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func s3SignedHeaders(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ";")
+}
+
+// s3CanonicalQuery encodes query sorted by name with SigV4's escaping,
+// which differs from url.Values.Encode for spaces and reserved characters.
+func s3CanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := []string{}
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(name, true)+"="+s3Escape(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes everything but unreserved characters, and
+// slashes too when encodeSlash is set.
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
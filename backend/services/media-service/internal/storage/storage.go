@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/scouttalent/pkg/azure"
+)
+
+// Storage drivers selectable with Config.Driver
+const (
+	DriverAzure = "azure"
+	DriverS3    = "s3"
+	DriverLocal = "local"
+)
+
+var (
+	ErrNotFound      = errors.New("object not found")
+	ErrInvalidRange  = errors.New("invalid byte range")
+	ErrInvalidDriver = errors.New("unknown storage driver")
+)
+
+const (
+	defaultUploadExpiry   = 1 * time.Hour
+	defaultDownloadExpiry = 24 * time.Hour
+)
+
+// Backend stores media objects by key. Keys are slash-separated paths
+// relative to the configured container or bucket.
+type Backend interface {
+	// PresignUpload returns a URL the client can PUT the object to directly.
+	PresignUpload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error)
+	// PresignDownload returns a URL the client can GET the object from.
+	PresignDownload(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error)
+	// Put stores size bytes read from body under key, replacing any object
+	// already there.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the object, or the part of it within rng when rng is not
+	// nil. The caller closes the reader.
+	Get(ctx context.Context, key string, rng *ByteRange) (io.ReadCloser, *ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	Head(ctx context.Context, key string) (*ObjectInfo, error)
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// URL returns the unsigned location of key, as stored on the video.
+	URL(key string) string
+	// Driver names the implementation, one of the Driver constants.
+	Driver() string
+}
+
+// PresignOptions constrain a presigned URL. Zero values fall back to the
+// backend defaults. For uploads ContentType and ContentLength are what the
+// client must send; for downloads ContentType is what the object is served
+// as. Backends bind them into the signature where the store supports it.
+type PresignOptions struct {
+	Expiry        time.Duration
+	ContentType   string
+	ContentLength int64
+}
+
+// PresignedURL is a time-limited URL and the request the client must make
+// with it. Headers must be sent as given or the signature will not match.
+type PresignedURL struct {
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	ContentType  string    `json:"content_type"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// ByteRange is an inclusive byte range. A negative End reads to the end of
+// the object.
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// Header formats the range as an HTTP Range header value.
+func (r ByteRange) Header() string {
+	if r.End < 0 {
+		return fmt.Sprintf("bytes=%d-", r.Start)
+	}
+	return fmt.Sprintf("bytes=%d-%d", r.Start, r.End)
+}
+
+func (r ByteRange) validate() error {
+	if r.Start < 0 || (r.End >= 0 && r.End < r.Start) {
+		return fmt.Errorf("%w: %s", ErrInvalidRange, r.Header())
+	}
+	return nil
+}
+
+// Config selects and configures the storage driver.
+type Config struct {
+	Driver string
+	Azure  azure.BlobConfig
+	S3     S3Config
+	Local  LocalConfig
+}
+
+// New returns the backend selected by cfg.Driver.
+func New(cfg Config) (Backend, error) {
+	switch cfg.Driver {
+	case DriverAzure:
+		return NewAzureBackend(cfg.Azure)
+	case DriverS3:
+		return NewS3Backend(cfg.S3)
+	case DriverLocal:
+		return NewLocalBackend(cfg.Local)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrInvalidDriver, cfg.Driver)
+	}
+}
+
+// VideoKey is the key a video's original upload is stored under. Only the
+// last element of fileName is used, so it cannot leave the video's prefix.
+func VideoKey(videoID, fileName string) string {
+	name, ok := BaseFileName(fileName)
+	if !ok {
+		name = "original"
+	}
+	return videoID + "/" + name
+}
+
+// BaseFileName strips any directories, including Windows ones, from a
+// client-supplied file name. It reports false when nothing usable is left.
+func BaseFileName(fileName string) (string, bool) {
+	name := path.Base(strings.ReplaceAll(strings.TrimSpace(fileName), `\`, "/"))
+	switch name {
+	case "", ".", "..", "/":
+		return "", false
+	}
+	return name, true
+}
+
+func withDefaults(opts PresignOptions, expiry time.Duration) PresignOptions {
+	if opts.Expiry <= 0 {
+		opts.Expiry = expiry
+	}
+	return opts
+}
+
+// checkResponse turns a non-2xx response from an object store into an
+// error, closing its body.
+func checkResponse(resp *http.Response, op, key string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return fmt.Errorf("%w for %s", ErrInvalidRange, key)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("failed to %s %s: %s: %s", op, key, resp.Status, body)
+}
+
+// objectInfoFromHeaders reads the standard object headers of a HEAD or GET
+// response.
+func objectInfoFromHeaders(key string, h http.Header, size int64) *ObjectInfo {
+	info := &ObjectInfo{
+		Key:         key,
+		Size:        size,
+		ContentType: h.Get("Content-Type"),
+		ETag:        h.Get("ETag"),
+	}
+	if modified, err := http.ParseTime(h.Get("Last-Modified")); err == nil {
+		info.LastModified = modified
+	}
+	return info
+}
@@ -0,0 +1,37 @@
+package storage
+
+import "testing"
+
+func TestVideoKey(t *testing.T) {
+	const id = "0c9a7e51-3d2b-4f86-9e1a-5b7c3d9f2a44"
+
+	tests := []struct {
+		name     string
+		fileName string
+		want     string
+		ok       bool
+	}{
+		{name: "plain", fileName: "match.mp4", want: id + "/match.mp4", ok: true},
+		{name: "trimmed", fileName: "  match.mp4 ", want: id + "/match.mp4", ok: true},
+		{name: "directories", fileName: "clips/2024/match.mp4", want: id + "/match.mp4", ok: true},
+		{name: "parent traversal", fileName: "../../other/match.mp4", want: id + "/match.mp4", ok: true},
+		{name: "windows path", fileName: `C:\Users\jo\match.mp4`, want: id + "/match.mp4", ok: true},
+		{name: "absolute", fileName: "/etc/passwd", want: id + "/passwd", ok: true},
+		{name: "dot dot", fileName: "..", want: id + "/original", ok: false},
+		{name: "trailing dot dot", fileName: "clips/..", want: id + "/original", ok: false},
+		{name: "dot", fileName: ".", want: id + "/original", ok: false},
+		{name: "root", fileName: "/", want: id + "/original", ok: false},
+		{name: "empty", fileName: "", want: id + "/original", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := BaseFileName(tt.fileName); ok != tt.ok {
+				t.Errorf("BaseFileName(%q) ok = %v, want %v", tt.fileName, ok, tt.ok)
+			}
+			if got := VideoKey(id, tt.fileName); got != tt.want {
+				t.Errorf("VideoKey(%q) = %q, want %q", tt.fileName, got, tt.want)
+			}
+		})
+	}
+}
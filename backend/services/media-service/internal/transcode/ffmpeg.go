@@ -0,0 +1,133 @@
+package transcode
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/hls"
+)
+
+const (
+	// segmentDuration is the target HLS segment length. Keyframes are
+	// forced every keyframeInterval so every rendition cuts at the same
+	// points and players can switch between them at any segment.
+	segmentDuration  = 6
+	keyframeInterval = 2
+)
+
+// encode runs ffmpeg once to produce every target from input, writing each
+// rendition to outDir/<name>/ as a VOD playlist and MPEG-TS segments.
+// onProgress is called with how much of the input has been encoded.
+func encode(ctx context.Context, ffmpegPath, input, outDir string, targets []Target, hasAudio bool, onProgress func(time.Duration)) error {
+	cmd := exec.CommandContext(ctx, ffmpegPath, encodeArgs(input, outDir, targets, hasAudio)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start ffmpeg: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start ffmpeg: %w", err)
+	}
+
+	// -progress writes key=value blocks; out_time_us is the position
+	// reached. Older ffmpeg only has out_time_ms, which is also in
+	// microseconds.
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || (key != "out_time_us" && key != "out_time_ms") {
+			continue
+		}
+		if us, err := strconv.ParseInt(value, 10, 64); err == nil && us >= 0 {
+			onProgress(time.Duration(us) * time.Microsecond)
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("ffmpeg failed: %w: %s", err, lastLines(stderr.String(), 5))
+	}
+	return nil
+}
+
+func encodeArgs(input, outDir string, targets []Target, hasAudio bool) []string {
+	args := []string{
+		"-hide_banner", "-nostdin", "-y",
+		"-loglevel", "error",
+		"-progress", "pipe:1", "-nostats",
+		"-i", input,
+	}
+
+	// Decode once and scale for each rendition
+	var filter strings.Builder
+	fmt.Fprintf(&filter, "[0:v]split=%d", len(targets))
+	for i := range targets {
+		fmt.Fprintf(&filter, "[s%d]", i)
+	}
+	for i, t := range targets {
+		fmt.Fprintf(&filter, ";[s%d]scale=%d:%d[v%d]", i, t.Width, t.Height, i)
+	}
+	args = append(args, "-filter_complex", filter.String())
+
+	streamMap := make([]string, len(targets))
+	for i, t := range targets {
+		args = append(args, "-map", fmt.Sprintf("[v%d]", i))
+		if hasAudio {
+			args = append(args, "-map", "0:a:0")
+		}
+
+		n := strconv.Itoa(i)
+		args = append(args,
+			"-b:v:"+n, strconv.Itoa(t.VideoBitrate),
+			"-maxrate:v:"+n, strconv.Itoa(t.VideoBitrate*107/100),
+			"-bufsize:v:"+n, strconv.Itoa(t.VideoBitrate*3/2),
+			"-level:v:"+n, fmt.Sprintf("%d.%d", t.Level/10, t.Level%10),
+		)
+		if hasAudio {
+			args = append(args, "-b:a:"+n, strconv.Itoa(t.AudioBitrate))
+			streamMap[i] = fmt.Sprintf("v:%d,a:%d,name:%s", i, i, t.Name)
+		} else {
+			streamMap[i] = fmt.Sprintf("v:%d,name:%s", i, t.Name)
+		}
+	}
+
+	args = append(args,
+		"-c:v", "libx264",
+		"-profile:v", "main",
+		"-preset", "veryfast",
+		"-pix_fmt", "yuv420p",
+		"-sc_threshold", "0",
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", keyframeInterval),
+	)
+	if hasAudio {
+		args = append(args, "-c:a", "aac", "-ac", "2", "-ar", "48000")
+	}
+	args = append(args,
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(segmentDuration),
+		"-hls_playlist_type", "vod",
+		"-hls_flags", "independent_segments",
+		"-hls_segment_filename", filepath.Join(outDir, "%v", "segment_%05d.ts"),
+		"-var_stream_map", strings.Join(streamMap, " "),
+		filepath.Join(outDir, "%v", hls.VariantPlaylist),
+	)
+	return args
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "; ")
+}
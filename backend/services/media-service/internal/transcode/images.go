@@ -0,0 +1,186 @@
+package transcode
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/scouttalent/media-service/internal/images"
+	"github.com/scouttalent/media-service/internal/probe"
+)
+
+const (
+	candidateCount = 4
+	// posterSize and thumbnailSize bound the longer side of the images
+	posterSize    = 1280
+	thumbnailSize = 640
+
+	spriteTileWidth = 160
+	spriteColumns   = 10
+	maxSpriteTiles  = 100
+	// minSpriteInterval keeps short videos from getting a tile per frame
+	minSpriteInterval = 2 * time.Second
+
+	// frameSampleSize is how many frames the thumbnail filter compares to
+	// pick a representative one, skipping fades and blurred frames.
+	frameSampleSize = 25
+)
+
+// frame is a still rendered from the video.
+type frame struct {
+	File   string
+	Offset time.Duration
+	Width  int
+	Height int
+}
+
+// imageSet is everything renderImages made. Cues is empty when the video's
+// duration is unknown and no sprite was made.
+type imageSet struct {
+	Poster     frame
+	Candidates []frame
+	Cues       []images.Cue
+}
+
+// renderImages writes the poster, thumbnail candidates and seek preview
+// sprite of source to outDir.
+func renderImages(ctx context.Context, ffmpegPath, source, outDir string, meta *probe.Metadata) (*imageSet, error) {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create image directory: %w", err)
+	}
+	width, height := meta.DisplaySize()
+	duration := meta.Duration
+
+	set := &imageSet{}
+
+	// The poster is taken early, past any fade in; candidates spread
+	// evenly through the video
+	set.Poster = frame{File: images.Poster, Offset: duration / 10}
+	set.Poster.Width, set.Poster.Height = fit(width, height, posterSize)
+	if err := grabFrame(ctx, ffmpegPath, source, outDir, set.Poster); err != nil {
+		return nil, err
+	}
+
+	count := candidateCount
+	if duration <= 0 {
+		count = 1
+	}
+	for i := 1; i <= count; i++ {
+		candidate := frame{
+			File:   images.CandidateFile(i),
+			Offset: duration * time.Duration(i) / time.Duration(count+1),
+		}
+		candidate.Width, candidate.Height = fit(width, height, thumbnailSize)
+		if err := grabFrame(ctx, ffmpegPath, source, outDir, candidate); err != nil {
+			return nil, err
+		}
+		set.Candidates = append(set.Candidates, candidate)
+	}
+
+	if duration > 0 {
+		cues, err := renderSprite(ctx, ffmpegPath, source, outDir, width, height, duration)
+		if err != nil {
+			return nil, err
+		}
+		set.Cues = cues
+	}
+
+	return set, nil
+}
+
+// grabFrame renders a representative frame near f.Offset as a JPEG.
+func grabFrame(ctx context.Context, ffmpegPath, source, outDir string, f frame) error {
+	args := []string{
+		"-hide_banner", "-nostdin", "-y",
+		"-loglevel", "error",
+		// Seeking before the input jumps to the nearest keyframe
+		// instead of decoding up to the offset
+		"-ss", seconds(f.Offset),
+		"-i", source,
+		"-vf", fmt.Sprintf("thumbnail=%d,scale=%d:%d", frameSampleSize, f.Width, f.Height),
+		"-frames:v", "1",
+		"-q:v", "3",
+		filepath.Join(outDir, f.File),
+	}
+	return runFFmpeg(ctx, ffmpegPath, args, "render "+f.File)
+}
+
+// renderSprite tiles one frame per interval into a sprite sheet and
+// returns the cues indexing it. The interval grows with the duration so the
+// sheet never exceeds maxSpriteTiles.
+func renderSprite(ctx context.Context, ffmpegPath, source, outDir string, width, height int, duration time.Duration) ([]images.Cue, error) {
+	interval := max(minSpriteInterval, (duration/maxSpriteTiles + time.Second - 1).Truncate(time.Second))
+	tiles := min(maxSpriteTiles, int(math.Ceil(float64(duration)/float64(interval))))
+	columns := min(spriteColumns, tiles)
+	rows := (tiles + columns - 1) / columns
+	tileWidth, tileHeight := spriteTileWidth, even(float64(spriteTileWidth)*float64(height)/float64(width))
+
+	args := []string{
+		"-hide_banner", "-nostdin", "-y",
+		"-loglevel", "error",
+		"-i", source,
+		"-vf", fmt.Sprintf("fps=1/%s,scale=%d:%d,tile=%dx%d", seconds(interval), tileWidth, tileHeight, columns, rows),
+		"-frames:v", "1",
+		"-q:v", "5",
+		filepath.Join(outDir, images.Sprite),
+	}
+	if err := runFFmpeg(ctx, ffmpegPath, args, "render sprite"); err != nil {
+		return nil, err
+	}
+
+	cues := make([]images.Cue, tiles)
+	for i := range cues {
+		end := time.Duration(i+1) * interval
+		if i == tiles-1 {
+			end = duration
+		}
+		cues[i] = images.Cue{
+			Start:  time.Duration(i) * interval,
+			End:    end,
+			X:      i % columns * tileWidth,
+			Y:      i / columns * tileHeight,
+			Width:  tileWidth,
+			Height: tileHeight,
+		}
+	}
+
+	var track bytes.Buffer
+	if err := images.WriteTrack(&track, images.Sprite, cues); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(outDir, images.SpriteTrack), track.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write sprite track: %w", err)
+	}
+
+	return cues, nil
+}
+
+func runFFmpeg(ctx context.Context, ffmpegPath string, args []string, what string) error {
+	cmd := exec.CommandContext(ctx, ffmpegPath, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to %s: %w: %s", what, err, lastLines(stderr.String(), 5))
+	}
+	return nil
+}
+
+// fit scales width x height down so its longer side is at most size,
+// keeping the aspect ratio and even dimensions.
+func fit(width, height, size int) (int, int) {
+	scale := min(1, float64(size)/float64(max(width, height)))
+	return even(float64(width) * scale), even(float64(height) * scale)
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
@@ -0,0 +1,76 @@
+// Package transcode turns uploaded videos into an adaptive HLS ladder with
+// ffmpeg. Jobs are rows in transcode_jobs; the Worker claims them, encodes
+// every rendition in one ffmpeg run and uploads the result next to the
+// original.
+package transcode
+
+import (
+	"fmt"
+	"math"
+)
+
+// Profile is one rung of the ladder. Height is the short side of the
+// output, so portrait videos get the same rungs as landscape ones.
+type Profile struct {
+	Name         string
+	Height       int
+	VideoBitrate int
+	AudioBitrate int
+	// Level is the H.264 level times ten
+	Level int
+}
+
+// Ladder is every rendition offered, lowest first.
+var Ladder = []Profile{
+	{Name: "240p", Height: 240, VideoBitrate: 400_000, AudioBitrate: 64_000, Level: 30},
+	{Name: "360p", Height: 360, VideoBitrate: 800_000, AudioBitrate: 96_000, Level: 30},
+	{Name: "480p", Height: 480, VideoBitrate: 1_400_000, AudioBitrate: 128_000, Level: 31},
+	{Name: "720p", Height: 720, VideoBitrate: 2_800_000, AudioBitrate: 128_000, Level: 31},
+	{Name: "1080p", Height: 1080, VideoBitrate: 5_000_000, AudioBitrate: 192_000, Level: 40},
+}
+
+// Target is a profile scaled to a particular source.
+type Target struct {
+	Profile
+	Width  int
+	Height int
+}
+
+// Codecs is the RFC 6381 codecs string of the rendition: H.264 Main at the
+// profile's level, plus AAC-LC when there is audio.
+func (t Target) Codecs(hasAudio bool) string {
+	codecs := fmt.Sprintf("avc1.4d40%02x", t.Level)
+	if hasAudio {
+		codecs += ",mp4a.40.2"
+	}
+	return codecs
+}
+
+// Plan picks the rungs for a source displayed at width x height. Rungs
+// above the source's short side would only upscale, so they are left out,
+// but the lowest rung is always produced. Sizes keep the aspect ratio and
+// are rounded to even numbers as 4:2:0 chroma requires.
+func Plan(width, height int) []Target {
+	short := min(width, height)
+	var targets []Target
+	for i, p := range Ladder {
+		if p.Height > short && i > 0 {
+			break
+		}
+		scale := 1.0
+		if p.Height < short {
+			scale = float64(p.Height) / float64(short)
+		}
+		targets = append(targets, Target{
+			Profile: p,
+			Width:   even(float64(width) * scale),
+			Height:  even(float64(height) * scale),
+		})
+	}
+	return targets
+}
+
+func even(v float64) int {
+	n := int(math.Round(v/2)) * 2
+	return max(n, 2)
+}
@@ -0,0 +1,597 @@
+package transcode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/scouttalent/media-service/internal/hls"
+	"github.com/scouttalent/media-service/internal/images"
+	"github.com/scouttalent/media-service/internal/model"
+	"github.com/scouttalent/media-service/internal/probe"
+	"github.com/scouttalent/media-service/internal/repository"
+	"github.com/scouttalent/media-service/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	queueGroup = "media-transcoder"
+
+	// leaseDuration is how long a claimed job stays with its worker
+	// without a heartbeat before another worker may take it over.
+	leaseDuration     = 2 * time.Minute
+	heartbeatInterval = 15 * time.Second
+	sweepInterval     = 30 * time.Second
+	// retryBackoff doubles with every failed attempt
+	retryBackoff = 30 * time.Second
+	// encodeShare is the part of the progress bar encoding fills; the
+	// rest is the upload.
+	encodeShare = 90
+)
+
+var (
+	// ErrUnsupported fails a job without retrying: the source cannot be
+	// transcoded however many times it is tried.
+	ErrUnsupported = errors.New("unsupported video")
+
+	// errLeaseLost means the job was taken over or deleted while this
+	// worker held it, so its result must be dropped.
+	errLeaseLost = errors.New("transcode job lease lost")
+)
+
+// Config configures a Worker.
+type Config struct {
+	FFmpegPath  string
+	FFprobePath string
+	// WorkDir holds the source and output of each job while it runs
+	WorkDir     string
+	Concurrency int
+	// BaseURL is where clients reach media-service, for image URLs
+	BaseURL string
+}
+
+// Worker runs transcode jobs. The database is the queue: NATS requests
+// only get a job started sooner, and a periodic sweep finds jobs whose
+// request was missed, whose retry is due or whose worker died.
+type Worker struct {
+	repo    *repository.MediaRepository
+	storage storage.Backend
+	cfg     Config
+	logger  *zap.Logger
+	queue   chan string
+}
+
+func NewWorker(repo *repository.MediaRepository, store storage.Backend, cfg Config, logger *zap.Logger) *Worker {
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	return &Worker{
+		repo:    repo,
+		storage: store,
+		cfg:     cfg,
+		logger:  logger,
+		queue:   make(chan string, cfg.Concurrency),
+	}
+}
+
+// Subscribe starts taking job requests from media-service.
+func (w *Worker) Subscribe(nc *nats.Conn) (*nats.Subscription, error) {
+	// Queue subscription so each request reaches one transcoder
+	sub, err := nc.QueueSubscribe(model.TranscodeSubject, queueGroup, func(msg *nats.Msg) {
+		var req model.TranscodeRequest
+		if err := json.Unmarshal(msg.Data, &req); err != nil {
+			w.logger.Error("failed to decode transcode request", zap.Error(err))
+			return
+		}
+		w.notify(req.JobID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", model.TranscodeSubject, err)
+	}
+	return sub, nil
+}
+
+// Run processes jobs until ctx is cancelled, then hands back the jobs in
+// progress and returns.
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case id := <-w.queue:
+					w.process(ctx, id)
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		w.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// notify queues a job for the next free worker. When all are busy the
+// request is dropped; the sweep finds the job once one is free.
+func (w *Worker) notify(jobID string) {
+	select {
+	case w.queue <- jobID:
+	default:
+	}
+}
+
+func (w *Worker) sweep(ctx context.Context) {
+	ids, err := w.repo.ListDueTranscodeJobs(ctx, w.cfg.Concurrency)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to list due transcode jobs", zap.Error(err))
+		}
+		return
+	}
+	for _, id := range ids {
+		w.notify(id)
+	}
+}
+
+func (w *Worker) process(ctx context.Context, jobID string) {
+	job, err := w.repo.ClaimTranscodeJob(ctx, jobID, time.Now().Add(leaseDuration))
+	if err != nil {
+		w.logger.Error("failed to claim transcode job", zap.String("job_id", jobID), zap.Error(err))
+		return
+	}
+	if job == nil {
+		// Claimed by another worker, or not due yet
+		return
+	}
+
+	logger := w.logger.With(
+		zap.String("job_id", job.ID),
+		zap.String("video_id", job.VideoID),
+		zap.Int("attempt", job.Attempts),
+	)
+	logger.Info("transcoding video")
+
+	err = w.transcode(ctx, job, logger)
+	switch {
+	case err == nil:
+		logger.Info("transcoded video")
+	case ctx.Err() != nil:
+		// Shutting down; the attempt is not the job's fault
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := w.repo.ReleaseTranscodeJob(releaseCtx, job.ID, job.Attempts); err != nil {
+			logger.Error("failed to release transcode job", zap.Error(err))
+		}
+	case errors.Is(err, errLeaseLost):
+		logger.Warn("transcode job was taken over, dropping the result")
+	default:
+		w.fail(ctx, job, err, logger)
+	}
+}
+
+// fail schedules another attempt, or fails the job and its video when
+// the error is permanent or the attempts are used up.
+func (w *Worker) fail(ctx context.Context, job *model.TranscodeJob, cause error, logger *zap.Logger) {
+	msg := cause.Error()
+
+	if !errors.Is(cause, ErrUnsupported) && job.Attempts < job.MaxAttempts {
+		next := time.Now().Add(retryBackoff << (job.Attempts - 1))
+		if _, err := w.repo.RetryTranscodeJob(ctx, job.ID, job.Attempts, msg, next); err != nil {
+			logger.Error("failed to schedule transcode retry", zap.Error(err))
+			return
+		}
+		logger.Warn("transcode attempt failed, retrying", zap.Time("next_attempt_at", next), zap.Error(cause))
+		return
+	}
+
+	reason := "video could not be processed"
+	if errors.Is(cause, ErrUnsupported) {
+		reason = msg
+	}
+	err := w.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		ok, err := repo.FinishTranscodeJob(ctx, job.ID, job.Attempts, model.TranscodeStatusFailed, &msg)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errLeaseLost
+		}
+		failed, err := repo.TransitionVideo(ctx, job.VideoID, model.VideoStatusProcessing, model.VideoStatusFailed, reason)
+		if err != nil || !failed {
+			return err
+		}
+		video, err := repo.GetVideoByID(ctx, job.VideoID)
+		if err != nil {
+			return err
+		}
+		return repo.EnqueueVideoEvent(ctx, model.EventVideoProcessed, video)
+	})
+	if err != nil {
+		logger.Error("failed to fail transcode job", zap.Error(err))
+		return
+	}
+	logger.Error("transcoding failed", zap.Error(cause))
+
+	if err := w.deletePrefix(ctx, hls.Prefix(job.VideoID)); err != nil {
+		logger.Error("failed to delete partial renditions", zap.Error(err))
+	}
+}
+
+func (w *Worker) transcode(ctx context.Context, job *model.TranscodeJob, logger *zap.Logger) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	// The heartbeat reports progress and keeps the lease, and stops the
+	// job if the lease was lost
+	var progress atomic.Int64
+	done := make(chan struct{})
+	defer close(done)
+	go w.heartbeat(ctx, job, &progress, done, cancel, logger)
+
+	err := w.run(ctx, job, &progress)
+	if cause := context.Cause(ctx); errors.Is(cause, errLeaseLost) {
+		return cause
+	}
+	return err
+}
+
+func (w *Worker) heartbeat(ctx context.Context, job *model.TranscodeJob, progress *atomic.Int64, done <-chan struct{}, cancel context.CancelCauseFunc, logger *zap.Logger) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ok, err := w.repo.UpdateTranscodeProgress(ctx, job.ID, job.Attempts, int(progress.Load()), time.Now().Add(leaseDuration))
+		if err != nil {
+			// A missed heartbeat is survivable; the lease outlasts several
+			logger.Warn("failed to report transcode progress", zap.Error(err))
+			continue
+		}
+		if !ok {
+			cancel(errLeaseLost)
+			return
+		}
+	}
+}
+
+func (w *Worker) run(ctx context.Context, job *model.TranscodeJob, progress *atomic.Int64) error {
+	video, err := w.repo.GetVideoByID(ctx, job.VideoID)
+	if err != nil {
+		return fmt.Errorf("failed to get video: %w", err)
+	}
+
+	dir, err := os.MkdirTemp(w.cfg.WorkDir, "transcode-")
+	if err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "source")
+	if err := w.download(ctx, storage.VideoKey(video.ID, video.FileName), source); err != nil {
+		return err
+	}
+
+	meta, err := probe.FFProbe(ctx, w.cfg.FFprobePath, source)
+	if errors.Is(err, probe.ErrNoVideoStream) {
+		return fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// What was probed is kept even if the file is rejected, so the owner
+	// can see why
+	applyMetadata(video, meta)
+	if err := w.repo.SetVideoMetadata(ctx, video); err != nil {
+		return err
+	}
+	if err := probe.CheckCodecs(meta); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	width, height := meta.DisplaySize()
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("%w: video has no frame size", ErrUnsupported)
+	}
+
+	targets := Plan(width, height)
+	out := filepath.Join(dir, "hls")
+	err = encode(ctx, w.cfg.FFmpegPath, source, out, targets, meta.HasAudio, func(position time.Duration) {
+		if meta.Duration > 0 {
+			progress.Store(int64(min(encodeShare, int(encodeShare*position/meta.Duration))))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	imageDir := filepath.Join(dir, "images")
+	rendered, err := renderImages(ctx, w.cfg.FFmpegPath, source, imageDir, meta)
+	if err != nil {
+		return err
+	}
+	progress.Store(encodeShare)
+
+	// Clear renditions of an earlier attempt that may not be overwritten
+	if err := w.deletePrefix(ctx, hls.Prefix(video.ID)); err != nil {
+		return fmt.Errorf("failed to clear old renditions: %w", err)
+	}
+
+	renditions := make([]model.Rendition, 0, len(targets))
+	for i, target := range targets {
+		rendition, err := w.uploadRendition(ctx, video.ID, filepath.Join(out, target.Name), target, meta.HasAudio)
+		if err != nil {
+			return err
+		}
+		renditions = append(renditions, *rendition)
+		progress.Store(int64(encodeShare + (100-encodeShare)*(i+1)/len(targets) - 1))
+	}
+
+	var master bytes.Buffer
+	if err := hls.WriteMaster(&master, renditions); err != nil {
+		return fmt.Errorf("failed to write master playlist: %w", err)
+	}
+	masterKey := hls.Key(video.ID, hls.MasterPlaylist)
+	if err := w.storage.Put(ctx, masterKey, &master, int64(master.Len()), hls.PlaylistContentType); err != nil {
+		return fmt.Errorf("failed to upload master playlist: %w", err)
+	}
+
+	videoImages, err := w.uploadImages(ctx, video.ID, imageDir, rendered)
+	if err != nil {
+		return err
+	}
+
+	return w.repo.InTx(ctx, func(repo *repository.MediaRepository) error {
+		ok, err := repo.FinishTranscodeJob(ctx, job.ID, job.Attempts, model.TranscodeStatusCompleted, nil)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errLeaseLost
+		}
+		if err := repo.ReplaceRenditions(ctx, video.ID, renditions); err != nil {
+			return err
+		}
+		if err := repo.ReplaceThumbnailCandidates(ctx, video.ID, videoImages.Candidates); err != nil {
+			return err
+		}
+		if err := repo.SetVideoImages(ctx, video.ID, videoImages); err != nil {
+			return err
+		}
+		if err := repo.SetVideoStream(ctx, video.ID, masterKey); err != nil {
+			return err
+		}
+		processed, err := repo.TransitionVideo(ctx, video.ID, model.VideoStatusProcessing, model.VideoStatusAwaitingModeration, "")
+		if err != nil {
+			return err
+		}
+		if !processed {
+			w.logger.Warn("video left processing while it was transcoded", zap.String("video_id", video.ID))
+			return nil
+		}
+		// The video is published once moderation approves it
+		video.Status = model.VideoStatusAwaitingModeration
+		return repo.EnqueueVideoEvent(ctx, model.EventVideoProcessed, video)
+	})
+}
+
+// uploadRendition stores one rendition's playlist and segments and
+// measures its bandwidth from the segments ffmpeg wrote.
+func (w *Worker) uploadRendition(ctx context.Context, videoID, dir string, target Target, hasAudio bool) (*model.Rendition, error) {
+	playlist, err := os.ReadFile(filepath.Join(dir, hls.VariantPlaylist))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s playlist: %w", target.Name, err)
+	}
+	segments, err := hls.Segments(playlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s playlist: %w", target.Name, err)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("%s playlist has no segments", target.Name)
+	}
+
+	var total int64
+	var duration time.Duration
+	peak := 0
+	for _, segment := range segments {
+		path := filepath.Join(dir, filepath.Base(segment.URI))
+		size, err := w.uploadFile(ctx, path, hls.Key(videoID, target.Name+"/"+filepath.Base(segment.URI)), hls.SegmentContentType)
+		if err != nil {
+			return nil, err
+		}
+		total += size
+		duration += segment.Duration
+		if segment.Duration > 0 {
+			peak = max(peak, bitsPerSecond(size, segment.Duration))
+		}
+	}
+
+	playlistKey := hls.Key(videoID, hls.VariantURI(target.Name))
+	err = w.storage.Put(ctx, playlistKey, bytes.NewReader(playlist), int64(len(playlist)), hls.PlaylistContentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s playlist: %w", target.Name, err)
+	}
+
+	average := peak
+	if duration > 0 {
+		average = bitsPerSecond(total, duration)
+	}
+
+	return &model.Rendition{
+		VideoID:          videoID,
+		Name:             target.Name,
+		Width:            target.Width,
+		Height:           target.Height,
+		VideoBitrate:     target.VideoBitrate,
+		AudioBitrate:     audioBitrate(target, hasAudio),
+		Bandwidth:        peak,
+		AverageBandwidth: average,
+		Codecs:           target.Codecs(hasAudio),
+		PlaylistKey:      playlistKey,
+		SegmentCount:     len(segments),
+		SizeBytes:        total,
+	}, nil
+}
+
+// uploadImages stores the rendered images next to any the owner uploaded
+// and returns their URLs.
+func (w *Worker) uploadImages(ctx context.Context, videoID, dir string, set *imageSet) (*model.VideoImages, error) {
+	files := []string{set.Poster.File}
+	for _, candidate := range set.Candidates {
+		files = append(files, candidate.File)
+	}
+	if len(set.Cues) > 0 {
+		files = append(files, images.Sprite, images.SpriteTrack)
+	}
+	for _, file := range files {
+		if _, err := w.uploadFile(ctx, filepath.Join(dir, file), images.Key(videoID, file), images.ContentType(file)); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &model.VideoImages{
+		PosterURL: images.URL(w.cfg.BaseURL, videoID, set.Poster.File),
+	}
+	if len(set.Cues) > 0 {
+		result.SeekPreviewURL = images.URL(w.cfg.BaseURL, videoID, images.SpriteTrack)
+	}
+	for _, candidate := range set.Candidates {
+		offset := int(candidate.Offset.Milliseconds())
+		result.Candidates = append(result.Candidates, model.Thumbnail{
+			VideoID:  videoID,
+			Kind:     model.ThumbnailKindCandidate,
+			ImageKey: images.Key(videoID, candidate.File),
+			URL:      images.URL(w.cfg.BaseURL, videoID, candidate.File),
+			OffsetMS: &offset,
+			Width:    candidate.Width,
+			Height:   candidate.Height,
+		})
+	}
+	return result, nil
+}
+
+func (w *Worker) uploadFile(ctx context.Context, path, key, contentType string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if err := w.storage.Put(ctx, key, f, stat.Size(), contentType); err != nil {
+		return 0, fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return stat.Size(), nil
+}
+
+func (w *Worker) download(ctx context.Context, key, path string) error {
+	body, _, err := w.storage.Get(ctx, key, nil)
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("%w: the uploaded file is missing", ErrUnsupported)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download source: %w", err)
+	}
+	defer body.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create source file: %w", err)
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to download source: %w", err)
+	}
+	return f.Close()
+}
+
+func (w *Worker) deletePrefix(ctx context.Context, prefix string) error {
+	objects, err := w.storage.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if err := w.storage.Delete(ctx, object.Key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMetadata copies what ffprobe read onto the video. The duration the
+// container recorded is kept when ffprobe could not measure one.
+func applyMetadata(video *model.Video, meta *probe.Metadata) {
+	if meta.Duration > 0 {
+		seconds := int(meta.Duration.Round(time.Second) / time.Second)
+		video.Duration = &seconds
+	}
+	width, height := meta.DisplaySize()
+	video.Width = optional(width)
+	video.Height = optional(height)
+	video.FrameRate = optional(meta.FrameRate)
+	video.VideoCodec = optional(meta.VideoCodec)
+	video.AudioCodec = optional(meta.AudioCodec)
+	video.Bitrate = optional(meta.Bitrate)
+	rotation := meta.Rotation
+	video.Rotation = &rotation
+	video.RecordedAt = nil
+	if !meta.CreationTime.IsZero() {
+		recordedAt := meta.CreationTime
+		video.RecordedAt = &recordedAt
+	}
+	video.Metadata = meta.Map()
+}
+
+// optional returns nil for the zero value, which ffprobe leaves for
+// fields it did not report.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+func bitsPerSecond(size int64, d time.Duration) int {
+	return int(math.Ceil(float64(size*8) / d.Seconds()))
+}
+
+func audioBitrate(target Target, hasAudio bool) int {
+	if !hasAudio {
+		return 0
+	}
+	return target.AudioBitrate
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_videos_profile_public;
+ALTER TABLE videos DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,4 @@
+ALTER TABLE videos ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public';
+
+CREATE INDEX idx_videos_profile_public ON videos(profile_id, created_at DESC)
+    WHERE visibility = 'public' AND status = 'ready';
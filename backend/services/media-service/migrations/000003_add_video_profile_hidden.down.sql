@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_videos_profile_public;
+CREATE INDEX idx_videos_profile_public ON videos(profile_id, created_at DESC)
+    WHERE visibility = 'public' AND status = 'ready';
+
+ALTER TABLE videos DROP COLUMN IF EXISTS profile_hidden;
@@ -0,0 +1,7 @@
+-- Set while the owning profile is deactivated or taken down in
+-- profile-service; hidden videos are left out of every listing.
+ALTER TABLE videos ADD COLUMN profile_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+DROP INDEX IF EXISTS idx_videos_profile_public;
+CREATE INDEX idx_videos_profile_public ON videos(profile_id, created_at DESC)
+    WHERE visibility = 'public' AND status = 'ready' AND NOT profile_hidden;
@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS idx_uploads_expires_at;
+
+ALTER TABLE uploads DROP CONSTRAINT IF EXISTS uploads_offset_check;
+ALTER TABLE uploads DROP CONSTRAINT IF EXISTS uploads_status_check;
+
+UPDATE uploads SET status = CASE status
+    WHEN 'completed' THEN 'ready'
+    WHEN 'failed' THEN 'failed'
+    WHEN 'expired' THEN 'failed'
+    ELSE 'uploading'
+END;
+
+ALTER TABLE uploads ADD CONSTRAINT uploads_status_check
+    CHECK (status IN ('uploading', 'processing', 'ready', 'failed'));
+
+ALTER TABLE uploads
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS error_msg,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS upload_length,
+    DROP COLUMN IF EXISTS upload_offset;
+
+ALTER TABLE videos DROP COLUMN IF EXISTS file_name;
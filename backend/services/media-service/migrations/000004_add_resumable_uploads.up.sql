@@ -0,0 +1,27 @@
+-- Storage keys are derived from the uploaded file's name.
+ALTER TABLE videos ADD COLUMN IF NOT EXISTS file_name VARCHAR(255) NOT NULL DEFAULT '';
+
+-- tus resumable uploads: the server tracks how many bytes it has stored and
+-- when an unfinished upload is abandoned.
+ALTER TABLE uploads
+    ADD COLUMN upload_offset BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN upload_length BIGINT,
+    ADD COLUMN expires_at TIMESTAMP,
+    ADD COLUMN error_msg TEXT,
+    ADD COLUMN completed_at TIMESTAMP;
+
+ALTER TABLE uploads DROP CONSTRAINT uploads_status_check;
+
+UPDATE uploads SET status = CASE status
+    WHEN 'uploading' THEN 'in_progress'
+    WHEN 'failed' THEN 'failed'
+    ELSE 'completed'
+END;
+
+ALTER TABLE uploads ADD CONSTRAINT uploads_status_check
+    CHECK (status IN ('initiated', 'in_progress', 'completed', 'failed', 'expired'));
+ALTER TABLE uploads ADD CONSTRAINT uploads_offset_check
+    CHECK (upload_offset >= 0 AND (upload_length IS NULL OR upload_offset <= upload_length));
+
+CREATE INDEX idx_uploads_expires_at ON uploads(expires_at)
+    WHERE status IN ('initiated', 'in_progress');
@@ -0,0 +1,3 @@
+ALTER TABLE videos
+    DROP COLUMN IF EXISTS failure_reason,
+    DROP COLUMN IF EXISTS content_hash;
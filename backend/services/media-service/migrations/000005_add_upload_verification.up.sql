@@ -0,0 +1,5 @@
+-- Results of verifying an upload before processing: the SHA-256 of the
+-- file, and why it was rejected when verification failed.
+ALTER TABLE videos
+    ADD COLUMN content_hash VARCHAR(64),
+    ADD COLUMN failure_reason TEXT;
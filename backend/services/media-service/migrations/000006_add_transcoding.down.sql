@@ -0,0 +1,4 @@
+ALTER TABLE videos DROP COLUMN IF EXISTS hls_master_key;
+
+DROP TABLE IF EXISTS video_renditions;
+DROP TABLE IF EXISTS transcode_jobs;
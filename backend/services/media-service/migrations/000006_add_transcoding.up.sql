@@ -0,0 +1,49 @@
+-- HLS transcoding: one job per video, retried with backoff until it
+-- succeeds or runs out of attempts. A running job holds a lease the worker
+-- renews while ffmpeg makes progress; an expired lease means the worker
+-- died and the job can be claimed again.
+CREATE TABLE IF NOT EXISTS transcode_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    video_id UUID NOT NULL UNIQUE REFERENCES videos(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 3,
+    progress INTEGER NOT NULL DEFAULT 0,
+    error_msg TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT transcode_jobs_status_check CHECK (status IN ('queued', 'running', 'completed', 'failed')),
+    CONSTRAINT transcode_jobs_progress_check CHECK (progress >= 0 AND progress <= 100)
+);
+
+CREATE INDEX idx_transcode_jobs_due ON transcode_jobs(next_attempt_at)
+    WHERE status IN ('queued', 'running');
+
+-- One row per HLS rendition of a video. Bandwidths are measured from the
+-- encoded segments, bitrates are the encoder targets.
+CREATE TABLE IF NOT EXISTS video_renditions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    video_id UUID NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    name VARCHAR(20) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    video_bitrate INTEGER NOT NULL,
+    audio_bitrate INTEGER NOT NULL DEFAULT 0,
+    bandwidth INTEGER NOT NULL,
+    average_bandwidth INTEGER NOT NULL,
+    codecs VARCHAR(100) NOT NULL,
+    playlist_key TEXT NOT NULL,
+    segment_count INTEGER NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT video_renditions_name_unique UNIQUE (video_id, name)
+);
+
+-- Set once the renditions are written; ready videos stream from it.
+ALTER TABLE videos ADD COLUMN hls_master_key TEXT;
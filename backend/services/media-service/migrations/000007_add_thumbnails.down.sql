@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS video_thumbnails;
+
+ALTER TABLE videos DROP COLUMN IF EXISTS seek_preview_url;
+ALTER TABLE videos DROP COLUMN IF EXISTS poster_url;
@@ -0,0 +1,23 @@
+-- Images generated while transcoding. The URLs point at media-service's
+-- image route, which redirects to signed storage URLs, so they stay valid
+-- for readers of this table such as discovery-service.
+ALTER TABLE videos ADD COLUMN poster_url TEXT;
+ALTER TABLE videos ADD COLUMN seek_preview_url TEXT;
+
+-- Thumbnails the owner can choose from: frames picked while transcoding
+-- and at most one uploaded image. The chosen one is videos.thumbnail_url.
+CREATE TABLE IF NOT EXISTS video_thumbnails (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    video_id UUID NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    image_key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    offset_ms INTEGER,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT video_thumbnails_kind_check CHECK (kind IN ('candidate', 'custom'))
+);
+
+CREATE INDEX idx_video_thumbnails_video_id ON video_thumbnails(video_id);
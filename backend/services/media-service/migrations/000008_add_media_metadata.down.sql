@@ -0,0 +1,9 @@
+ALTER TABLE videos
+    DROP COLUMN IF EXISTS recorded_at,
+    DROP COLUMN IF EXISTS rotation,
+    DROP COLUMN IF EXISTS bitrate,
+    DROP COLUMN IF EXISTS audio_codec,
+    DROP COLUMN IF EXISTS video_codec,
+    DROP COLUMN IF EXISTS frame_rate,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width;
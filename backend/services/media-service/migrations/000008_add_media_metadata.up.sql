@@ -0,0 +1,12 @@
+-- Stream details ffprobe reads from the upload before transcoding. The
+-- fields below are the ones worth filtering on; videos.metadata holds
+-- everything probed. width and height are as displayed, after rotation.
+ALTER TABLE videos
+    ADD COLUMN width INTEGER,
+    ADD COLUMN height INTEGER,
+    ADD COLUMN frame_rate DOUBLE PRECISION,
+    ADD COLUMN video_codec VARCHAR(32),
+    ADD COLUMN audio_codec VARCHAR(32),
+    ADD COLUMN bitrate BIGINT,
+    ADD COLUMN rotation SMALLINT,
+    ADD COLUMN recorded_at TIMESTAMPTZ;
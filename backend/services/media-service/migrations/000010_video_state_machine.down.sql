@@ -0,0 +1,19 @@
+DROP TABLE IF EXISTS video_status_history;
+
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_status_check;
+
+UPDATE videos SET status = CASE status
+    WHEN 'uploaded' THEN 'processing'
+    WHEN 'awaiting_moderation' THEN 'processing'
+    WHEN 'published' THEN 'ready'
+    WHEN 'archived' THEN 'ready'
+    WHEN 'rejected' THEN 'failed'
+    ELSE status
+END;
+
+ALTER TABLE videos ADD CONSTRAINT videos_status_check
+    CHECK (status IN ('uploading', 'processing', 'ready', 'failed'));
+
+DROP INDEX IF EXISTS idx_videos_profile_public;
+CREATE INDEX idx_videos_profile_public ON videos(profile_id, created_at DESC)
+    WHERE visibility = 'public' AND status = 'ready' AND NOT profile_hidden;
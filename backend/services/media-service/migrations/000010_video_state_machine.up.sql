@@ -0,0 +1,41 @@
+-- Align the stored statuses with the state machine in model.VideoStatus.
+-- Videos created as 'pending' never finished uploading, and 'ready' videos
+-- were visible without moderation, so they stay published.
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_status_check;
+
+UPDATE videos SET status = CASE status
+    WHEN 'pending' THEN 'uploading'
+    WHEN 'ready' THEN 'published'
+    WHEN 'approved' THEN 'published'
+    WHEN 'moderated' THEN 'published'
+    ELSE status
+END
+WHERE status IN ('pending', 'ready', 'approved', 'moderated');
+
+ALTER TABLE videos ALTER COLUMN status SET DEFAULT 'uploading';
+ALTER TABLE videos ADD CONSTRAINT videos_status_check CHECK (status IN (
+    'uploading', 'uploaded', 'processing', 'awaiting_moderation',
+    'published', 'rejected', 'failed', 'archived'
+));
+
+DROP INDEX IF EXISTS idx_videos_profile_public;
+CREATE INDEX idx_videos_profile_public ON videos(profile_id, created_at DESC)
+    WHERE visibility = 'public' AND status = 'published' AND NOT profile_hidden;
+
+-- Every status a video has been in. Rows are only written by
+-- MediaRepository.TransitionVideo and CreateVideo, in the statement that
+-- changes the status.
+CREATE TABLE IF NOT EXISTS video_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    video_id UUID NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_video_status_history_video_id ON video_status_history(video_id, id);
+
+-- Existing videos start their history in the status they are in
+INSERT INTO video_status_history (video_id, to_status, created_at)
+SELECT id, status, updated_at FROM videos;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_videos_discovery;
+DROP INDEX IF EXISTS idx_videos_share_token;
+ALTER TABLE videos DROP COLUMN IF EXISTS share_token;
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_visibility_check;
@@ -0,0 +1,15 @@
+-- Visibility was stored without being checked; anything unknown becomes
+-- public, the column default.
+UPDATE videos SET visibility = 'public'
+WHERE visibility NOT IN ('public', 'scouts_only', 'private', 'unlisted');
+
+ALTER TABLE videos ADD CONSTRAINT videos_visibility_check
+    CHECK (visibility IN ('public', 'scouts_only', 'private', 'unlisted'));
+
+-- Opens an unlisted video's share link; cleared when it stops being unlisted
+ALTER TABLE videos ADD COLUMN share_token VARCHAR(64);
+CREATE UNIQUE INDEX idx_videos_share_token ON videos(share_token) WHERE share_token IS NOT NULL;
+
+-- Discovery lists published videos by visibility
+CREATE INDEX idx_videos_discovery ON videos(visibility, created_at DESC)
+    WHERE status = 'published' AND NOT profile_hidden;
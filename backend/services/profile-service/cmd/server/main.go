@@ -8,12 +8,17 @@ import (
 	"syscall"
 
 	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/client"
 	"github.com/scouttalent/profile-service/internal/config"
+	"github.com/scouttalent/profile-service/internal/consumer"
+	"github.com/scouttalent/profile-service/internal/events"
 	"github.com/scouttalent/profile-service/internal/handler"
+	"github.com/scouttalent/profile-service/internal/jobs"
 	"github.com/scouttalent/profile-service/internal/repository"
 	"github.com/scouttalent/profile-service/internal/service"
 	"github.com/scouttalent/pkg/database"
 	"github.com/scouttalent/pkg/logging"
+	"github.com/scouttalent/pkg/messaging"
 	"github.com/scouttalent/pkg/middleware"
 	"go.uber.org/zap"
 )
@@ -46,27 +51,209 @@ func main() {
 
 	// Initialize layers
 	repo := repository.NewProfileRepository(pool)
-	svc := service.NewProfileService(repo)
+	media := client.NewMediaClient(cfg.MediaService.URL, cfg.MediaService.APIKey)
+	if cfg.MediaService.URL == "" {
+		logger.Warn("MEDIA_SERVICE_URL not set, public profiles will not list videos")
+	}
+	auth := client.NewAuthClient(cfg.AuthService.URL, cfg.AuthService.APIKey)
+	if !auth.Enabled() {
+		logger.Warn("AUTH_SERVICE_URL not set, roster imports are limited to dry runs")
+	}
+	svc := service.NewProfileService(repo, media, auth, cfg.PublicBaseURL)
 	h := handler.NewProfileHandler(svc, logger.Logger)
 
+	// Initialize NATS and consumers
+	nc, err := messaging.NewNATSClient(cfg.NATS)
+	if err != nil {
+		logger.Fatal("failed to connect to NATS", zap.Error(err))
+	}
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	if err != nil {
+		logger.Fatal("failed to get JetStream context", zap.Error(err))
+	}
+	if err := events.EnsureStream(js); err != nil {
+		logger.Fatal("failed to set up event stream", zap.Error(err))
+	}
+
+	skillConsumer := consumer.NewSkillConsumer(nc, svc, logger.Logger)
+	if err := skillConsumer.Start(); err != nil {
+		logger.Fatal("failed to start skill consumer", zap.Error(err))
+	}
+	defer skillConsumer.Stop()
+
+	completionConsumer := consumer.NewCompletionConsumer(nc, svc, logger.Logger)
+	if err := completionConsumer.Start(); err != nil {
+		logger.Fatal("failed to start completion consumer", zap.Error(err))
+	}
+	defer completionConsumer.Stop()
+
+	// Relay outbox events to NATS
+	relay := events.NewRelay(repo, nc, js, logger.Logger)
+	go relay.Run(ctx)
+
+	// Process queued roster imports
+	rosterImporter := jobs.NewRosterImporter(svc, logger.Logger)
+	go rosterImporter.Run(ctx)
+
+	// Hard-delete profiles whose deactivation grace period has ended
+	purger := jobs.NewProfilePurger(svc, logger.Logger)
+	go purger.Run(ctx)
+
+	// Normalize locations saved before they were geocoded
+	go jobs.BackfillLocations(ctx, svc, logger.Logger)
+
+	// Bring stored completion scores in line with the current checklist
+	go jobs.RescoreProfiles(ctx, svc, logger.Logger)
+
 	// Setup router
 	router := gin.Default()
 
 	// Public routes
 	router.GET("/health", h.Health)
 
+	// Internal service-to-service routes
+	if cfg.InternalAPIKey != "" {
+		internal := router.Group("/internal/v1")
+		internal.Use(middleware.InternalAuth(cfg.InternalAPIKey))
+		{
+			internal.POST("/skill-assessments", h.SubmitAIAssessment)
+			internal.GET("/profiles/:id", h.GetInternalProfile)
+		}
+	} else {
+		logger.Warn("INTERNAL_API_KEY not set, internal routes disabled")
+	}
+
+	// Public profile pages
+	public := router.Group("/api/v1/public")
+	{
+		public.GET("/profiles/:slug", h.GetPublicProfile)
+	}
+
+	// Location autocomplete
+	locations := router.Group("/api/v1/locations")
+	{
+		locations.GET("/countries", h.ListCountries)
+		locations.GET("/cities", h.SuggestCities)
+	}
+
 	// Protected routes
 	api := router.Group("/api/v1/profiles")
 	api.Use(middleware.AuthMiddleware(cfg.JWT))
 	{
 		api.POST("", h.CreateProfile)
 		api.GET("/me", h.GetMyProfile)
+		api.GET("/me/blocks", h.ListBlocks)
+		api.GET("/me/mutes", h.ListMutes)
 		api.GET("/:id", h.GetProfile)
 		api.PUT("/:id", h.UpdateProfile)
+		api.DELETE("/:id", h.DeactivateProfile)
+		api.POST("/:id/restore", h.RestoreProfile)
+		api.POST("/:id/takedown", h.TakedownProfile)
+		api.GET("/:id/privacy", h.GetPrivacy)
+		api.PUT("/:id/privacy", h.UpdatePrivacy)
+		api.PUT("/:id/slug", h.UpdateSlug)
+		api.GET("/:id/share-tokens", h.ListShareTokens)
+		api.POST("/:id/share-tokens", h.CreateShareToken)
+		api.DELETE("/:id/share-tokens/:tokenId", h.RevokeShareToken)
+		api.GET("/:id/history", h.GetProfileHistory)
+		api.GET("/:id/completion", h.GetCompletionChecklist)
+		api.GET("/:id/translations", h.ListTranslations)
+		api.PUT("/:id/translations/:locale", h.UpsertTranslation)
+		api.DELETE("/:id/translations/:locale", h.DeleteTranslation)
 		
 		// Player-specific routes
 		api.POST("/:id/player-details", h.CreatePlayerDetails)
+		api.PUT("/:id/player-details", h.UpdatePlayerDetails)
 		api.GET("/:id/player", h.GetPlayerProfile)
+		api.POST("/:id/player/verification", h.VerifyPlayerDetails)
+		api.DELETE("/:id/player/verification", h.RevokePlayerVerification)
+		api.GET("/:id/endorsements", h.ListEndorsements)
+		api.POST("/:id/endorsements", h.EndorsePlayer)
+		api.PUT("/:id/endorsements/:endorsementId", h.RespondToEndorsement)
+		api.DELETE("/:id/endorsements/:endorsementId", h.RevokeEndorsement)
+
+		// Career history and season stats
+		api.GET("/stat-schema", h.GetStatSchema)
+		api.GET("/:id/career", h.ListCareer)
+		api.POST("/:id/career", h.CreateCareerEntry)
+		api.PUT("/:id/career/:entryId", h.UpdateCareerEntry)
+		api.DELETE("/:id/career/:entryId", h.DeleteCareerEntry)
+		api.POST("/:id/career/:entryId/confirm", h.ConfirmCareerEntry)
+		api.POST("/:id/career/:entryId/reject", h.RejectCareerEntry)
+		api.POST("/:id/career/:entryId/stats", h.CreateSeasonStats)
+		api.PUT("/:id/career/:entryId/stats/:statsId", h.UpdateSeasonStats)
+		api.DELETE("/:id/career/:entryId/stats/:statsId", h.DeleteSeasonStats)
+
+		// Follow graph
+		api.GET("/:id/followers", h.ListFollowers)
+		api.GET("/:id/following", h.ListFollowing)
+		api.GET("/:id/relationship", h.GetRelationship)
+		api.POST("/:id/follow", h.Follow)
+		api.DELETE("/:id/follow", h.Unfollow)
+		api.POST("/:id/block", h.Block)
+		api.DELETE("/:id/block", h.Unblock)
+		api.POST("/:id/mute", h.Mute)
+		api.DELETE("/:id/mute", h.Unmute)
+
+		// Academy staff
+		api.GET("/:id/staff", h.ListAcademyStaff)
+		api.POST("/:id/staff", h.AddAcademyStaff)
+		api.DELETE("/:id/staff/:memberId", h.RemoveAcademyStaff)
+
+		// Academy roster imports
+		api.GET("/roster-import-fields", h.GetRosterImportFields)
+		api.GET("/:id/roster-imports", h.ListRosterImports)
+		api.POST("/:id/roster-imports", h.CreateRosterImport)
+		api.GET("/:id/roster-imports/:importId", h.GetRosterImport)
+		api.GET("/:id/roster-imports/:importId/rows", h.ListRosterImportRows)
+		api.POST("/:id/roster-imports/:importId/commit", h.CommitRosterImport)
+		api.POST("/:id/roster-imports/:importId/cancel", h.CancelRosterImport)
+		api.POST("/:id/roster-imports/:importId/resume", h.ResumeRosterImport)
+
+		// Scouting report templates and player reports
+		api.GET("/:id/report-templates", h.ListReportTemplates)
+		api.POST("/:id/report-templates", h.CreateReportTemplate)
+		api.PUT("/:id/report-templates/:templateId", h.UpdateReportTemplate)
+		api.DELETE("/:id/report-templates/:templateId", h.DeleteReportTemplate)
+		api.GET("/:id/scouting-reports", h.ListPlayerReports)
+		api.GET("/:id/scouting-summary", h.GetScoutingSummary)
+
+		// Skill assessments
+		api.GET("/skills/taxonomy", h.GetSkillTaxonomy)
+		api.GET("/:id/skills/assessments", h.ListSkillAssessments)
+		api.POST("/:id/skills/assessments", h.SubmitCoachAssessment)
+		api.GET("/:id/skills/history/:skill", h.GetSkillHistory)
+	}
+
+	// Scout shortlists
+	shortlists := router.Group("/api/v1/shortlists")
+	shortlists.Use(middleware.AuthMiddleware(cfg.JWT))
+	{
+		shortlists.GET("", h.ListShortlists)
+		shortlists.POST("", h.CreateShortlist)
+		shortlists.GET("/:id", h.GetShortlist)
+		shortlists.PUT("/:id", h.UpdateShortlist)
+		shortlists.DELETE("/:id", h.DeleteShortlist)
+		shortlists.GET("/:id/export", h.ExportShortlist)
+		shortlists.PUT("/:id/order", h.ReorderShortlist)
+		shortlists.POST("/:id/entries", h.AddShortlistEntry)
+		shortlists.PUT("/:id/entries/:entryId", h.UpdateShortlistEntry)
+		shortlists.DELETE("/:id/entries/:entryId", h.RemoveShortlistEntry)
+	}
+
+	// Scouting reports
+	reports := router.Group("/api/v1/scouting-reports")
+	reports.Use(middleware.AuthMiddleware(cfg.JWT))
+	{
+		reports.POST("", h.CreateReport)
+		reports.GET("/:id", h.GetReport)
+		reports.PUT("/:id", h.UpdateReport)
+		reports.DELETE("/:id", h.DeleteReport)
+		reports.GET("/:id/versions", h.ListReportVersions)
+		reports.GET("/:id/versions/:version", h.GetReportVersion)
+		reports.GET("/:id/export", h.ExportReport)
 	}
 
 	// Start server
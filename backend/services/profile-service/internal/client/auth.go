@@ -0,0 +1,93 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const authRequestTimeout = 5 * time.Second
+
+// ErrAuthDisabled is returned when no auth-service URL is configured.
+var ErrAuthDisabled = errors.New("auth-service client is not configured")
+
+// AuthClient calls auth-service's internal API.
+type AuthClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func NewAuthClient(baseURL, apiKey string) *AuthClient {
+	return &AuthClient{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: authRequestTimeout},
+	}
+}
+
+// Enabled reports whether the client has somewhere to send requests.
+func (c *AuthClient) Enabled() bool {
+	return c != nil && c.baseURL != ""
+}
+
+// AccountStatusInvited is the status of auth-service accounts whose invite
+// has not been accepted yet.
+const AccountStatusInvited = "invited"
+
+// InvitedUser is auth-service's view of an invited account. InviteToken is
+// empty when the account already existed and was active.
+type InvitedUser struct {
+	UserID      string     `json:"user_id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	Created     bool       `json:"created"`
+	InviteToken string     `json:"invite_token,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// InviteUser creates an invite-pending account for email, or returns the
+// existing account with that email. Repeated calls are safe.
+func (c *AuthClient) InviteUser(ctx context.Context, email, role, invitedBy string) (*InvitedUser, error) {
+	if !c.Enabled() {
+		return nil, ErrAuthDisabled
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"email":      email,
+		"role":       role,
+		"invited_by": invitedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode invite request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/internal/v1/users/invite", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build auth request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Token", c.apiKey)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to invite user: auth-service returned %d", resp.StatusCode)
+	}
+
+	var user InvitedUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode invited user: %w", err)
+	}
+
+	return &user, nil
+}
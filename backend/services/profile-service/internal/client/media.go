@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+const mediaRequestTimeout = 3 * time.Second
+
+// MediaClient calls media-service's internal API. A client with an empty
+// base URL is disabled and returns no videos.
+type MediaClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func NewMediaClient(baseURL, apiKey string) *MediaClient {
+	return &MediaClient{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: mediaRequestTimeout},
+	}
+}
+
+// ListPublicVideos returns the profile's ready, publicly visible videos,
+// newest first.
+func (c *MediaClient) ListPublicVideos(ctx context.Context, profileID string, limit int) ([]*model.PublicVideo, error) {
+	if c == nil || c.baseURL == "" {
+		return []*model.PublicVideo{}, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/v1/profiles/%s/public-videos?limit=%s",
+		c.baseURL, url.PathEscape(profileID), strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build media request: %w", err)
+	}
+	req.Header.Set("X-Internal-Token", c.apiKey)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public videos: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list public videos: media-service returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Videos []*model.PublicVideo `json:"videos"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode public videos: %w", err)
+	}
+	if body.Videos == nil {
+		body.Videos = []*model.PublicVideo{}
+	}
+
+	return body.Videos, nil
+}
@@ -11,10 +11,24 @@ import (
 )
 
 type Config struct {
-	ServerAddress string
-	Database      database.Config
-	NATS          messaging.NATSConfig
-	JWT           auth.TokenConfig
+	ServerAddress  string
+	Database       database.Config
+	NATS           messaging.NATSConfig
+	JWT            auth.TokenConfig
+	InternalAPIKey string
+	MediaService   MediaServiceConfig
+	AuthService    AuthServiceConfig
+	PublicBaseURL  string
+}
+
+type MediaServiceConfig struct {
+	URL    string
+	APIKey string
+}
+
+type AuthServiceConfig struct {
+	URL    string
+	APIKey string
 }
 
 func Load() (*Config, error) {
@@ -37,6 +51,16 @@ func Load() (*Config, error) {
 			Issuer:               "scouttalent.com",
 			Audience:             []string{"api.scouttalent.com"},
 		},
+		InternalAPIKey: getEnv("INTERNAL_API_KEY", ""),
+		MediaService: MediaServiceConfig{
+			URL:    getEnv("MEDIA_SERVICE_URL", ""),
+			APIKey: getEnv("MEDIA_SERVICE_API_KEY", ""),
+		},
+		AuthService: AuthServiceConfig{
+			URL:    getEnv("AUTH_SERVICE_URL", ""),
+			APIKey: getEnv("AUTH_SERVICE_API_KEY", ""),
+		},
+		PublicBaseURL: getEnv("PUBLIC_BASE_URL", "https://scouttalent.com"),
 	}
 
 	if cfg.Database.URL == "" {
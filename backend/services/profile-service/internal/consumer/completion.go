@@ -0,0 +1,88 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	VideoUploadedSubject    = "media.video.uploaded"
+	VideoDeletedSubject     = "media.video.deleted"
+	completionHandleTimeout = 10 * time.Second
+)
+
+// CompletionConsumer records facts owned by media-service that count
+// towards profile completion.
+type CompletionConsumer struct {
+	nats    *nats.Conn
+	service *service.ProfileService
+	logger  *zap.Logger
+	subs    []*nats.Subscription
+}
+
+func NewCompletionConsumer(nc *nats.Conn, svc *service.ProfileService, logger *zap.Logger) *CompletionConsumer {
+	return &CompletionConsumer{
+		nats:    nc,
+		service: svc,
+		logger:  logger,
+	}
+}
+
+func (c *CompletionConsumer) Start() error {
+	handlers := map[string]nats.MsgHandler{
+		VideoUploadedSubject: c.handleVideo(c.service.RecordVideoUploaded),
+		VideoDeletedSubject:  c.handleVideo(c.service.RecordVideoDeleted),
+	}
+
+	for subject, handle := range handlers {
+		// Queue subscription so only one replica handles each event
+		sub, err := c.nats.QueueSubscribe(subject, queueGroup, handle)
+		if err != nil {
+			c.Stop()
+			return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+		}
+		c.subs = append(c.subs, sub)
+		c.logger.Info("subscribed to completion events", zap.String("subject", subject))
+	}
+
+	return nil
+}
+
+func (c *CompletionConsumer) Stop() error {
+	for _, sub := range c.subs {
+		if err := sub.Drain(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CompletionConsumer) handleVideo(record func(context.Context, model.VideoEvent) error) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		var event model.VideoEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			c.logger.Error("failed to parse video event", zap.String("subject", msg.Subject), zap.Error(err))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), completionHandleTimeout)
+		defer cancel()
+
+		if err := record(ctx, event); err != nil {
+			c.logger.Error("failed to record video event",
+				zap.String("subject", msg.Subject),
+				zap.String("event_id", event.ID),
+				zap.String("profile_id", event.ProfileID),
+				zap.String("video_id", event.VideoID),
+				zap.Error(err),
+			)
+		}
+	}
+}
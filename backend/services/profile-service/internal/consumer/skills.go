@@ -0,0 +1,80 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	SkillAssessedSubject = "ai.skills.assessed"
+	queueGroup           = "profile-service"
+)
+
+// SkillConsumer ingests scored assessments published by AI scoring workers.
+type SkillConsumer struct {
+	nats    *nats.Conn
+	service *service.ProfileService
+	logger  *zap.Logger
+	sub     *nats.Subscription
+}
+
+func NewSkillConsumer(nc *nats.Conn, svc *service.ProfileService, logger *zap.Logger) *SkillConsumer {
+	return &SkillConsumer{
+		nats:    nc,
+		service: svc,
+		logger:  logger,
+	}
+}
+
+func (c *SkillConsumer) Start() error {
+	// Queue subscription so only one replica handles each assessment
+	sub, err := c.nats.QueueSubscribe(SkillAssessedSubject, queueGroup, c.handleSkillAssessed)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to NATS: %w", err)
+	}
+
+	c.sub = sub
+	c.logger.Info("subscribed to skill assessment events", zap.String("subject", SkillAssessedSubject))
+
+	return nil
+}
+
+func (c *SkillConsumer) Stop() error {
+	if c.sub != nil {
+		return c.sub.Drain()
+	}
+	return nil
+}
+
+func (c *SkillConsumer) handleSkillAssessed(msg *nats.Msg) {
+	var req model.SubmitAssessmentRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		c.logger.Error("failed to parse skill assessment event", zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	assessment, err := c.service.SubmitAIAssessment(ctx, req)
+	if err != nil {
+		c.logger.Error("failed to record skill assessment",
+			zap.String("profile_id", req.ProfileID),
+			zap.String("worker_id", req.WorkerID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	c.logger.Info("recorded skill assessment",
+		zap.String("assessment_id", assessment.ID),
+		zap.String("profile_id", assessment.ProfileID),
+	)
+}
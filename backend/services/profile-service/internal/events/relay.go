@@ -0,0 +1,184 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	pollInterval    = time.Second
+	batchSize       = 100
+	publishTimeout  = 5 * time.Second
+	retention       = 7 * 24 * time.Hour
+	cleanupInterval = time.Hour
+	// maxAttempts is how many times an event is tried before it is set
+	// aside so the events behind it are not held up.
+	maxAttempts = 10
+
+	// streamMaxAge is how long JetStream keeps events for consumers to
+	// catch up on; duplicateWindow is how long it remembers event IDs to
+	// drop a batch the relay sends again.
+	streamMaxAge    = 7 * 24 * time.Hour
+	duplicateWindow = 10 * time.Minute
+)
+
+// EnsureStream creates the stream profile events are published to, or
+// updates it to the current settings.
+func EnsureStream(js nats.JetStreamContext) error {
+	cfg := &nats.StreamConfig{
+		Name:       model.EventStream,
+		Subjects:   []string{model.EventStreamSubjects},
+		Storage:    nats.FileStorage,
+		Retention:  nats.LimitsPolicy,
+		MaxAge:     streamMaxAge,
+		Duplicates: duplicateWindow,
+	}
+
+	_, err := js.StreamInfo(cfg.Name)
+	switch {
+	case errors.Is(err, nats.ErrStreamNotFound):
+		_, err = js.AddStream(cfg)
+	case err == nil:
+		_, err = js.UpdateStream(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set up stream %s: %w", cfg.Name, err)
+	}
+	return nil
+}
+
+// Relay publishes outbox events to JetStream. An event is only marked
+// published once the stream acknowledges it. Delivery is at-least-once: a
+// crash between publishing and marking a batch re-sends it, which the
+// stream drops as duplicates of the event ID (the Nats-Msg-Id header)
+// within its duplicate window; consumers should de-duplicate too.
+type Relay struct {
+	repo   *repository.ProfileRepository
+	nats   *nats.Conn
+	js     nats.JetStreamContext
+	logger *zap.Logger
+}
+
+func NewRelay(repo *repository.ProfileRepository, nc *nats.Conn, js nats.JetStreamContext, logger *zap.Logger) *Relay {
+	return &Relay{
+		repo:   repo,
+		nats:   nc,
+		js:     js,
+		logger: logger,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			n, err := r.relayBatch(ctx)
+			if err != nil {
+				r.logger.Error("failed to relay outbox events", zap.Error(err))
+				break
+			}
+			if n < batchSize {
+				break
+			}
+		}
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			lastCleanup = time.Now()
+			purged, err := r.repo.PurgePublishedEvents(ctx, time.Now().Add(-retention))
+			if err != nil {
+				r.logger.Error("failed to purge outbox events", zap.Error(err))
+			} else if purged > 0 {
+				r.logger.Info("purged published outbox events", zap.Int64("count", purged))
+			}
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	var count int
+	err := r.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		events, err := repo.ClaimOutboxEvents(ctx, batchSize)
+		if err != nil {
+			return err
+		}
+		count = len(events)
+		if count == 0 {
+			return nil
+		}
+
+		published := make([]int64, 0, count)
+		for _, event := range events {
+			err := r.publish(ctx, event)
+			if err == nil {
+				published = append(published, event.ID)
+				continue
+			}
+			if !r.nats.IsConnected() {
+				// Not the event's fault: retry the batch once NATS is back
+				// without counting it against the event.
+				break
+			}
+
+			failed, markErr := repo.MarkOutboxEventFailed(ctx, event.ID, err, maxAttempts)
+			if markErr != nil {
+				return markErr
+			}
+			if !failed {
+				// Preserve ordering: stop at the first failure and retry
+				// the rest of the batch on the next tick.
+				break
+			}
+			r.logger.Error("set aside outbox event after repeated failures",
+				zap.String("event_id", event.EventID),
+				zap.String("subject", event.Subject),
+				zap.Int("attempts", event.Attempts+1),
+				zap.Error(err),
+			)
+		}
+
+		if len(published) > 0 {
+			if err := repo.MarkOutboxEventsPublished(ctx, published, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		if len(published) < count {
+			count = len(published)
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+// publish sends one event and waits for the stream to store it.
+func (r *Relay) publish(ctx context.Context, event *model.OutboxEvent) error {
+	msg := nats.NewMsg(event.Subject)
+	msg.Data = event.Payload
+	msg.Header.Set(nats.MsgIdHdr, event.EventID)
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	if _, err := r.js.PublishMsg(msg, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", event.Subject, err)
+	}
+	return nil
+}
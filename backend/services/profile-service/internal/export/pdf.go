@@ -0,0 +1,221 @@
+// Package export renders documents for download.
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth    = 595.0 // A4 in points
+	pageHeight   = 842.0
+	margin       = 50.0
+	bodySize     = 10.5
+	bodyLeading  = 14.0
+	charWidthEm  = 0.5 // average Helvetica glyph width, used for wrapping
+	headingSpace = 6.0
+)
+
+type textStyle struct {
+	font    string
+	size    float64
+	leading float64
+	indent  float64
+}
+
+var (
+	styleTitle   = textStyle{font: "F2", size: 18, leading: 24}
+	styleHeading = textStyle{font: "F2", size: 13, leading: 18}
+	styleBody    = textStyle{font: "F1", size: bodySize, leading: bodyLeading}
+	styleBullet  = textStyle{font: "F1", size: bodySize, leading: bodyLeading, indent: 12}
+)
+
+// MarkdownToPDF renders the subset of Markdown produced by this service
+// (headings, bullet lists, paragraphs and tables) as a plain A4 PDF using
+// the standard Helvetica fonts. Characters outside Windows-1252 are
+// replaced with '?'.
+func MarkdownToPDF(markdown string) []byte {
+	l := &layout{}
+	l.newPage()
+
+	for _, raw := range strings.Split(markdown, "\n") {
+		line := strings.TrimRight(raw, " ")
+		switch {
+		case line == "":
+			l.space(bodyLeading / 2)
+		case strings.HasPrefix(line, "# "):
+			l.space(headingSpace)
+			l.text(styleTitle, stripInline(line[2:]))
+		case strings.HasPrefix(line, "## "), strings.HasPrefix(line, "### "):
+			l.space(headingSpace)
+			l.text(styleHeading, stripInline(strings.TrimLeft(line, "# ")))
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			l.text(styleBullet, "• "+stripInline(line[2:]))
+		case strings.HasPrefix(line, "|"):
+			if isTableRule(line) {
+				continue
+			}
+			cells := strings.Split(strings.Trim(line, "|"), "|")
+			for i := range cells {
+				cells[i] = stripInline(strings.TrimSpace(cells[i]))
+			}
+			l.text(styleBody, strings.Join(cells, "   "))
+		default:
+			l.text(styleBody, stripInline(line))
+		}
+	}
+
+	return l.render()
+}
+
+type layout struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func (l *layout) newPage() {
+	l.pages = append(l.pages, &bytes.Buffer{})
+	l.y = pageHeight - margin
+}
+
+func (l *layout) space(points float64) {
+	l.y -= points
+}
+
+// text writes s word-wrapped to the page width, breaking pages as needed.
+func (l *layout) text(style textStyle, s string) {
+	width := pageWidth - 2*margin - style.indent
+	maxChars := int(width / (style.size * charWidthEm))
+
+	for _, line := range wrap(s, maxChars) {
+		if l.y-style.leading < margin {
+			l.newPage()
+		}
+		l.y -= style.leading
+		fmt.Fprintf(l.pages[len(l.pages)-1], "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n",
+			style.font, style.size, margin+style.indent, l.y, escape(encodeWinAnsi(line)))
+	}
+}
+
+func (l *layout) render() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4 are fixed; each page then takes a page and a content object
+	kids := make([]string, len(l.pages))
+	for i := range l.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(l.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range l.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+func wrap(s string, maxChars int) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return nil
+	}
+
+	var lines []string
+	current := ""
+	for _, word := range words {
+		for len([]rune(word)) > maxChars {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			r := []rune(word)
+			lines = append(lines, string(r[:maxChars]))
+			word = string(r[maxChars:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case len([]rune(current))+1+len([]rune(word)) <= maxChars:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// stripInline removes Markdown emphasis and code markers.
+func stripInline(s string) string {
+	return strings.NewReplacer("**", "", "__", "", "`", "").Replace(s)
+}
+
+func isTableRule(line string) bool {
+	return strings.Trim(line, "|-: ") == ""
+}
+
+// winAnsiExtras maps the non-Latin-1 characters of Windows-1252.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+func encodeWinAnsi(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiExtras[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch c {
+		case '\\', '(', ')':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case '\r', '\n', '\t':
+			sb.WriteByte(' ')
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
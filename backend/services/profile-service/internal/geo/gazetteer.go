@@ -0,0 +1,357 @@
+// Package geo normalizes free-text locations against a gazetteer bundled
+// with the service: ISO 3166-1 countries and a curated list of cities with
+// coordinates. It works offline and never calls an external geocoder.
+package geo
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/countries.tsv data/cities.tsv
+var data embed.FS
+
+var (
+	ErrUnknownCountry = errors.New("unknown country")
+	ErrUnknownCity    = errors.New("unknown city")
+)
+
+const earthRadiusKM = 6371.0
+
+type Country struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"-"`
+}
+
+type City struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	CountryCode string   `json:"country_code"`
+	CountryName string   `json:"country_name"`
+	Lat         float64  `json:"lat"`
+	Lng         float64  `json:"lng"`
+	Aliases     []string `json:"-"`
+
+	keys []string
+}
+
+// Location is a resolved profile location. City is nil when the city text
+// did not match the gazetteer; CityName then holds the cleaned-up input.
+type Location struct {
+	Country  *Country
+	City     *City
+	CityName string
+}
+
+// Gazetteer is an in-memory index over the bundled data. It is read-only
+// after loading and safe for concurrent use.
+type Gazetteer struct {
+	countries       []*Country
+	countryByKey    map[string]*Country
+	cities          []*City
+	cityByID        map[string]*City
+	citiesByKey     map[string][]*City
+	citiesByCountry map[string][]*City
+}
+
+var (
+	defaultOnce sync.Once
+	defaultGaz  *Gazetteer
+)
+
+// Default returns the gazetteer built from the bundled data. The data ships
+// with the binary, so failing to parse it is a programming error.
+func Default() *Gazetteer {
+	defaultOnce.Do(func() {
+		g, err := load()
+		if err != nil {
+			panic(fmt.Sprintf("geo: bundled gazetteer is invalid: %v", err))
+		}
+		defaultGaz = g
+	})
+	return defaultGaz
+}
+
+func load() (*Gazetteer, error) {
+	g := &Gazetteer{
+		countryByKey:    make(map[string]*Country),
+		cityByID:        make(map[string]*City),
+		citiesByKey:     make(map[string][]*City),
+		citiesByCountry: make(map[string][]*City),
+	}
+
+	err := readTSV("data/countries.tsv", 3, func(fields []string) error {
+		c := &Country{Code: fields[0], Name: fields[1], Aliases: splitAliases(fields[2])}
+		g.countries = append(g.countries, c)
+		g.countryByKey[strings.ToLower(c.Code)] = c
+		for _, name := range append([]string{c.Name}, c.Aliases...) {
+			g.countryByKey[Fold(name)] = c
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readTSV("data/cities.tsv", 6, func(fields []string) error {
+		country, ok := g.countryByKey[strings.ToLower(fields[1])]
+		if !ok {
+			return fmt.Errorf("city %s: %w %q", fields[0], ErrUnknownCountry, fields[1])
+		}
+		lat, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return fmt.Errorf("city %s: bad latitude: %w", fields[0], err)
+		}
+		lng, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return fmt.Errorf("city %s: bad longitude: %w", fields[0], err)
+		}
+
+		c := &City{
+			ID:          fields[0],
+			Name:        fields[2],
+			CountryCode: country.Code,
+			CountryName: country.Name,
+			Lat:         lat,
+			Lng:         lng,
+			Aliases:     splitAliases(fields[3]),
+		}
+		for _, name := range append([]string{c.Name}, c.Aliases...) {
+			key := Fold(name)
+			if slices.Contains(c.keys, key) {
+				continue
+			}
+			c.keys = append(c.keys, key)
+			g.citiesByKey[key] = append(g.citiesByKey[key], c)
+		}
+		g.cities = append(g.cities, c)
+		g.cityByID[c.ID] = c
+		g.citiesByCountry[c.CountryCode] = append(g.citiesByCountry[c.CountryCode], c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func readTSV(name string, columns int, fn func(fields []string) error) error {
+	f, err := data.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != columns {
+			return fmt.Errorf("%s: expected %d columns, got %d in %q", name, columns, len(fields), line)
+		}
+		if err := fn(fields); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func splitAliases(field string) []string {
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, "|")
+}
+
+// Countries returns all countries ordered by name.
+func (g *Gazetteer) Countries() []*Country {
+	countries := append([]*Country(nil), g.countries...)
+	sort.Slice(countries, func(i, j int) bool {
+		return countries[i].Name < countries[j].Name
+	})
+	return countries
+}
+
+// Country looks up a country by ISO 3166-1 alpha-2 code, name or common
+// alias, ignoring case and accents.
+func (g *Gazetteer) Country(query string) (*Country, bool) {
+	query = strings.TrimSpace(query)
+	if c, ok := g.countryByKey[strings.ToLower(query)]; ok && len(query) == 2 {
+		return c, true
+	}
+	c, ok := g.countryByKey[Fold(query)]
+	return c, ok
+}
+
+func (g *Gazetteer) CityByID(id string) (*City, bool) {
+	c, ok := g.cityByID[id]
+	return c, ok
+}
+
+// FindCity matches a city name or alias exactly (ignoring case and accents).
+// Without a country code the match must be unambiguous.
+func (g *Gazetteer) FindCity(name, countryCode string) (*City, bool) {
+	var found *City
+	for _, c := range g.citiesByKey[Fold(name)] {
+		if countryCode != "" && c.CountryCode != countryCode {
+			continue
+		}
+		if found != nil && found != c {
+			return nil, false
+		}
+		found = c
+	}
+	return found, found != nil
+}
+
+// SuggestCities returns cities whose name or alias starts with prefix,
+// optionally limited to one country. Exact matches sort first, then by name.
+func (g *Gazetteer) SuggestCities(prefix, countryCode string, limit int) []*City {
+	key := Fold(prefix)
+	candidates := g.cities
+	if countryCode != "" {
+		candidates = g.citiesByCountry[countryCode]
+	}
+
+	type match struct {
+		city  *City
+		exact bool
+	}
+	matches := []match{}
+	for _, c := range candidates {
+		for _, k := range c.keys {
+			if strings.HasPrefix(k, key) || strings.Contains(k, " "+key) {
+				matches = append(matches, match{city: c, exact: k == key})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].exact != matches[j].exact {
+			return matches[i].exact
+		}
+		return matches[i].city.Name < matches[j].city.Name
+	})
+
+	cities := []*City{}
+	for _, m := range matches {
+		if len(cities) == limit {
+			break
+		}
+		cities = append(cities, m.city)
+	}
+	return cities
+}
+
+// Resolve normalizes free-text country and city input. The city may carry
+// its country after a comma ("Lagos, NG"); the country may be omitted when
+// the city is unambiguous. Unknown cities are kept as text without
+// coordinates, but the country must always be recognised.
+func (g *Gazetteer) Resolve(country, city string) (*Location, error) {
+	country = strings.TrimSpace(country)
+	city = collapseSpaces(city)
+
+	if i := strings.LastIndex(city, ","); i >= 0 {
+		if c, ok := g.Country(city[i+1:]); ok {
+			if country == "" {
+				country = c.Code
+			}
+			city = strings.TrimSpace(city[:i])
+		}
+	}
+
+	loc := &Location{}
+	if country != "" {
+		c, ok := g.Country(country)
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownCountry, country)
+		}
+		loc.Country = c
+	}
+
+	if city == "" {
+		return loc, nil
+	}
+
+	code := ""
+	if loc.Country != nil {
+		code = loc.Country.Code
+	}
+	if c, ok := g.FindCity(city, code); ok {
+		loc.City = c
+		loc.CityName = c.Name
+		if loc.Country == nil {
+			loc.Country, _ = g.Country(c.CountryCode)
+		}
+		return loc, nil
+	}
+
+	if loc.Country == nil {
+		return nil, fmt.Errorf("%w: %q, include a country", ErrUnknownCity, city)
+	}
+	loc.CityName = city
+	return loc, nil
+}
+
+// ResolveCityID resolves a gazetteer city ID, as returned by SuggestCities.
+func (g *Gazetteer) ResolveCityID(id string) (*Location, error) {
+	c, ok := g.CityByID(id)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownCity, id)
+	}
+	country, _ := g.Country(c.CountryCode)
+	return &Location{Country: country, City: c, CityName: c.Name}, nil
+}
+
+// Fold lowercases s, strips accents and reduces punctuation to single spaces
+// so that "Saint-Étienne" and "saint etienne" compare equal.
+func Fold(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// DistanceKM is the great-circle distance between two points.
+func DistanceKM(lat1, lng1, lat2, lng2 float64) float64 {
+	const rad = math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKM * math.Asin(math.Sqrt(a))
+}
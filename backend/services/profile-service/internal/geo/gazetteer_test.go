@@ -0,0 +1,270 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Lagos", "lagos"},
+		{"Saint-Étienne", "saint etienne"},
+		{"  São   Paulo ", "sao paulo"},
+		{"Côte d'Ivoire", "cote d ivoire"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Fold(tt.in); got != tt.want {
+			t.Errorf("Fold(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCountry(t *testing.T) {
+	g := Default()
+
+	tests := []struct {
+		query string
+		want  string
+		found bool
+	}{
+		{"NG", "NG", true},
+		{"ng", "NG", true},
+		{" Nigeria ", "NG", true},
+		{"ivory coast", "CI", true},
+		{"Cote d'Ivoire", "CI", true},
+		{"UK", "GB", true},
+		{"england", "GB", true},
+		{"USA", "US", true},
+		{"Atlantis", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		c, ok := g.Country(tt.query)
+		if ok != tt.found {
+			t.Errorf("Country(%q) found = %v, want %v", tt.query, ok, tt.found)
+			continue
+		}
+		if ok && c.Code != tt.want {
+			t.Errorf("Country(%q) = %s, want %s", tt.query, c.Code, tt.want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	g := Default()
+
+	tests := []struct {
+		name     string
+		country  string
+		city     string
+		wantCode string
+		wantCity string // gazetteer ID, empty when the city is kept as text
+		wantName string
+		err      error
+	}{
+		{
+			name:     "country and city",
+			country:  "Nigeria",
+			city:     "lagos",
+			wantCode: "NG",
+			wantCity: "ng-lagos",
+			wantName: "Lagos",
+		},
+		{
+			name:     "country after comma",
+			city:     "Lagos, NG",
+			wantCode: "NG",
+			wantCity: "ng-lagos",
+			wantName: "Lagos",
+		},
+		{
+			name:     "country inferred from unambiguous alias",
+			city:     "Oporto",
+			wantCode: "PT",
+			wantCity: "pt-porto",
+			wantName: "Porto",
+		},
+		{
+			name:     "accents and punctuation ignored",
+			country:  "FR",
+			city:     "saint  etienne",
+			wantCode: "FR",
+			wantCity: "fr-saint-etienne",
+			wantName: "Saint-Étienne",
+		},
+		{
+			name:     "unknown city kept as text",
+			country:  "NG",
+			city:     "  Ikorodu   North ",
+			wantCode: "NG",
+			wantName: "Ikorodu North",
+		},
+		{
+			name:     "city in another country kept as text",
+			country:  "GB",
+			city:     "Lagos",
+			wantCode: "GB",
+			wantName: "Lagos",
+		},
+		{
+			name:     "country only",
+			country:  "ghana",
+			wantCode: "GH",
+		},
+		{
+			name: "unknown city without country",
+			city: "Ikorodu",
+			err:  ErrUnknownCity,
+		},
+		{
+			name:    "unknown country",
+			country: "Atlantis",
+			city:    "Lagos",
+			err:     ErrUnknownCountry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := g.Resolve(tt.country, tt.city)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if loc.Country == nil || loc.Country.Code != tt.wantCode {
+				t.Errorf("country = %v, want %s", loc.Country, tt.wantCode)
+			}
+			cityID := ""
+			if loc.City != nil {
+				cityID = loc.City.ID
+			}
+			if cityID != tt.wantCity {
+				t.Errorf("city = %q, want %q", cityID, tt.wantCity)
+			}
+			if loc.CityName != tt.wantName {
+				t.Errorf("city name = %q, want %q", loc.CityName, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFindCityAmbiguous(t *testing.T) {
+	g := &Gazetteer{citiesByKey: map[string][]*City{
+		"valencia": {
+			{ID: "es-valencia", CountryCode: "ES"},
+			{ID: "ve-valencia", CountryCode: "VE"},
+		},
+	}}
+
+	if c, ok := g.FindCity("Valencia", ""); ok {
+		t.Errorf("FindCity without country = %s, want no match", c.ID)
+	}
+	c, ok := g.FindCity("Valencia", "VE")
+	if !ok || c.ID != "ve-valencia" {
+		t.Errorf("FindCity(Valencia, VE) = %v, %v, want ve-valencia", c, ok)
+	}
+}
+
+func TestSuggestCities(t *testing.T) {
+	g := Default()
+
+	tests := []struct {
+		name    string
+		prefix  string
+		country string
+		limit   int
+		want    []string
+	}{
+		{
+			name:   "prefix across countries and aliases",
+			prefix: "port",
+			limit:  10,
+			// Gqeberha matches its alias Port Elizabeth
+			want: []string{"za-gqeberha", "ng-port-harcourt", "pt-porto", "br-porto-alegre"},
+		},
+		{
+			name:   "exact match first",
+			prefix: "porto",
+			limit:  10,
+			want:   []string{"pt-porto", "br-porto-alegre"},
+		},
+		{
+			name:    "limited to country",
+			prefix:  "port",
+			country: "NG",
+			limit:   10,
+			want:    []string{"ng-port-harcourt"},
+		},
+		{
+			name:   "later word",
+			prefix: "Harc",
+			limit:  10,
+			want:   []string{"ng-port-harcourt"},
+		},
+		{
+			name:   "limit",
+			prefix: "port",
+			limit:  2,
+			want:   []string{"za-gqeberha", "ng-port-harcourt"},
+		},
+		{
+			name:   "no match",
+			prefix: "zzz",
+			limit:  10,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cities := g.SuggestCities(tt.prefix, tt.country, tt.limit)
+			got := make([]string, len(cities))
+			for i, c := range cities {
+				got[i] = c.ID
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SuggestCities = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("SuggestCities = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDistanceKM(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+	}{
+		{"same point", 6.4550, 3.3841, 6.4550, 3.3841, 0},
+		{"London to Paris", 51.5074, -0.1278, 48.8566, 2.3522, 343.56},
+		{"Lagos to Accra", 6.4550, 3.3841, 5.6037, -0.1870, 406.07},
+		{"across the antimeridian", 0, 179.5, 0, -179.5, 111.19},
+		{"pole to pole", 90, 0, -90, 0, math.Pi * earthRadiusKM},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceKM(tt.lat1, tt.lng1, tt.lat2, tt.lng2)
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("DistanceKM = %.3f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func (h *ProfileHandler) ListCareer(c *gin.Context) {
+	profileID := c.Param("id")
+
+	entries, err := h.service.ListCareer(c.Request.Context(), viewerFromContext(c), profileID)
+	if err != nil {
+		h.respondError(c, err, "failed to list career entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"career": entries})
+}
+
+func (h *ProfileHandler) CreateCareerEntry(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	var req model.CreateCareerEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.CreateCareerEntry(c.Request.Context(), userID.(string), profileID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to create career entry")
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *ProfileHandler) UpdateCareerEntry(c *gin.Context) {
+	profileID := c.Param("id")
+	entryID := c.Param("entryId")
+	userID, _ := c.Get("user_id")
+
+	var req model.UpdateCareerEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.UpdateCareerEntry(c.Request.Context(), userID.(string), profileID, entryID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to update career entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *ProfileHandler) DeleteCareerEntry(c *gin.Context) {
+	profileID := c.Param("id")
+	entryID := c.Param("entryId")
+	userID, _ := c.Get("user_id")
+
+	if err := h.service.DeleteCareerEntry(c.Request.Context(), userID.(string), profileID, entryID); err != nil {
+		h.respondError(c, err, "failed to delete career entry")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) ConfirmCareerEntry(c *gin.Context) {
+	profileID := c.Param("id")
+	entryID := c.Param("entryId")
+	userID, _ := c.Get("user_id")
+
+	entry, err := h.service.ConfirmCareerEntry(c.Request.Context(), userID.(string), profileID, entryID)
+	if err != nil {
+		h.respondError(c, err, "failed to confirm career entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *ProfileHandler) RejectCareerEntry(c *gin.Context) {
+	profileID := c.Param("id")
+	entryID := c.Param("entryId")
+	userID, _ := c.Get("user_id")
+
+	entry, err := h.service.RejectCareerEntry(c.Request.Context(), userID.(string), profileID, entryID)
+	if err != nil {
+		h.respondError(c, err, "failed to reject career entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *ProfileHandler) CreateSeasonStats(c *gin.Context) {
+	profileID := c.Param("id")
+	entryID := c.Param("entryId")
+	userID, _ := c.Get("user_id")
+
+	var req model.SeasonStatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats, err := h.service.CreateSeasonStats(c.Request.Context(), userID.(string), profileID, entryID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to create season stats")
+		return
+	}
+
+	c.JSON(http.StatusCreated, stats)
+}
+
+func (h *ProfileHandler) UpdateSeasonStats(c *gin.Context) {
+	profileID := c.Param("id")
+	entryID := c.Param("entryId")
+	statsID := c.Param("statsId")
+	userID, _ := c.Get("user_id")
+
+	var req model.SeasonStatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats, err := h.service.UpdateSeasonStats(c.Request.Context(), userID.(string), profileID, entryID, statsID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to update season stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *ProfileHandler) DeleteSeasonStats(c *gin.Context) {
+	profileID := c.Param("id")
+	entryID := c.Param("entryId")
+	statsID := c.Param("statsId")
+	userID, _ := c.Get("user_id")
+
+	if err := h.service.DeleteSeasonStats(c.Request.Context(), userID.(string), profileID, entryID, statsID); err != nil {
+		h.respondError(c, err, "failed to delete season stats")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) GetStatSchema(c *gin.Context) {
+	position := c.Query("position")
+
+	c.JSON(http.StatusOK, gin.H{
+		"position": position,
+		"optional": model.StatSchemaForPosition(position),
+	})
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCompletionChecklist returns the profile's completion score together
+// with the checklist items still missing.
+func (h *ProfileHandler) GetCompletionChecklist(c *gin.Context) {
+	checklist, err := h.service.GetCompletionChecklist(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to get completion checklist")
+		return
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
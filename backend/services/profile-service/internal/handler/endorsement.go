@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func (h *ProfileHandler) ListEndorsements(c *gin.Context) {
+	endorsements, err := h.service.ListEndorsements(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to list endorsements")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"endorsements": endorsements})
+}
+
+// EndorsePlayer endorses the player for a skill or character trait on
+// behalf of the caller's profile.
+func (h *ProfileHandler) EndorsePlayer(c *gin.Context) {
+	var req model.CreateEndorsementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endorsement, err := h.service.EndorsePlayer(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to endorse player")
+		return
+	}
+
+	c.JSON(http.StatusCreated, endorsement)
+}
+
+// RespondToEndorsement accepts or hides an endorsement of the caller.
+func (h *ProfileHandler) RespondToEndorsement(c *gin.Context) {
+	var req model.RespondEndorsementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endorsement, err := h.service.RespondToEndorsement(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Param("endorsementId"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to update endorsement")
+		return
+	}
+
+	c.JSON(http.StatusOK, endorsement)
+}
+
+func (h *ProfileHandler) RevokeEndorsement(c *gin.Context) {
+	if err := h.service.RevokeEndorsement(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Param("endorsementId")); err != nil {
+		h.respondError(c, err, "failed to revoke endorsement")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *ProfileHandler) Follow(c *gin.Context) {
+	rel, err := h.service.Follow(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to follow profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, rel)
+}
+
+func (h *ProfileHandler) Unfollow(c *gin.Context) {
+	if err := h.service.Unfollow(c.Request.Context(), viewerFromContext(c), c.Param("id")); err != nil {
+		h.respondError(c, err, "failed to unfollow profile")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) Block(c *gin.Context) {
+	rel, err := h.service.Block(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to block profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, rel)
+}
+
+func (h *ProfileHandler) Unblock(c *gin.Context) {
+	if err := h.service.Unblock(c.Request.Context(), viewerFromContext(c), c.Param("id")); err != nil {
+		h.respondError(c, err, "failed to unblock profile")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) Mute(c *gin.Context) {
+	rel, err := h.service.Mute(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to mute profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, rel)
+}
+
+func (h *ProfileHandler) Unmute(c *gin.Context) {
+	if err := h.service.Unmute(c.Request.Context(), viewerFromContext(c), c.Param("id")); err != nil {
+		h.respondError(c, err, "failed to unmute profile")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) GetRelationship(c *gin.Context) {
+	rel, err := h.service.GetRelationship(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to get relationship")
+		return
+	}
+
+	c.JSON(http.StatusOK, rel)
+}
+
+func (h *ProfileHandler) ListFollowers(c *gin.Context) {
+	page, err := h.service.ListFollowers(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Query("cursor"), pageSize(c))
+	if err != nil {
+		h.respondError(c, err, "failed to list followers")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ProfileHandler) ListFollowing(c *gin.Context) {
+	page, err := h.service.ListFollowing(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Query("cursor"), pageSize(c))
+	if err != nil {
+		h.respondError(c, err, "failed to list following")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ProfileHandler) ListBlocks(c *gin.Context) {
+	page, err := h.service.ListBlocks(c.Request.Context(), viewerFromContext(c), c.Query("cursor"), pageSize(c))
+	if err != nil {
+		h.respondError(c, err, "failed to list blocked profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ProfileHandler) ListMutes(c *gin.Context) {
+	page, err := h.service.ListMutes(c.Request.Context(), viewerFromContext(c), c.Query("cursor"), pageSize(c))
+	if err != nil {
+		h.respondError(c, err, "failed to list muted profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func pageSize(c *gin.Context) int {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return limit
+}
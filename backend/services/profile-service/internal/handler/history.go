@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// GetProfileHistory lists the profile's change log, optionally only the
+// entries that touched ?field=.
+func (h *ProfileHandler) GetProfileHistory(c *gin.Context) {
+	page, err := h.service.GetProfileHistory(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Query("field"), c.Query("cursor"), pageSize(c))
+	if err != nil {
+		h.respondError(c, err, "failed to get profile history")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ProfileHandler) UpdatePlayerDetails(c *gin.Context) {
+	var req model.UpdatePlayerDetailsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	player, err := h.service.UpdatePlayerDetails(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to update player details")
+		return
+	}
+
+	c.JSON(http.StatusOK, player)
+}
+
+func (h *ProfileHandler) VerifyPlayerDetails(c *gin.Context) {
+	player, err := h.service.VerifyPlayerDetails(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to verify player details")
+		return
+	}
+
+	c.JSON(http.StatusOK, player)
+}
+
+func (h *ProfileHandler) RevokePlayerVerification(c *gin.Context) {
+	player, err := h.service.RevokePlayerVerification(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to revoke player verification")
+		return
+	}
+
+	c.JSON(http.StatusOK, player)
+}
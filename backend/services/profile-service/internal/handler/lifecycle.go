@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// DeactivateProfile hides the caller's profile and schedules its deletion.
+// The request body with a reason is optional.
+func (h *ProfileHandler) DeactivateProfile(c *gin.Context) {
+	var req model.DeactivateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.service.DeactivateProfile(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to deactivate profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ProfileHandler) RestoreProfile(c *gin.Context) {
+	profile, err := h.service.RestoreProfile(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to restore profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ProfileHandler) TakedownProfile(c *gin.Context) {
+	var req model.TakedownProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.service.TakedownProfile(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to take down profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListCountries serves the country picker used alongside city autocomplete.
+func (h *ProfileHandler) ListCountries(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"countries": h.service.ListCountries()})
+}
+
+// SuggestCities autocompletes city names. The returned IDs can be sent back
+// as location_city_id when creating or updating a profile.
+func (h *ProfileHandler) SuggestCities(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	cities, err := h.service.SuggestCities(c.Query("q"), c.Query("country"), limit)
+	if err != nil {
+		h.respondError(c, err, "failed to suggest cities")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"cities": cities})
+}
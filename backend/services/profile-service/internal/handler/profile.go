@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/pkg/auth"
+	"github.com/scouttalent/profile-service/internal/i18n"
 	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
 	"github.com/scouttalent/profile-service/internal/service"
 	"go.uber.org/zap"
 )
@@ -70,13 +74,26 @@ func (h *ProfileHandler) GetMyProfile(c *gin.Context) {
 func (h *ProfileHandler) GetProfile(c *gin.Context) {
 	profileID := c.Param("id")
 
-	profile, err := h.service.GetProfile(c.Request.Context(), profileID)
+	profile, err := h.service.GetProfile(c.Request.Context(), viewerFromContext(c), profileID)
 	if err != nil {
 		h.logger.Error("failed to get profile", zap.Error(err))
 		c.JSON(http.StatusNotFound, gin.H{"error": "profile not found"})
 		return
 	}
 
+	setContentLanguage(c, profile.Locale)
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetInternalProfile serves other services the fields they enforce policy
+// on, such as media-service's upload limits.
+func (h *ProfileHandler) GetInternalProfile(c *gin.Context) {
+	profile, err := h.service.GetInternalProfile(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to get profile")
+		return
+	}
+
 	c.JSON(http.StatusOK, profile)
 }
 
@@ -89,10 +106,9 @@ func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	profile, err := h.service.UpdateProfile(c.Request.Context(), profileID, req)
+	profile, err := h.service.UpdateProfile(c.Request.Context(), viewerFromContext(c), profileID, req)
 	if err != nil {
-		h.logger.Error("failed to update profile", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update profile"})
+		h.respondError(c, err, "failed to update profile")
 		return
 	}
 
@@ -108,10 +124,9 @@ func (h *ProfileHandler) CreatePlayerDetails(c *gin.Context) {
 		return
 	}
 
-	player, err := h.service.CreatePlayerDetails(c.Request.Context(), profileID, req)
+	player, err := h.service.CreatePlayerDetails(c.Request.Context(), viewerFromContext(c), profileID, req)
 	if err != nil {
-		h.logger.Error("failed to create player details", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create player details"})
+		h.respondError(c, err, "failed to create player details")
 		return
 	}
 
@@ -121,13 +136,14 @@ func (h *ProfileHandler) CreatePlayerDetails(c *gin.Context) {
 func (h *ProfileHandler) GetPlayerProfile(c *gin.Context) {
 	profileID := c.Param("id")
 
-	player, err := h.service.GetPlayerProfile(c.Request.Context(), profileID)
+	player, err := h.service.GetPlayerProfile(c.Request.Context(), viewerFromContext(c), profileID)
 	if err != nil {
 		h.logger.Error("failed to get player profile", zap.Error(err))
 		c.JSON(http.StatusNotFound, gin.H{"error": "player profile not found"})
 		return
 	}
 
+	setContentLanguage(c, player.Locale)
 	c.JSON(http.StatusOK, player)
 }
 
@@ -136,4 +152,132 @@ func (h *ProfileHandler) Health(c *gin.Context) {
 		"status":  "healthy",
 		"service": "profile-service",
 	})
-}
\ No newline at end of file
+}
+
+// viewerFromContext builds the viewer from the JWT claims set by
+// middleware.AuthMiddleware. Requests without claims are anonymous.
+func viewerFromContext(c *gin.Context) model.Viewer {
+	viewer := model.Viewer{Languages: contentLanguages(c)}
+
+	claims, ok := c.Get("claims")
+	if !ok {
+		return viewer
+	}
+	userClaims, ok := claims.(*auth.Claims)
+	if !ok {
+		return viewer
+	}
+	viewer.UserID = userClaims.UserID
+	viewer.Role = userClaims.Role
+	viewer.TrustLevel = userClaims.TrustLevel
+	return viewer
+}
+
+// contentLanguages returns the caller's preferred content locales: ?lang=
+// first, then the Accept-Language header.
+func contentLanguages(c *gin.Context) []string {
+	languages := i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if locale, ok := i18n.Normalize(c.Query("lang")); ok {
+		languages = append([]string{locale}, languages...)
+	}
+	return languages
+}
+
+// setContentLanguage reports the locale a localized response was served in.
+func setContentLanguage(c *gin.Context, locale string) {
+	c.Header("Vary", "Accept-Language")
+	if locale != "" {
+		c.Header("Content-Language", locale)
+	}
+}
+
+func (h *ProfileHandler) GetPrivacy(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	privacy, err := h.service.GetPrivacy(c.Request.Context(), userID.(string), profileID)
+	if err != nil {
+		h.respondError(c, err, "failed to get privacy settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, privacy)
+}
+
+func (h *ProfileHandler) UpdatePrivacy(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	var req model.UpdatePrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	privacy, err := h.service.UpdatePrivacy(c.Request.Context(), userID.(string), profileID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to update privacy settings")
+		return
+	}
+
+	c.JSON(http.StatusOK, privacy)
+}
+
+// errorStatus maps service and repository errors to an HTTP status and a
+// client-safe message. Unknown errors fall back to fallback with a 500.
+func errorStatus(err error, fallback string) (int, string) {
+	switch {
+	case errors.Is(err, repository.ErrProfileNotFound),
+		errors.Is(err, repository.ErrCareerEntryNotFound),
+		errors.Is(err, repository.ErrSeasonStatsNotFound),
+		errors.Is(err, repository.ErrShareTokenNotFound),
+		errors.Is(err, repository.ErrShortlistNotFound),
+		errors.Is(err, repository.ErrShortlistEntryNotFound),
+		errors.Is(err, repository.ErrStaffMemberNotFound),
+		errors.Is(err, repository.ErrReportNotFound),
+		errors.Is(err, repository.ErrReportVersionNotFound),
+		errors.Is(err, repository.ErrReportTemplateNotFound),
+		errors.Is(err, repository.ErrRosterImportNotFound),
+		errors.Is(err, repository.ErrEndorsementNotFound),
+		errors.Is(err, repository.ErrTranslationNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, repository.ErrProfileAlreadyExists),
+		errors.Is(err, repository.ErrSeasonStatsDuplicate),
+		errors.Is(err, repository.ErrSlugTaken),
+		errors.Is(err, repository.ErrShortlistEntryDuplicate),
+		errors.Is(err, repository.ErrReportTemplateInUse),
+		errors.Is(err, repository.ErrEndorsementDuplicate),
+		errors.Is(err, service.ErrRosterImportState),
+		errors.Is(err, service.ErrProfileState),
+		errors.Is(err, service.ErrRestoreExpired):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, service.ErrForbidden),
+		errors.Is(err, service.ErrBlocked):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, service.ErrNotPlayer),
+		errors.Is(err, service.ErrInvalidCareerEntry),
+		errors.Is(err, service.ErrInvalidSeasonStats),
+		errors.Is(err, service.ErrInvalidAssessment),
+		errors.Is(err, service.ErrInvalidSlug),
+		errors.Is(err, service.ErrSelfRelation),
+		errors.Is(err, service.ErrInvalidCursor),
+		errors.Is(err, service.ErrInvalidShortlist),
+		errors.Is(err, service.ErrInvalidReport),
+		errors.Is(err, service.ErrInvalidImport),
+		errors.Is(err, service.ErrInvalidLocation),
+		errors.Is(err, service.ErrInvalidEndorsement),
+		errors.Is(err, service.ErrInvalidLocale),
+		errors.Is(err, service.ErrInvalidTranslation):
+		return http.StatusBadRequest, err.Error()
+	default:
+		return http.StatusInternalServerError, fallback
+	}
+}
+
+func (h *ProfileHandler) respondError(c *gin.Context, err error, fallback string) {
+	status, message := errorStatus(err, fallback)
+	if status == http.StatusInternalServerError {
+		h.logger.Error(fallback, zap.Error(err))
+	}
+	c.JSON(status, gin.H{"error": message})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+	"go.uber.org/zap"
+)
+
+// GetPublicProfile serves the unauthenticated profile page data. Retired
+// slugs redirect permanently to the current one.
+func (h *ProfileHandler) GetPublicProfile(c *gin.Context) {
+	slug := c.Param("slug")
+	token := c.Query("token")
+
+	profile, movedTo, err := h.service.GetPublicProfile(c.Request.Context(), slug, token, contentLanguages(c))
+	if err != nil {
+		h.respondError(c, err, "failed to get profile")
+		return
+	}
+	if movedTo != "" {
+		location := "/api/v1/public/profiles/" + url.PathEscape(movedTo)
+		if token != "" {
+			location += "?" + url.Values{"token": {token}}.Encode()
+		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	// Videos are best effort: the page still renders if media-service is down
+	videos, err := h.service.ListPublicVideos(c.Request.Context(), profile.ID)
+	if err != nil {
+		h.logger.Warn("failed to load public videos", zap.String("profile_id", profile.ID), zap.Error(err))
+	} else {
+		profile.Videos = videos
+	}
+
+	if token != "" {
+		c.Header("X-Robots-Tag", "noindex")
+	}
+	setContentLanguage(c, profile.Locale)
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ProfileHandler) UpdateSlug(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	var req model.UpdateSlugRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.service.UpdateSlug(c.Request.Context(), userID.(string), profileID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to update slug")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ProfileHandler) ListShareTokens(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	tokens, err := h.service.ListShareTokens(c.Request.Context(), userID.(string), profileID)
+	if err != nil {
+		h.respondError(c, err, "failed to list share tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"share_tokens": tokens})
+}
+
+func (h *ProfileHandler) CreateShareToken(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	var req model.CreateShareTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := h.service.CreateShareToken(c.Request.Context(), userID.(string), profileID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to create share token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+func (h *ProfileHandler) RevokeShareToken(c *gin.Context) {
+	profileID := c.Param("id")
+	tokenID := c.Param("tokenId")
+	userID, _ := c.Get("user_id")
+
+	if err := h.service.RevokeShareToken(c.Request.Context(), userID.(string), profileID, tokenID); err != nil {
+		h.respondError(c, err, "failed to revoke share token")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func (h *ProfileHandler) ListReportTemplates(c *gin.Context) {
+	templates, err := h.service.ListReportTemplates(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to list report templates")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates, "default": model.DefaultReportTemplate})
+}
+
+func (h *ProfileHandler) CreateReportTemplate(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req model.ReportTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmpl, err := h.service.CreateReportTemplate(c.Request.Context(), userID.(string), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to create report template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, tmpl)
+}
+
+func (h *ProfileHandler) UpdateReportTemplate(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req model.ReportTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmpl, err := h.service.UpdateReportTemplate(c.Request.Context(), userID.(string), c.Param("id"), c.Param("templateId"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to update report template")
+		return
+	}
+
+	c.JSON(http.StatusOK, tmpl)
+}
+
+func (h *ProfileHandler) DeleteReportTemplate(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	if err := h.service.DeleteReportTemplate(c.Request.Context(), userID.(string), c.Param("id"), c.Param("templateId")); err != nil {
+		h.respondError(c, err, "failed to delete report template")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) ListPlayerReports(c *gin.Context) {
+	reports, err := h.service.ListPlayerReports(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to list scouting reports")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reports": reports})
+}
+
+func (h *ProfileHandler) GetScoutingSummary(c *gin.Context) {
+	summary, err := h.service.GetScoutingSummary(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to get scouting summary")
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (h *ProfileHandler) CreateReport(c *gin.Context) {
+	var req model.CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.service.CreateReport(c.Request.Context(), viewerFromContext(c), req)
+	if err != nil {
+		h.respondError(c, err, "failed to create scouting report")
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func (h *ProfileHandler) GetReport(c *gin.Context) {
+	report, err := h.service.GetReport(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to get scouting report")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *ProfileHandler) UpdateReport(c *gin.Context) {
+	var req model.UpdateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.service.UpdateReport(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to update scouting report")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *ProfileHandler) DeleteReport(c *gin.Context) {
+	if err := h.service.DeleteReport(c.Request.Context(), viewerFromContext(c), c.Param("id")); err != nil {
+		h.respondError(c, err, "failed to delete scouting report")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) ListReportVersions(c *gin.Context) {
+	versions, err := h.service.ListReportVersions(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to list report versions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+func (h *ProfileHandler) GetReportVersion(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return
+	}
+
+	report, err := h.service.GetReportVersion(c.Request.Context(), viewerFromContext(c), c.Param("id"), version)
+	if err != nil {
+		h.respondError(c, err, "failed to get report version")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportReport downloads the report as Markdown (format=md, default) or PDF.
+func (h *ProfileHandler) ExportReport(c *gin.Context) {
+	format := c.DefaultQuery("format", "md")
+
+	contentType := "text/markdown; charset=utf-8"
+	if format == "pdf" {
+		contentType = "application/pdf"
+	}
+
+	reportID := c.Param("id")
+	doc, err := h.service.ExportReport(c.Request.Context(), viewerFromContext(c), reportID, format)
+	if err != nil {
+		h.respondError(c, err, "failed to export scouting report")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="scouting-report-%s.%s"`, reportID, format))
+	c.Data(http.StatusOK, contentType, doc)
+}
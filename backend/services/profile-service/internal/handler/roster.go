@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/service"
+	"go.uber.org/zap"
+)
+
+func (h *ProfileHandler) GetRosterImportFields(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"fields":        model.RosterImportFields,
+		"formats":       []string{"csv", "xlsx"},
+		"max_rows":      service.MaxRosterRows,
+		"max_file_size": service.MaxRosterFileSize,
+	})
+}
+
+// CreateRosterImport accepts a multipart upload with the roster in "file",
+// an optional "dry_run" flag and an optional JSON "mapping" of field to
+// column header. The import runs in the background; poll it for progress.
+func (h *ProfileHandler) CreateRosterImport(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if file.Size > service.MaxRosterFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file exceeds %d bytes", service.MaxRosterFileSize)})
+		return
+	}
+
+	var req model.CreateRosterImportRequest
+	if raw := c.PostForm("dry_run"); raw != "" {
+		req.DryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be a boolean"})
+			return
+		}
+	}
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.Mapping); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mapping must be a JSON object of field to column header"})
+			return
+		}
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		h.respondError(c, err, "failed to read upload")
+		return
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, service.MaxRosterFileSize))
+	if err != nil {
+		h.respondError(c, err, "failed to read upload")
+		return
+	}
+
+	imp, err := h.service.StartRosterImport(c.Request.Context(), userID.(string), c.Param("id"), file.Filename, data, req)
+	if err != nil {
+		h.respondError(c, err, "failed to start roster import")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, imp)
+}
+
+func (h *ProfileHandler) ListRosterImports(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	imports, err := h.service.ListRosterImports(c.Request.Context(), userID.(string), c.Param("id"), pageSize(c))
+	if err != nil {
+		h.respondError(c, err, "failed to list roster imports")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"imports": imports})
+}
+
+func (h *ProfileHandler) GetRosterImport(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	imp, err := h.service.GetRosterImport(c.Request.Context(), userID.(string), c.Param("id"), c.Param("importId"))
+	if err != nil {
+		h.respondError(c, err, "failed to get roster import")
+		return
+	}
+
+	c.JSON(http.StatusOK, imp)
+}
+
+// ListRosterImportRows returns the per-row report as JSON pages, or the whole
+// report as CSV with format=csv.
+func (h *ProfileHandler) ListRosterImportRows(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	status := model.RosterRowStatus(c.Query("status"))
+	format := c.DefaultQuery("format", "json")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or json"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if format == "csv" {
+		limit, offset = service.MaxRosterRows, 0
+	}
+
+	importID := c.Param("importId")
+	rows, err := h.service.ListRosterImportRows(c.Request.Context(), userID.(string), c.Param("id"), importID, status, limit, offset)
+	if err != nil {
+		h.respondError(c, err, "failed to list roster import rows")
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, gin.H{
+			"rows":   rows,
+			"limit":  limit,
+			"offset": offset,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="roster-import-%s.csv"`, importID))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := h.service.WriteRosterReportCSV(c.Writer, rows); err != nil {
+		h.logger.Error("failed to write roster report csv", zap.Error(err))
+	}
+}
+
+func (h *ProfileHandler) CommitRosterImport(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	imp, err := h.service.CommitRosterImport(c.Request.Context(), userID.(string), c.Param("id"), c.Param("importId"))
+	if err != nil {
+		h.respondError(c, err, "failed to commit roster import")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, imp)
+}
+
+func (h *ProfileHandler) CancelRosterImport(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	imp, err := h.service.CancelRosterImport(c.Request.Context(), userID.(string), c.Param("id"), c.Param("importId"))
+	if err != nil {
+		h.respondError(c, err, "failed to cancel roster import")
+		return
+	}
+
+	c.JSON(http.StatusOK, imp)
+}
+
+func (h *ProfileHandler) ResumeRosterImport(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	imp, err := h.service.ResumeRosterImport(c.Request.Context(), userID.(string), c.Param("id"), c.Param("importId"))
+	if err != nil {
+		h.respondError(c, err, "failed to resume roster import")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, imp)
+}
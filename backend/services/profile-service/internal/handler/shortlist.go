@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+	"go.uber.org/zap"
+)
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+func (h *ProfileHandler) ListShortlists(c *gin.Context) {
+	lists, err := h.service.ListShortlists(c.Request.Context(), viewerFromContext(c))
+	if err != nil {
+		h.respondError(c, err, "failed to list shortlists")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shortlists": lists})
+}
+
+func (h *ProfileHandler) CreateShortlist(c *gin.Context) {
+	var req model.CreateShortlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.service.CreateShortlist(c.Request.Context(), viewerFromContext(c), req)
+	if err != nil {
+		h.respondError(c, err, "failed to create shortlist")
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+func (h *ProfileHandler) GetShortlist(c *gin.Context) {
+	status := model.ShortlistStatus(c.Query("status"))
+
+	list, err := h.service.GetShortlist(c.Request.Context(), viewerFromContext(c), c.Param("id"), status, c.Query("tag"))
+	if err != nil {
+		h.respondError(c, err, "failed to get shortlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *ProfileHandler) UpdateShortlist(c *gin.Context) {
+	var req model.UpdateShortlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.service.UpdateShortlist(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to update shortlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *ProfileHandler) DeleteShortlist(c *gin.Context) {
+	if err := h.service.DeleteShortlist(c.Request.Context(), viewerFromContext(c), c.Param("id")); err != nil {
+		h.respondError(c, err, "failed to delete shortlist")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) AddShortlistEntry(c *gin.Context) {
+	var req model.AddShortlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.AddShortlistEntry(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to add shortlist entry")
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *ProfileHandler) UpdateShortlistEntry(c *gin.Context) {
+	var req model.UpdateShortlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.UpdateShortlistEntry(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Param("entryId"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to update shortlist entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *ProfileHandler) RemoveShortlistEntry(c *gin.Context) {
+	if err := h.service.RemoveShortlistEntry(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Param("entryId")); err != nil {
+		h.respondError(c, err, "failed to remove shortlist entry")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) ReorderShortlist(c *gin.Context) {
+	var req model.ReorderShortlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.service.ReorderShortlist(c.Request.Context(), viewerFromContext(c), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to reorder shortlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// ExportShortlist downloads the list as CSV (default) or JSON.
+func (h *ProfileHandler) ExportShortlist(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or json"})
+		return
+	}
+
+	list, err := h.service.GetShortlist(c.Request.Context(), viewerFromContext(c), c.Param("id"), "", "")
+	if err != nil {
+		h.respondError(c, err, "failed to export shortlist")
+		return
+	}
+
+	filename := unsafeFilenameChars.ReplaceAllString(list.Name, "_") + "." + format
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if format == "json" {
+		c.JSON(http.StatusOK, list)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := h.service.WriteShortlistCSV(c.Writer, list); err != nil {
+		h.logger.Error("failed to write shortlist csv", zap.Error(err))
+	}
+}
+
+func (h *ProfileHandler) ListAcademyStaff(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	staff, err := h.service.ListAcademyStaff(c.Request.Context(), userID.(string), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to list staff")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"staff": staff})
+}
+
+func (h *ProfileHandler) AddAcademyStaff(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req model.AddStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	staff, err := h.service.AddAcademyStaff(c.Request.Context(), userID.(string), c.Param("id"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to add staff member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"staff": staff})
+}
+
+func (h *ProfileHandler) RemoveAcademyStaff(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	if err := h.service.RemoveAcademyStaff(c.Request.Context(), userID.(string), c.Param("id"), c.Param("memberId")); err != nil {
+		h.respondError(c, err, "failed to remove staff member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func (h *ProfileHandler) GetSkillTaxonomy(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"max_score":        model.MaxSkillScore,
+		"skills":           model.SkillTaxonomy,
+		"position_weights": model.PositionSkillWeights,
+	})
+}
+
+func (h *ProfileHandler) ListSkillAssessments(c *gin.Context) {
+	profileID := c.Param("id")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	assessments, err := h.service.ListSkillAssessments(c.Request.Context(), viewerFromContext(c), profileID, limit, offset)
+	if err != nil {
+		h.respondError(c, err, "failed to list skill assessments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"assessments": assessments,
+		"limit":       limit,
+		"offset":      offset,
+	})
+}
+
+func (h *ProfileHandler) GetSkillHistory(c *gin.Context) {
+	profileID := c.Param("id")
+	skill := c.Param("skill")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	history, err := h.service.GetSkillHistory(c.Request.Context(), viewerFromContext(c), profileID, skill, limit)
+	if err != nil {
+		h.respondError(c, err, "failed to get skill history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"skill":   skill,
+		"history": history,
+	})
+}
+
+func (h *ProfileHandler) SubmitCoachAssessment(c *gin.Context) {
+	profileID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	var req model.CoachAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assessment, err := h.service.SubmitCoachAssessment(c.Request.Context(), userID.(string), profileID, req)
+	if err != nil {
+		h.respondError(c, err, "failed to submit assessment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, assessment)
+}
+
+// SubmitAIAssessment is the internal endpoint used by scoring workers.
+func (h *ProfileHandler) SubmitAIAssessment(c *gin.Context) {
+	var req model.SubmitAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assessment, err := h.service.SubmitAIAssessment(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "failed to submit assessment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, assessment)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func (h *ProfileHandler) ListTranslations(c *gin.Context) {
+	translations, err := h.service.ListTranslations(c.Request.Context(), viewerFromContext(c), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "failed to list translations")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+// UpsertTranslation sets the profile's display name and bio in the locale
+// given in the path, e.g. PUT /profiles/:id/translations/fr.
+func (h *ProfileHandler) UpsertTranslation(c *gin.Context) {
+	var req model.UpsertTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	translation, err := h.service.UpsertTranslation(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Param("locale"), req)
+	if err != nil {
+		h.respondError(c, err, "failed to save translation")
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+func (h *ProfileHandler) DeleteTranslation(c *gin.Context) {
+	if err := h.service.DeleteTranslation(c.Request.Context(), viewerFromContext(c), c.Param("id"), c.Param("locale")); err != nil {
+		h.respondError(c, err, "failed to delete translation")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+// Package i18n holds the content languages profiles can be written in,
+// Accept-Language negotiation and a small stopword-based detector for
+// the languages with a dedicated full-text search configuration.
+package i18n
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the primary language of profiles that never set one.
+const DefaultLocale = "en"
+
+// Locales are the supported content languages as ISO 639-1 codes. Yoruba,
+// Hausa and Igbo have no Postgres stemmer and are searched with the simple
+// configuration; they are never auto-detected.
+var Locales = []string{"en", "fr", "es", "pt", "yo", "ha", "ig"}
+
+// searchConfigs maps locales to Postgres text search configurations. It
+// mirrors profile_text_search_config() in the migrations.
+var searchConfigs = map[string]string{
+	"en": "english",
+	"fr": "french",
+	"es": "spanish",
+	"pt": "portuguese",
+}
+
+// Normalize reduces a BCP 47 tag such as "pt-BR" to its supported base
+// language code.
+func Normalize(tag string) (string, bool) {
+	t, err := language.Parse(strings.TrimSpace(tag))
+	if err != nil {
+		return "", false
+	}
+	base, _ := t.Base()
+	code := base.String()
+	return code, slices.Contains(Locales, code)
+}
+
+// SearchConfig returns the Postgres text search configuration for locale.
+func SearchConfig(locale string) string {
+	if config, ok := searchConfigs[locale]; ok {
+		return config
+	}
+	return "simple"
+}
+
+// ParseAcceptLanguage returns the supported locales in an Accept-Language
+// header, most preferred first and without duplicates.
+func ParseAcceptLanguage(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	locales := []string{}
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		code := base.String()
+		if slices.Contains(Locales, code) && !slices.Contains(locales, code) {
+			locales = append(locales, code)
+		}
+	}
+	return locales
+}
+
+// Negotiate picks the first preferred locale that is available, falling
+// back to fallback.
+func Negotiate(preferred, available []string, fallback string) string {
+	for _, locale := range preferred {
+		if slices.Contains(available, locale) {
+			return locale
+		}
+	}
+	return fallback
+}
+
+// stopwords are frequent function words used to tell the detectable
+// languages apart. Words shared between languages count for each.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "a", "i", "am", "my", "in", "of", "to", "with", "for", "on", "at", "have", "play", "player", "team", "who", "as", "from", "since", "years", "old"},
+	"fr": {"le", "la", "les", "et", "est", "je", "suis", "mon", "ma", "mes", "un", "une", "des", "du", "de", "avec", "pour", "dans", "joue", "joueur", "équipe", "depuis", "ans", "au", "aux"},
+	"es": {"el", "la", "los", "las", "y", "es", "soy", "mi", "mis", "un", "una", "de", "del", "con", "para", "en", "juego", "jugador", "equipo", "desde", "años", "al", "que"},
+	"pt": {"o", "a", "os", "as", "e", "é", "sou", "meu", "minha", "um", "uma", "de", "do", "da", "com", "para", "em", "no", "na", "jogo", "jogador", "time", "equipe", "desde", "anos", "que", "não"},
+}
+
+const minDetectionHits = 2
+
+// Detect guesses the language of text from its stopwords. It reports false
+// when the text is too short or too evenly split to call.
+func Detect(text string) (string, bool) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	hits := map[string]int{}
+	for _, word := range words {
+		for locale, list := range stopwords {
+			if slices.Contains(list, word) {
+				hits[locale]++
+			}
+		}
+	}
+
+	best, bestHits, runnerUp := "", 0, 0
+	for _, locale := range Locales {
+		switch n := hits[locale]; {
+		case n > bestHits:
+			best, bestHits, runnerUp = locale, n, bestHits
+		case n > runnerUp:
+			runnerUp = n
+		}
+	}
+	if bestHits < minDetectionHits || bestHits == runnerUp {
+		return "", false
+	}
+	return best, true
+}
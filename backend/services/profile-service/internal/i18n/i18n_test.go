@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tag       string
+		want      string
+		supported bool
+	}{
+		{tag: "en", want: "en", supported: true},
+		{tag: "pt-BR", want: "pt", supported: true},
+		{tag: " fr-CA ", want: "fr", supported: true},
+		{tag: "yo-NG", want: "yo", supported: true},
+		{tag: "de-DE", want: "de", supported: false},
+		{tag: "not a tag", want: "", supported: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := Normalize(tt.tag)
+			if got != tt.want || ok != tt.supported {
+				t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.supported)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{name: "empty", header: "", want: []string{}},
+		{name: "single", header: "fr", want: []string{"fr"}},
+		{name: "regions reduce to base", header: "pt-BR,pt;q=0.9,en-GB;q=0.8", want: []string{"pt", "en"}},
+		{name: "ordered by quality", header: "en;q=0.5,es;q=0.9,fr", want: []string{"fr", "es", "en"}},
+		{name: "unsupported skipped", header: "de-DE,ha;q=0.7,nl;q=0.6", want: []string{"ha"}},
+		{name: "wildcard skipped", header: "*,ig;q=0.5", want: []string{"ig"}},
+		{name: "malformed", header: "en;q=nope", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseAcceptLanguage(tt.header)
+			if !slices.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("ParseAcceptLanguage(%q) = %#v, want %#v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	available := []string{"en", "pt"}
+
+	if got := Negotiate([]string{"fr", "pt", "en"}, available, "en"); got != "pt" {
+		t.Errorf("Negotiate = %q, want pt", got)
+	}
+	if got := Negotiate([]string{"es"}, available, "en"); got != "en" {
+		t.Errorf("Negotiate = %q, want fallback en", got)
+	}
+	if got := Negotiate(nil, available, "pt"); got != "pt" {
+		t.Errorf("Negotiate = %q, want fallback pt", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+		ok   bool
+	}{
+		{
+			name: "english",
+			text: "I am a left-footed winger and I play for the under-17 team.",
+			want: "en",
+			ok:   true,
+		},
+		{
+			name: "french",
+			text: "Je suis un joueur offensif, je joue avec mon équipe depuis 3 ans.",
+			want: "fr",
+			ok:   true,
+		},
+		{
+			name: "spanish",
+			text: "Soy un jugador rápido y juego en el equipo del barrio desde hace años.",
+			want: "es",
+			ok:   true,
+		},
+		{
+			name: "portuguese",
+			text: "Sou um jogador canhoto e jogo no time da minha cidade há três anos.",
+			want: "pt",
+			ok:   true,
+		},
+		{
+			name: "case and punctuation",
+			text: "THE TEAM, AND THE PLAYER!",
+			want: "en",
+			ok:   true,
+		},
+		{
+			name: "too short",
+			text: "Striker.",
+			ok:   false,
+		},
+		{
+			name: "single stopword",
+			text: "Goalkeeper with experience",
+			ok:   false,
+		},
+		{
+			name: "tied between languages",
+			text: "de la",
+			ok:   false,
+		},
+		{
+			name: "empty",
+			text: "",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Detect(tt.text)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Detect(%q) = %q, %v, want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
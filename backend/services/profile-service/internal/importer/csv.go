@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// readCSV reads comma or semicolon separated values. Spreadsheet exports in
+// many European locales use semicolons, so the delimiter is sniffed from the
+// first line.
+func readCSV(data []byte) ([]record, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		r.Comma = ';'
+	}
+
+	records := []record{}
+	for {
+		cells, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedFile, err)
+		}
+		line, _ := r.FieldPos(0)
+		records = append(records, record{line: line, cells: cells})
+	}
+	return records, nil
+}
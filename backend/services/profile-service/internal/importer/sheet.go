@@ -0,0 +1,117 @@
+// Package importer reads tabular uploads (CSV and XLSX) into rows of
+// strings. It knows nothing about profiles; mapping and validation happen in
+// the service layer.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+	ErrEmptySheet        = errors.New("file has no header row")
+	ErrTooManyRows       = errors.New("file has too many rows")
+	ErrMalformedFile     = errors.New("malformed file")
+)
+
+// Sheet is a parsed upload. Every row has exactly len(Headers) cells.
+type Sheet struct {
+	Headers []string
+	Rows    []Row
+}
+
+// Row is a data row. Line is its 1-based row number in the source file so
+// errors can point at what the user sees in their spreadsheet.
+type Row struct {
+	Line  int
+	Cells []string
+}
+
+// record is a raw row as read from the file, before header handling.
+type record struct {
+	line  int
+	cells []string
+}
+
+// Parse reads data in the given format. The first non-empty row is the
+// header; fully blank rows are dropped. maxRows bounds the data rows.
+func Parse(format string, data []byte, maxRows int) (*Sheet, error) {
+	var (
+		records []record
+		err     error
+	)
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(data)
+	case FormatXLSX:
+		records, err = readXLSX(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSheet(records, maxRows)
+}
+
+// FormatFromFilename infers the format from a file extension.
+func FormatFromFilename(name string) (string, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return FormatCSV, nil
+	case strings.HasSuffix(lower, ".xlsx"):
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("%w: expected .csv or .xlsx", ErrUnsupportedFormat)
+	}
+}
+
+func buildSheet(records []record, maxRows int) (*Sheet, error) {
+	sheet := &Sheet{Rows: []Row{}}
+	for _, rec := range records {
+		if isBlank(rec.cells) {
+			continue
+		}
+		if sheet.Headers == nil {
+			sheet.Headers = make([]string, len(rec.cells))
+			for i, h := range rec.cells {
+				sheet.Headers[i] = strings.TrimSpace(h)
+			}
+			continue
+		}
+		if len(sheet.Rows) >= maxRows {
+			return nil, fmt.Errorf("%w: limit is %d", ErrTooManyRows, maxRows)
+		}
+
+		cells := make([]string, len(sheet.Headers))
+		for i := range cells {
+			if i < len(rec.cells) {
+				cells[i] = strings.TrimSpace(rec.cells[i])
+			}
+		}
+		sheet.Rows = append(sheet.Rows, Row{Line: rec.line, Cells: cells})
+	}
+
+	if sheet.Headers == nil {
+		return nil, ErrEmptySheet
+	}
+	return sheet, nil
+}
+
+func isBlank(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// workbook zips the given parts into an in-memory xlsx file.
+func workbook(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const (
+	testWorkbookXML = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+		xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+		<sheets><sheet name="Roster" sheetId="1" r:id="rId2"/></sheets></workbook>`
+	testRelsXML = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+		<Relationship Id="rId1" Target="worksheets/other.xml"/>
+		<Relationship Id="rId2" Target="worksheets/roster.xml"/></Relationships>`
+	testSharedStringsXML = `<sst><si><t>email</t></si><si><r><t>display</t></r><r><t>_name</t></r></si></sst>`
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []record
+		err  error
+	}{
+		{
+			name: "comma separated",
+			data: "email,display_name\na@example.com,Ada\n",
+			want: []record{
+				{line: 1, cells: []string{"email", "display_name"}},
+				{line: 2, cells: []string{"a@example.com", "Ada"}},
+			},
+		},
+		{
+			name: "semicolon separated with BOM",
+			data: "\xEF\xBB\xBFemail;display_name\na@example.com;Ada, Jr.\n",
+			want: []record{
+				{line: 1, cells: []string{"email", "display_name"}},
+				{line: 2, cells: []string{"a@example.com", "Ada, Jr."}},
+			},
+		},
+		{
+			name: "ragged rows and quoted newline",
+			data: "email,display_name,bio\nb@example.com,\"Bo\nB\"\nc@example.com\n",
+			want: []record{
+				{line: 1, cells: []string{"email", "display_name", "bio"}},
+				{line: 2, cells: []string{"b@example.com", "Bo\nB"}},
+				{line: 4, cells: []string{"c@example.com"}},
+			},
+		},
+		{
+			name: "empty",
+			data: "",
+			want: []record{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readCSV([]byte(tt.data))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readCSV = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadXLSX(t *testing.T) {
+	sheet := `<worksheet><sheetData>
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+		<row r="3"><c r="A3" t="inlineStr"><is><t>a@example.com</t></is></c><c r="C3"><v>180</v></c></row>
+		<row><c t="str"><v>b@example.com</v></c></row>
+	</sheetData></worksheet>`
+
+	tests := []struct {
+		name  string
+		parts map[string]string
+		want  []record
+		err   error
+	}{
+		{
+			name: "first sheet in workbook order",
+			parts: map[string]string{
+				"xl/workbook.xml":            testWorkbookXML,
+				"xl/_rels/workbook.xml.rels": testRelsXML,
+				"xl/sharedStrings.xml":       testSharedStringsXML,
+				"xl/worksheets/roster.xml":   sheet,
+				"xl/worksheets/other.xml":    `<worksheet><sheetData/></worksheet>`,
+			},
+			want: []record{
+				{line: 1, cells: []string{"email", "display_name"}},
+				{line: 3, cells: []string{"a@example.com", "", "180"}},
+				{line: 4, cells: []string{"b@example.com"}},
+			},
+		},
+		{
+			name: "falls back to sheet1 without relationships",
+			parts: map[string]string{
+				"xl/workbook.xml":          testWorkbookXML,
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="B1"><v>x</v></c></row></sheetData></worksheet>`,
+			},
+			want: []record{
+				{line: 1, cells: []string{"", "x"}},
+			},
+		},
+		{
+			name: "missing workbook",
+			parts: map[string]string{
+				"xl/worksheets/sheet1.xml": sheet,
+			},
+			err: ErrMalformedFile,
+		},
+		{
+			name: "bad shared string index",
+			parts: map[string]string{
+				"xl/workbook.xml":          testWorkbookXML,
+				"xl/sharedStrings.xml":     testSharedStringsXML,
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>7</v></c></row></sheetData></worksheet>`,
+			},
+			err: ErrMalformedFile,
+		},
+		{
+			name: "column out of range",
+			parts: map[string]string{
+				"xl/workbook.xml":          testWorkbookXML,
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="ZZZZ1"><v>1</v></c></row></sheetData></worksheet>`,
+			},
+			err: ErrMalformedFile,
+		},
+		{
+			name: "row out of range",
+			parts: map[string]string{
+				"xl/workbook.xml":          testWorkbookXML,
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="2000000"><c r="A2000000"><v>1</v></c></row></sheetData></worksheet>`,
+			},
+			err: ErrMalformedFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readXLSX(workbook(t, tt.parts))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readXLSX = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("not a zip", func(t *testing.T) {
+		if _, err := readXLSX([]byte("email,display_name\n")); !errors.Is(err, ErrMalformedFile) {
+			t.Fatalf("err = %v, want %v", err, ErrMalformedFile)
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		maxRows int
+		want    *Sheet
+		err     error
+	}{
+		{
+			name:    "blank rows dropped and cells padded",
+			data:    "\n , \n email , display_name \n\na@example.com\n",
+			maxRows: 10,
+			want: &Sheet{
+				Headers: []string{"email", "display_name"},
+				Rows:    []Row{{Line: 5, Cells: []string{"a@example.com", ""}}},
+			},
+		},
+		{
+			name:    "extra cells dropped",
+			data:    "email\na@example.com,extra\n",
+			maxRows: 10,
+			want: &Sheet{
+				Headers: []string{"email"},
+				Rows:    []Row{{Line: 2, Cells: []string{"a@example.com"}}},
+			},
+		},
+		{
+			name:    "too many rows",
+			data:    "email\na@example.com\nb@example.com\n",
+			maxRows: 1,
+			err:     ErrTooManyRows,
+		},
+		{
+			name:    "no header",
+			data:    "\n,\n",
+			maxRows: 10,
+			err:     ErrEmptySheet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(FormatCSV, []byte(tt.data), tt.maxRows)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
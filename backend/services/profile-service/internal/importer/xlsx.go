@@ -0,0 +1,235 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxXMLPartSize caps how much of any one decompressed workbook part is
+	// read, guarding against zip bombs.
+	maxXMLPartSize = 64 << 20
+
+	// Excel's own sheet limits; anything larger is not a real workbook.
+	maxSheetRows    = 1 << 20
+	maxSheetColumns = 1 << 14
+)
+
+// readXLSX reads the first worksheet of an Office Open XML workbook. Only
+// cell values are read; formulas contribute their cached result.
+func readXLSX(data []byte) ([]record, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not an xlsx archive", ErrMalformedFile)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		shared, err = readSharedStrings(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing worksheet %s", ErrMalformedFile, sheetPath)
+	}
+	return readWorksheet(f, shared)
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// firstSheetPath resolves the first sheet in workbook order to its part name,
+// falling back to the conventional sheet1.xml.
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: missing workbook", ErrMalformedFile)
+	}
+	var wb xlsxWorkbook
+	if err := decodePart(wbFile, &wb); err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", fmt.Errorf("%w: workbook has no sheets", ErrMalformedFile)
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return fallback, nil
+	}
+	var rels xlsxRelationships
+	if err := decodePart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText is a string item: plain text in <t> or rich text split into runs.
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	b.WriteString(t.T)
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	var sst xlsxSharedStrings
+	if err := decodePart(f, &sst); err != nil {
+		return nil, err
+	}
+	shared := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		shared[i] = item.String()
+	}
+	return shared, nil
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readWorksheet(f *zip.File, shared []string) ([]record, error) {
+	var ws xlsxWorksheet
+	if err := decodePart(f, &ws); err != nil {
+		return nil, err
+	}
+
+	records := []record{}
+	line := 0
+	for _, row := range ws.Rows {
+		// The r attribute is optional; rows without it follow the previous one
+		line++
+		if row.Index > 0 {
+			line = row.Index
+		}
+		if line > maxSheetRows {
+			return nil, fmt.Errorf("%w: row %d out of range", ErrMalformedFile, line)
+		}
+
+		cells := []string{}
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				c, err := columnIndex(cell.Ref)
+				if err != nil {
+					return nil, err
+				}
+				col = c
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(shared) {
+					return nil, fmt.Errorf("%w: bad shared string index in %s", ErrMalformedFile, cell.Ref)
+				}
+				cells[col] = shared[idx]
+			case "inlineStr":
+				cells[col] = cell.Inline.String()
+			default:
+				cells[col] = cell.Value
+			}
+		}
+		records = append(records, record{line: line, cells: cells})
+	}
+
+	return records, nil
+}
+
+// columnIndex converts the letters of a cell reference ("AB12") to a
+// zero-based column index.
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 || col > maxSheetColumns {
+		return 0, fmt.Errorf("%w: bad cell reference %q", ErrMalformedFile, ref)
+	}
+	return col - 1, nil
+}
+
+func decodePart(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrMalformedFile, f.Name, err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, maxXMLPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrMalformedFile, f.Name, err)
+	}
+	return nil
+}
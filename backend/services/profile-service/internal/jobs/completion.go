@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/scouttalent/profile-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// RescoreProfiles recalculates stored completion scores. It only writes
+// scores that changed, so it is cheap to run on every start once settled.
+func RescoreProfiles(ctx context.Context, svc *service.ProfileService, logger *zap.Logger) {
+	updated, err := svc.RescoreProfiles(ctx)
+	if err != nil {
+		logger.Error("failed to rescore profile completion", zap.Int("updated", updated), zap.Error(err))
+		return
+	}
+	if updated > 0 {
+		logger.Info("rescored profile completion", zap.Int("updated", updated))
+	}
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/scouttalent/profile-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// BackfillLocations normalizes locations stored before the gazetteer was
+// introduced. It is idempotent and safe to run on every start.
+func BackfillLocations(ctx context.Context, svc *service.ProfileService, logger *zap.Logger) {
+	updated, err := svc.BackfillLocations(ctx)
+	if err != nil {
+		logger.Error("failed to backfill profile locations", zap.Int("updated", updated), zap.Error(err))
+		return
+	}
+	if updated > 0 {
+		logger.Info("backfilled profile locations", zap.Int("updated", updated))
+	}
+}
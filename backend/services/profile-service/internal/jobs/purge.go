@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const purgeInterval = time.Hour
+
+// ProfilePurger hard-deletes deactivated profiles once their grace period
+// has ended. Instances can run side by side; claimed rows are locked.
+type ProfilePurger struct {
+	svc    *service.ProfileService
+	logger *zap.Logger
+}
+
+func NewProfilePurger(svc *service.ProfileService, logger *zap.Logger) *ProfilePurger {
+	return &ProfilePurger{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// Run purges on start and then every purgeInterval until ctx is cancelled.
+func (p *ProfilePurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			more, err := p.svc.PurgeDeactivatedProfiles(ctx)
+			if err != nil {
+				p.logger.Error("failed to purge deactivated profiles", zap.Error(err))
+				break
+			}
+			if !more {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Package jobs runs background work that is queued in the database.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/service"
+	"go.uber.org/zap"
+)
+
+const rosterPollInterval = 2 * time.Second
+
+// RosterImporter drains the roster import queue. Several instances can run
+// side by side; each import is leased to one of them at a time.
+type RosterImporter struct {
+	svc    *service.ProfileService
+	logger *zap.Logger
+}
+
+func NewRosterImporter(svc *service.ProfileService, logger *zap.Logger) *RosterImporter {
+	return &RosterImporter{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+// Run polls for queued imports until ctx is cancelled.
+func (w *RosterImporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(rosterPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for ctx.Err() == nil {
+			worked, err := w.svc.ProcessRosterImportBatch(ctx)
+			if err != nil {
+				w.logger.Error("failed to process roster import", zap.Error(err))
+				break
+			}
+			if !worked {
+				break
+			}
+		}
+	}
+}
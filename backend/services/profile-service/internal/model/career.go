@@ -0,0 +1,119 @@
+package model
+
+import (
+	"time"
+)
+
+type CareerEntry struct {
+	ID            string         `json:"id" db:"id"`
+	ProfileID     string         `json:"profile_id" db:"profile_id"`
+	ClubName      string         `json:"club_name" db:"club_name"`
+	ClubProfileID *string        `json:"club_profile_id,omitempty" db:"club_profile_id"`
+	Country       *string        `json:"country,omitempty" db:"country"`
+	StartDate     time.Time      `json:"start_date" db:"start_date"`
+	EndDate       *time.Time     `json:"end_date,omitempty" db:"end_date"`
+	IsCurrent     bool           `json:"is_current" db:"is_current"`
+	ConfirmedAt   *time.Time     `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	ConfirmedBy   *string        `json:"confirmed_by,omitempty" db:"confirmed_by"`
+	RejectedAt    *time.Time     `json:"rejected_at,omitempty" db:"rejected_at"`
+	RejectedBy    *string        `json:"rejected_by,omitempty" db:"rejected_by"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+	Seasons       []*SeasonStats `json:"seasons"`
+}
+
+// ClubConfirmed reports whether the linked club has confirmed this entry.
+func (e *CareerEntry) ClubConfirmed() bool {
+	return e.ConfirmedAt != nil
+}
+
+type SeasonStats struct {
+	ID            string    `json:"id" db:"id"`
+	CareerEntryID string    `json:"career_entry_id" db:"career_entry_id"`
+	Season        string    `json:"season" db:"season"`
+	Competition   string    `json:"competition" db:"competition"`
+	Appearances   int       `json:"appearances" db:"appearances"`
+	MinutesPlayed int       `json:"minutes_played" db:"minutes_played"`
+	Goals         int       `json:"goals" db:"goals"`
+	Assists       int       `json:"assists" db:"assists"`
+	YellowCards   int       `json:"yellow_cards" db:"yellow_cards"`
+	RedCards      int       `json:"red_cards" db:"red_cards"`
+	CleanSheets   *int      `json:"clean_sheets,omitempty" db:"clean_sheets"`
+	GoalsConceded *int      `json:"goals_conceded,omitempty" db:"goals_conceded"`
+	Saves         *int      `json:"saves,omitempty" db:"saves"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CareerTotals aggregates season stats across a player's whole career.
+// Goalkeeper fields are only populated for goalkeepers.
+type CareerTotals struct {
+	Clubs         int  `json:"clubs"`
+	Seasons       int  `json:"seasons"`
+	Appearances   int  `json:"appearances"`
+	MinutesPlayed int  `json:"minutes_played"`
+	Goals         int  `json:"goals"`
+	Assists       int  `json:"assists"`
+	YellowCards   int  `json:"yellow_cards"`
+	RedCards      int  `json:"red_cards"`
+	CleanSheets   *int `json:"clean_sheets,omitempty"`
+	GoalsConceded *int `json:"goals_conceded,omitempty"`
+	Saves         *int `json:"saves,omitempty"`
+}
+
+// StatSchema describes which optional season stats apply to a position.
+type StatSchema struct {
+	CleanSheets   bool `json:"clean_sheets"`
+	GoalsConceded bool `json:"goals_conceded"`
+	Saves         bool `json:"saves"`
+}
+
+// StatSchemaForPosition returns the optional stats a player in the given
+// position may record. Clean sheets are tracked for goalkeepers and
+// defenders; goals conceded and saves for goalkeepers only.
+func StatSchemaForPosition(position string) StatSchema {
+	switch position {
+	case "goalkeeper":
+		return StatSchema{CleanSheets: true, GoalsConceded: true, Saves: true}
+	case "defender":
+		return StatSchema{CleanSheets: true}
+	default:
+		return StatSchema{}
+	}
+}
+
+type CreateCareerEntryRequest struct {
+	ClubName      string     `json:"club_name" binding:"required,min=2,max=200"`
+	ClubProfileID *string    `json:"club_profile_id" binding:"omitempty,uuid"`
+	Country       *string    `json:"country" binding:"omitempty,max=100"`
+	StartDate     time.Time  `json:"start_date" binding:"required"`
+	EndDate       *time.Time `json:"end_date" binding:"omitempty"`
+	IsCurrent     bool       `json:"is_current"`
+}
+
+// UpdateCareerEntryRequest changes the fields it sets. A missing end_date
+// leaves it unchanged; ClearEndDate removes it, e.g. when the player
+// returns to the club.
+type UpdateCareerEntryRequest struct {
+	ClubName      *string    `json:"club_name" binding:"omitempty,min=2,max=200"`
+	ClubProfileID *string    `json:"club_profile_id" binding:"omitempty,uuid"`
+	Country       *string    `json:"country" binding:"omitempty,max=100"`
+	StartDate     *time.Time `json:"start_date" binding:"omitempty"`
+	EndDate       *time.Time `json:"end_date" binding:"omitempty"`
+	ClearEndDate  bool       `json:"clear_end_date"`
+	IsCurrent     *bool      `json:"is_current"`
+}
+
+type SeasonStatsRequest struct {
+	Season        string `json:"season" binding:"required,max=9"`
+	Competition   string `json:"competition" binding:"required,min=2,max=200"`
+	Appearances   int    `json:"appearances" binding:"min=0"`
+	MinutesPlayed int    `json:"minutes_played" binding:"min=0"`
+	Goals         int    `json:"goals" binding:"min=0"`
+	Assists       int    `json:"assists" binding:"min=0"`
+	YellowCards   int    `json:"yellow_cards" binding:"min=0"`
+	RedCards      int    `json:"red_cards" binding:"min=0"`
+	CleanSheets   *int   `json:"clean_sheets" binding:"omitempty,min=0"`
+	GoalsConceded *int   `json:"goals_conceded" binding:"omitempty,min=0"`
+	Saves         *int   `json:"saves" binding:"omitempty,min=0"`
+}
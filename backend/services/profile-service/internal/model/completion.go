@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+)
+
+// Completion checklist item keys.
+const (
+	CompletionDisplayName   = "display_name"
+	CompletionAvatar        = "avatar"
+	CompletionBio           = "bio"
+	CompletionLocation      = "location"
+	CompletionContact       = "contact"
+	CompletionPlayerDetails = "player_details"
+	CompletionPhysicals     = "physicals"
+	CompletionCareer        = "career"
+	CompletionFirstVideo    = "first_video"
+	CompletionStaff         = "staff"
+	CompletionTrustVerified = "trust_verified"
+)
+
+// CompletionRule is one weighted checklist item. Hint is the frontend route
+// that lets the user complete it.
+type CompletionRule struct {
+	Key    string `json:"key"`
+	Label  string `json:"label"`
+	Weight int    `json:"weight"`
+	Hint   string `json:"hint"`
+}
+
+// CompletionRules lists each profile type's checklist. Weights per type sum
+// to 100 so the score is a percentage.
+var CompletionRules = map[UserType][]CompletionRule{
+	UserTypePlayer: {
+		{CompletionDisplayName, "Add your name", 5, "/profile/edit#display_name"},
+		{CompletionAvatar, "Add a profile photo", 10, "/profile/edit#avatar"},
+		{CompletionBio, "Write a short bio", 10, "/profile/edit#bio"},
+		{CompletionLocation, "Add your city", 10, "/profile/edit#location"},
+		{CompletionContact, "Add contact details", 10, "/profile/edit#contact"},
+		{CompletionPlayerDetails, "Add your position and player details", 15, "/profile/player"},
+		{CompletionPhysicals, "Add date of birth and height", 10, "/profile/player#physicals"},
+		{CompletionCareer, "Add a club to your career history", 10, "/profile/career/new"},
+		{CompletionFirstVideo, "Upload your first video", 20, "/videos/upload"},
+	},
+	UserTypeScout: {
+		{CompletionDisplayName, "Add your name", 10, "/profile/edit#display_name"},
+		{CompletionAvatar, "Add a profile photo", 15, "/profile/edit#avatar"},
+		{CompletionBio, "Write a short bio", 20, "/profile/edit#bio"},
+		{CompletionLocation, "Add your city", 15, "/profile/edit#location"},
+		{CompletionContact, "Add contact details", 15, "/profile/edit#contact"},
+		{CompletionTrustVerified, "Get your scout account verified", 25, "/settings/verification"},
+	},
+	UserTypeAcademy: {
+		{CompletionDisplayName, "Add your academy name", 5, "/profile/edit#display_name"},
+		{CompletionAvatar, "Add a logo", 15, "/profile/edit#avatar"},
+		{CompletionBio, "Describe your academy", 20, "/profile/edit#bio"},
+		{CompletionLocation, "Add your city", 15, "/profile/edit#location"},
+		{CompletionContact, "Add contact details", 15, "/profile/edit#contact"},
+		{CompletionStaff, "Add a staff member", 15, "/academy/staff"},
+		{CompletionFirstVideo, "Upload your first video", 15, "/videos/upload"},
+	},
+}
+
+// CompletionItem is a checklist item and whether the profile has done it.
+type CompletionItem struct {
+	CompletionRule
+	Done bool `json:"done"`
+}
+
+// CompletionChecklist is a profile's completion score with the items behind
+// it. Missing holds the undone items, heaviest first.
+type CompletionChecklist struct {
+	ProfileID string           `json:"profile_id"`
+	Type      UserType         `json:"type"`
+	Score     int              `json:"score"`
+	Items     []CompletionItem `json:"items"`
+	Missing   []CompletionItem `json:"missing"`
+}
+
+// CompletionFacts are the stored facts the checklist is evaluated against
+// besides the profile row itself.
+type CompletionFacts struct {
+	HasPlayerDetails bool
+	HasPhysicals     bool
+	CareerEntries    int
+	StaffMembers     int
+	Videos           int
+}
+
+// Completion signals recorded from other services' events.
+const (
+	SignalVideoUploaded = "video_uploaded"
+)
+
+// CompletionSignal is a fact reported by another service. RefID tells apart
+// repeated signals, e.g. the video ID for SignalVideoUploaded.
+type CompletionSignal struct {
+	UserID    string    `json:"user_id" db:"user_id"`
+	Signal    string    `json:"signal" db:"signal"`
+	RefID     string    `json:"ref_id" db:"ref_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// VideoEvent is the part of media-service's video events the profile
+// service reads.
+type VideoEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	ProfileID string `json:"profile_id"`
+	VideoID   string `json:"video_id"`
+}
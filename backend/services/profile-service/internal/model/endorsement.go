@@ -0,0 +1,65 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+type EndorsementStatus string
+
+const (
+	EndorsementStatusPending  EndorsementStatus = "pending"
+	EndorsementStatusAccepted EndorsementStatus = "accepted"
+	EndorsementStatusHidden   EndorsementStatus = "hidden"
+)
+
+const (
+	EventProfileEndorsed    = "profile.endorsed"
+	EventEndorsementRevoked = "profile.endorsement_revoked"
+)
+
+// CharacterTraits are the non-technical qualities a player can be endorsed
+// for alongside the skill taxonomy.
+var CharacterTraits = []string{
+	"leadership",
+	"work_rate",
+	"teamwork",
+	"discipline",
+	"coachability",
+	"composure",
+	"communication",
+	"sportsmanship",
+}
+
+// ValidEndorsementSkill reports whether key is a skill taxonomy key or a
+// character trait.
+func ValidEndorsementSkill(key string) bool {
+	return ValidSkill(key) || slices.Contains(CharacterTraits, key)
+}
+
+// Endorsement is a scout's, coach's or academy's reference for one of a
+// player's skills or traits. EndorserTrustLevel is captured when the
+// endorsement is made; Endorser carries the endorser's current profile.
+type Endorsement struct {
+	ID                 string            `json:"id" db:"id"`
+	PlayerID           string            `json:"player_id" db:"player_profile_id"`
+	EndorserID         string            `json:"endorser_id" db:"endorser_profile_id"`
+	Endorser           *ProfileSummary   `json:"endorser,omitempty" db:"-"`
+	Skill              string            `json:"skill" db:"skill"`
+	Comment            *string           `json:"comment,omitempty" db:"comment"`
+	EndorserTrustLevel TrustLevel        `json:"endorser_trust_level" db:"endorser_trust_level"`
+	Status             EndorsementStatus `json:"status" db:"status"`
+	RespondedAt        *time.Time        `json:"responded_at,omitempty" db:"responded_at"`
+	RevokedAt          *time.Time        `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt          time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+type CreateEndorsementRequest struct {
+	Skill   string  `json:"skill" binding:"required,max=64"`
+	Comment *string `json:"comment" binding:"omitempty,max=500"`
+}
+
+type RespondEndorsementRequest struct {
+	Status EndorsementStatus `json:"status" binding:"required,oneof=accepted hidden"`
+}
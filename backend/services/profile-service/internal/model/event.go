@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventProfileCreated              = "profile.created"
+	EventProfileUpdated              = "profile.updated"
+	EventProfilePlayerDetailsUpdated = "profile.player_details.updated"
+	EventProfileDeleted              = "profile.deleted"
+	EventProfileDeactivated          = "profile.deactivated"
+	EventProfileRestored             = "profile.restored"
+
+	// EventSchemaVersion is bumped whenever an event payload changes in a
+	// backwards-incompatible way.
+	EventSchemaVersion = 1
+
+	// EventStream is the JetStream stream that keeps profile events for
+	// consumers that were away when they were published.
+	EventStream         = "PROFILES"
+	EventStreamSubjects = "profile.>"
+)
+
+// Event is the envelope published for every profile change. Data carries a
+// full snapshot of the aggregate after the change.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	Source     string          `json:"source"`
+	ProfileID  string          `json:"profile_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// OutboxEvent is a row in outbox_events awaiting relay to NATS.
+type OutboxEvent struct {
+	ID       int64
+	EventID  string
+	Subject  string
+	Payload  []byte
+	Attempts int
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	EventProfileFollowed   = "profile.followed"
+	EventProfileUnfollowed = "profile.unfollowed"
+)
+
+// Follow is the payload of follow events.
+type Follow struct {
+	FollowerID string    `json:"follower_id" db:"follower_id"`
+	FolloweeID string    `json:"followee_id" db:"followee_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// ProfileSummary is the compact profile shown in follower lists.
+type ProfileSummary struct {
+	ID          string     `json:"id" db:"id"`
+	Slug        string     `json:"slug" db:"slug"`
+	Type        UserType   `json:"type" db:"type"`
+	DisplayName string     `json:"display_name" db:"display_name"`
+	AvatarURL   *string    `json:"avatar_url,omitempty" db:"avatar_url"`
+	TrustLevel  TrustLevel `json:"trust_level" db:"trust_level"`
+}
+
+// Connection is a profile in a follower, following, block or mute list
+// together with when the relationship was created.
+type Connection struct {
+	Profile ProfileSummary `json:"profile"`
+	Since   time.Time      `json:"since"`
+}
+
+type ConnectionPage struct {
+	Connections []*Connection `json:"connections"`
+	NextCursor  string        `json:"next_cursor,omitempty"`
+}
+
+// ConnectionCursor is the keyset position after the last returned row.
+type ConnectionCursor struct {
+	Since     time.Time
+	ProfileID string
+}
+
+// Relationship describes how the viewer's profile relates to another.
+type Relationship struct {
+	Following  bool `json:"following"`
+	FollowedBy bool `json:"followed_by"`
+	Blocking   bool `json:"blocking"`
+	Muting     bool `json:"muting"`
+}
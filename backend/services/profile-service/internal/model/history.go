@@ -0,0 +1,57 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Change actions recorded in the profile change log.
+const (
+	ChangeActionProfileUpdated       = "profile.updated"
+	ChangeActionPrivacyUpdated       = "privacy.updated"
+	ChangeActionPlayerDetailsCreated = "player_details.created"
+	ChangeActionPlayerDetailsUpdated = "player_details.updated"
+	ChangeActionPlayerVerified       = "player_details.verified"
+	ChangeActionVerificationRevoked  = "player_details.verification_revoked"
+	ChangeActionProfileDeactivated   = "profile.deactivated"
+	ChangeActionProfileTakenDown     = "profile.taken_down"
+	ChangeActionProfileRestored      = "profile.restored"
+	ChangeActionTranslationUpdated   = "translation.updated"
+	ChangeActionTranslationDeleted   = "translation.deleted"
+)
+
+// ActorSystem is the actor role of changes not made by a user.
+const ActorSystem = "system"
+
+// VerifiedPlayerFields are the player details an admin vouches for when
+// verifying a player. Changing any of them clears the verification.
+var VerifiedPlayerFields = []string{"date_of_birth", "current_team"}
+
+// FieldChange is one field's value before and after an edit, as JSON.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// ProfileChange is one entry in a profile's change log.
+type ProfileChange struct {
+	ID          string        `json:"id" db:"id"`
+	ProfileID   string        `json:"profile_id" db:"profile_id"`
+	Action      string        `json:"action" db:"action"`
+	ActorUserID *string       `json:"actor_user_id,omitempty" db:"actor_user_id"`
+	ActorRole   string        `json:"actor_role" db:"actor_role"`
+	Changes     []FieldChange `json:"changes" db:"changes"` // JSONB
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+}
+
+type ProfileChangePage struct {
+	Changes    []*ProfileChange `json:"changes"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// ChangeCursor is the keyset position after the last returned change.
+type ChangeCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
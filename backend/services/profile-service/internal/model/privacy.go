@@ -0,0 +1,85 @@
+package model
+
+// Audience controls who may see a profile or one of its sensitive fields.
+// The profile owner always sees everything.
+type Audience string
+
+const (
+	AudiencePublic             Audience = "public"
+	AudienceScoutsAndAcademies Audience = "scouts_and_academies"
+	AudienceVerifiedScouts     Audience = "verified_scouts"
+	AudienceHidden             Audience = "hidden"
+)
+
+type PrivacySettings struct {
+	Visibility            Audience `json:"visibility" db:"visibility"`
+	CityVisibility        Audience `json:"city_visibility" db:"city_visibility"`
+	ContactVisibility     Audience `json:"contact_visibility" db:"contact_visibility"`
+	DateOfBirthVisibility Audience `json:"date_of_birth_visibility" db:"date_of_birth_visibility"`
+}
+
+// DefaultPrivacySettings matches the column defaults in the profiles table.
+// Viewers outside DateOfBirthVisibility still see the player's age.
+func DefaultPrivacySettings() PrivacySettings {
+	return PrivacySettings{
+		Visibility:            AudiencePublic,
+		CityVisibility:        AudienceScoutsAndAcademies,
+		ContactVisibility:     AudienceVerifiedScouts,
+		DateOfBirthVisibility: AudienceVerifiedScouts,
+	}
+}
+
+type UpdatePrivacyRequest struct {
+	Visibility            *Audience `json:"visibility" binding:"omitempty,oneof=public scouts_and_academies verified_scouts hidden"`
+	CityVisibility        *Audience `json:"city_visibility" binding:"omitempty,oneof=public scouts_and_academies verified_scouts hidden"`
+	ContactVisibility     *Audience `json:"contact_visibility" binding:"omitempty,oneof=public scouts_and_academies verified_scouts hidden"`
+	DateOfBirthVisibility *Audience `json:"date_of_birth_visibility" binding:"omitempty,oneof=public scouts_and_academies verified_scouts hidden"`
+}
+
+// Viewer identifies who is reading a profile. An empty UserID is an
+// anonymous viewer. Languages are the viewer's preferred content locales,
+// most preferred first.
+type Viewer struct {
+	UserID     string
+	Role       string
+	TrustLevel string
+	Languages  []string
+}
+
+// RoleAdmin is the auth-service role of platform administrators.
+const RoleAdmin = "admin"
+
+func (v Viewer) IsAdmin() bool {
+	return v.Role == RoleAdmin
+}
+
+func (v Viewer) IsVerifiedScout() bool {
+	return v.Role == string(UserTypeScout) &&
+		(v.TrustLevel == string(TrustLevelVerified) || v.TrustLevel == string(TrustLevelPro))
+}
+
+// CanSee reports whether the viewer belongs to the given audience.
+func (v Viewer) CanSee(audience Audience) bool {
+	switch audience {
+	case AudiencePublic:
+		return true
+	case AudienceScoutsAndAcademies:
+		return v.Role == string(UserTypeScout) || v.Role == string(UserTypeAcademy)
+	case AudienceVerifiedScouts:
+		return v.IsVerifiedScout()
+	default:
+		return false
+	}
+}
+
+// Audiences lists every audience the viewer belongs to, for filtering
+// profile lists in SQL.
+func (v Viewer) Audiences() []string {
+	audiences := []string{}
+	for _, a := range []Audience{AudiencePublic, AudienceScoutsAndAcademies, AudienceVerifiedScouts} {
+		if v.CanSee(a) {
+			audiences = append(audiences, string(a))
+		}
+	}
+	return audiences
+}
@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestViewerCanSee(t *testing.T) {
+	anonymous := Viewer{}
+	player := Viewer{UserID: "u1", Role: string(UserTypePlayer), TrustLevel: string(TrustLevelPro)}
+	academy := Viewer{UserID: "u2", Role: string(UserTypeAcademy), TrustLevel: string(TrustLevelVerified)}
+	scout := Viewer{UserID: "u3", Role: string(UserTypeScout), TrustLevel: string(TrustLevelEstablished)}
+	verifiedScout := Viewer{UserID: "u4", Role: string(UserTypeScout), TrustLevel: string(TrustLevelVerified)}
+	proScout := Viewer{UserID: "u5", Role: string(UserTypeScout), TrustLevel: string(TrustLevelPro)}
+	admin := Viewer{UserID: "u6", Role: RoleAdmin}
+
+	tests := []struct {
+		name     string
+		viewer   Viewer
+		audience Audience
+		want     bool
+	}{
+		{"anonymous public", anonymous, AudiencePublic, true},
+		{"anonymous scouts and academies", anonymous, AudienceScoutsAndAcademies, false},
+		{"anonymous verified scouts", anonymous, AudienceVerifiedScouts, false},
+		{"player scouts and academies", player, AudienceScoutsAndAcademies, false},
+		{"player verified scouts", player, AudienceVerifiedScouts, false},
+		{"academy scouts and academies", academy, AudienceScoutsAndAcademies, true},
+		{"verified academy verified scouts", academy, AudienceVerifiedScouts, false},
+		{"scout scouts and academies", scout, AudienceScoutsAndAcademies, true},
+		{"unverified scout verified scouts", scout, AudienceVerifiedScouts, false},
+		{"verified scout verified scouts", verifiedScout, AudienceVerifiedScouts, true},
+		{"pro scout verified scouts", proScout, AudienceVerifiedScouts, true},
+		{"pro scout hidden", proScout, AudienceHidden, false},
+		{"admin hidden", admin, AudienceHidden, false},
+		{"unknown audience", verifiedScout, Audience("friends"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.viewer.CanSee(tt.audience); got != tt.want {
+				t.Errorf("CanSee(%s) = %v, want %v", tt.audience, got, tt.want)
+			}
+		})
+	}
+}
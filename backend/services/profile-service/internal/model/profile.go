@@ -6,6 +6,7 @@ import (
 
 type UserType string
 type TrustLevel string
+type ProfileStatus string
 
 const (
 	UserTypePlayer  UserType = "player"
@@ -16,45 +17,86 @@ const (
 	TrustLevelEstablished TrustLevel = "established"
 	TrustLevelVerified    TrustLevel = "verified"
 	TrustLevelPro         TrustLevel = "pro"
+
+	ProfileStatusActive      ProfileStatus = "active"
+	ProfileStatusDeactivated ProfileStatus = "deactivated"
+	ProfileStatusTakenDown   ProfileStatus = "taken_down"
 )
 
 type Profile struct {
-	ID                     string     `json:"id" db:"id"`
-	UserID                 string     `json:"user_id" db:"user_id"`
-	Type                   UserType   `json:"type" db:"type"`
-	DisplayName            string     `json:"display_name" db:"display_name"`
-	Bio                    *string    `json:"bio,omitempty" db:"bio"`
-	AvatarURL              *string    `json:"avatar_url,omitempty" db:"avatar_url"`
-	LocationCountry        *string    `json:"location_country,omitempty" db:"location_country"`
-	LocationCity           *string    `json:"location_city,omitempty" db:"location_city"`
-	TrustLevel             TrustLevel `json:"trust_level" db:"trust_level"`
-	ProfileCompletionScore int        `json:"profile_completion_score" db:"profile_completion_score"`
-	CreatedAt              time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt              time.Time  `json:"updated_at" db:"updated_at"`
+	ID                     string          `json:"id" db:"id"`
+	UserID                 string          `json:"user_id" db:"user_id"`
+	Type                   UserType        `json:"type" db:"type"`
+	Slug                   string          `json:"slug" db:"slug"`
+	DisplayName            string          `json:"display_name" db:"display_name"`
+	Bio                    *string         `json:"bio,omitempty" db:"bio"`
+	AvatarURL              *string         `json:"avatar_url,omitempty" db:"avatar_url"`
+	LocationCountry        *string         `json:"location_country,omitempty" db:"location_country"`
+	LocationCity           *string         `json:"location_city,omitempty" db:"location_city"`
+	LocationCountryCode    *string         `json:"location_country_code,omitempty" db:"location_country_code"`
+	LocationCityID         *string         `json:"location_city_id,omitempty" db:"location_city_id"`
+	LocationLat            *float64        `json:"location_lat,omitempty" db:"location_lat"`
+	LocationLng            *float64        `json:"location_lng,omitempty" db:"location_lng"`
+	ContactEmail           *string         `json:"contact_email,omitempty" db:"contact_email"`
+	ContactPhone           *string         `json:"contact_phone,omitempty" db:"contact_phone"`
+	TrustLevel             TrustLevel      `json:"trust_level" db:"trust_level"`
+	ProfileCompletionScore int             `json:"profile_completion_score" db:"profile_completion_score"`
+	FollowerCount          int             `json:"follower_count" db:"follower_count"`
+	FollowingCount         int             `json:"following_count" db:"following_count"`
+	Privacy                PrivacySettings `json:"privacy"`
+	Status                 ProfileStatus   `json:"status" db:"status"`
+	DeactivatedAt          *time.Time      `json:"deactivated_at,omitempty" db:"deactivated_at"`
+	DeactivatedBy          *string         `json:"deactivated_by,omitempty" db:"deactivated_by"`
+	DeactivationReason     *string         `json:"deactivation_reason,omitempty" db:"deactivation_reason"`
+	PurgeAfter             *time.Time      `json:"purge_after,omitempty" db:"purge_after"`
+	PrimaryLanguage        string          `json:"primary_language" db:"primary_language"`
+	BioLanguage            *string         `json:"bio_language,omitempty" db:"bio_language"`
+	Locale                 string          `json:"locale,omitempty" db:"-"`
+	AvailableLocales       []string        `json:"available_locales,omitempty" db:"-"`
+	CreatedAt              time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt              time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// InternalProfile is what other services need to know about a profile to
+// enforce their own policies.
+type InternalProfile struct {
+	ID         string        `json:"id"`
+	Type       UserType      `json:"type"`
+	TrustLevel TrustLevel    `json:"trust_level"`
+	Status     ProfileStatus `json:"status"`
+	// Minor is set for players younger than AdultAge by their date of
+	// birth; players without one are not treated as minors
+	Minor bool `json:"minor"`
 }
 
+// AdultAge is the age from which a player is no longer a minor.
+const AdultAge = 18
+
 type PlayerDetails struct {
-	ProfileID      string     `json:"profile_id" db:"profile_id"`
-	Position       string     `json:"position" db:"position"`
-	DateOfBirth    *time.Time `json:"date_of_birth,omitempty" db:"date_of_birth"`
-	HeightCM       *int       `json:"height_cm,omitempty" db:"height_cm"`
-	WeightKG       *int       `json:"weight_kg,omitempty" db:"weight_kg"`
-	PreferredFoot  *string    `json:"preferred_foot,omitempty" db:"preferred_foot"`
-	CurrentTeam    *string    `json:"current_team,omitempty" db:"current_team"`
-	SkillScores    *string    `json:"skill_scores,omitempty" db:"skill_scores"` // JSONB
-	OverallScore   *float64   `json:"overall_score,omitempty" db:"overall_score"`
-	LastScoredAt   *time.Time `json:"last_scored_at,omitempty" db:"last_scored_at"`
+	ProfileID     string       `json:"profile_id" db:"profile_id"`
+	Position      string       `json:"position" db:"position"`
+	DateOfBirth   *time.Time   `json:"date_of_birth,omitempty" db:"date_of_birth"`
+	Age           *int         `json:"age,omitempty" db:"-"`
+	HeightCM      *int         `json:"height_cm,omitempty" db:"height_cm"`
+	WeightKG      *int         `json:"weight_kg,omitempty" db:"weight_kg"`
+	PreferredFoot *string      `json:"preferred_foot,omitempty" db:"preferred_foot"`
+	CurrentTeam   *string      `json:"current_team,omitempty" db:"current_team"`
+	SkillScores   *SkillScores `json:"skill_scores,omitempty" db:"skill_scores"` // JSONB
+	OverallScore  *float64     `json:"overall_score,omitempty" db:"overall_score"`
+	LastScoredAt  *time.Time   `json:"last_scored_at,omitempty" db:"last_scored_at"`
+	VerifiedAt    *time.Time   `json:"verified_at,omitempty" db:"verified_at"`
+	VerifiedBy    *string      `json:"verified_by,omitempty" db:"verified_by"`
 }
 
 type ScoutDetails struct {
-	ProfileID            string     `json:"profile_id" db:"profile_id"`
-	Organization         *string    `json:"organization,omitempty" db:"organization"`
-	OrganizationType     *string    `json:"organization_type,omitempty" db:"organization_type"`
-	RegionsOfInterest    []string   `json:"regions_of_interest,omitempty" db:"regions_of_interest"`
-	PositionsOfInterest  []string   `json:"positions_of_interest,omitempty" db:"positions_of_interest"`
-	VerifiedAt           *time.Time `json:"verified_at,omitempty" db:"verified_at"`
-	VerifiedBy           *string    `json:"verified_by,omitempty" db:"verified_by"`
-	VerificationDocuments *string   `json:"verification_documents,omitempty" db:"verification_documents"` // JSONB
+	ProfileID             string     `json:"profile_id" db:"profile_id"`
+	Organization          *string    `json:"organization,omitempty" db:"organization"`
+	OrganizationType      *string    `json:"organization_type,omitempty" db:"organization_type"`
+	RegionsOfInterest     []string   `json:"regions_of_interest,omitempty" db:"regions_of_interest"`
+	PositionsOfInterest   []string   `json:"positions_of_interest,omitempty" db:"positions_of_interest"`
+	VerifiedAt            *time.Time `json:"verified_at,omitempty" db:"verified_at"`
+	VerifiedBy            *string    `json:"verified_by,omitempty" db:"verified_by"`
+	VerificationDocuments *string    `json:"verification_documents,omitempty" db:"verification_documents"` // JSONB
 }
 
 type CreateProfileRequest struct {
@@ -62,6 +104,8 @@ type CreateProfileRequest struct {
 	Bio             *string `json:"bio" binding:"omitempty,max=500"`
 	LocationCountry *string `json:"location_country" binding:"omitempty,max=100"`
 	LocationCity    *string `json:"location_city" binding:"omitempty,max=100"`
+	LocationCityID  *string `json:"location_city_id" binding:"omitempty,max=64"`
+	PrimaryLanguage *string `json:"primary_language" binding:"omitempty,max=8"`
 }
 
 type UpdateProfileRequest struct {
@@ -70,6 +114,10 @@ type UpdateProfileRequest struct {
 	AvatarURL       *string `json:"avatar_url" binding:"omitempty,url"`
 	LocationCountry *string `json:"location_country" binding:"omitempty,max=100"`
 	LocationCity    *string `json:"location_city" binding:"omitempty,max=100"`
+	LocationCityID  *string `json:"location_city_id" binding:"omitempty,max=64"`
+	ContactEmail    *string `json:"contact_email" binding:"omitempty,email,max=255"`
+	ContactPhone    *string `json:"contact_phone" binding:"omitempty,e164"`
+	PrimaryLanguage *string `json:"primary_language" binding:"omitempty,max=8"`
 }
 
 type CreatePlayerDetailsRequest struct {
@@ -81,12 +129,31 @@ type CreatePlayerDetailsRequest struct {
 	CurrentTeam   *string    `json:"current_team" binding:"omitempty,max=100"`
 }
 
+type UpdatePlayerDetailsRequest struct {
+	Position      *string    `json:"position" binding:"omitempty,oneof=goalkeeper defender midfielder forward"`
+	DateOfBirth   *time.Time `json:"date_of_birth" binding:"omitempty"`
+	HeightCM      *int       `json:"height_cm" binding:"omitempty,min=100,max=250"`
+	WeightKG      *int       `json:"weight_kg" binding:"omitempty,min=30,max=150"`
+	PreferredFoot *string    `json:"preferred_foot" binding:"omitempty,oneof=left right both"`
+	CurrentTeam   *string    `json:"current_team" binding:"omitempty,max=100"`
+}
+
 type PlayerProfile struct {
 	Profile
-	PlayerDetails PlayerDetails `json:"player_details"`
+	PlayerDetails PlayerDetails  `json:"player_details"`
+	CareerTotals  *CareerTotals  `json:"career_totals,omitempty"`
+	Endorsements  []*Endorsement `json:"endorsements,omitempty"`
 }
 
 type ScoutProfile struct {
 	Profile
 	ScoutDetails ScoutDetails `json:"scout_details"`
-}
\ No newline at end of file
+}
+
+type DeactivateProfileRequest struct {
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+type TakedownProfileRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
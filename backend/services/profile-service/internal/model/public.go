@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+)
+
+// PublicProfile is the read-only view served on shareable profile pages.
+// It is always built for an anonymous viewer.
+type PublicProfile struct {
+	ID              string         `json:"id"`
+	Slug            string         `json:"slug"`
+	Type            UserType       `json:"type"`
+	DisplayName     string         `json:"display_name"`
+	Bio             *string        `json:"bio,omitempty"`
+	AvatarURL       *string        `json:"avatar_url,omitempty"`
+	LocationCountry *string        `json:"location_country,omitempty"`
+	LocationCity    *string        `json:"location_city,omitempty"`
+	TrustLevel      TrustLevel     `json:"trust_level"`
+	Locale          string         `json:"locale"`
+	Player          *PublicPlayer  `json:"player,omitempty"`
+	Videos          []*PublicVideo `json:"videos"`
+	Meta            OpenGraphMeta  `json:"meta"`
+}
+
+type PublicPlayer struct {
+	Position      string   `json:"position"`
+	Age           *int     `json:"age,omitempty"`
+	HeightCM      *int     `json:"height_cm,omitempty"`
+	WeightKG      *int     `json:"weight_kg,omitempty"`
+	PreferredFoot *string  `json:"preferred_foot,omitempty"`
+	CurrentTeam   *string  `json:"current_team,omitempty"`
+	OverallScore  *float64 `json:"overall_score,omitempty"`
+}
+
+// PublicVideo is the subset of a media-service video shown on public pages.
+type PublicVideo struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description,omitempty"`
+	ThumbnailURL *string   `json:"thumbnail_url,omitempty"`
+	Duration     *int      `json:"duration,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OpenGraphMeta carries og:* style fields for link previews.
+type OpenGraphMeta struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Image       *string `json:"image,omitempty"`
+	URL         string  `json:"url"`
+	Type        string  `json:"type"`
+	SiteName    string  `json:"site_name"`
+}
+
+type ShareToken struct {
+	ID        string     `json:"id" db:"id"`
+	ProfileID string     `json:"profile_id" db:"profile_id"`
+	Label     *string    `json:"label,omitempty" db:"label"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	// Token is only populated in the response that creates it
+	Token string `json:"token,omitempty" db:"-"`
+}
+
+type CreateShareTokenRequest struct {
+	Label          *string `json:"label" binding:"omitempty,max=100"`
+	ExpiresInHours int     `json:"expires_in_hours" binding:"required,min=1,max=720"`
+}
+
+type UpdateSlugRequest struct {
+	Slug string `json:"slug" binding:"required,min=3,max=60"`
+}
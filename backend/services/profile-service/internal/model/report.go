@@ -0,0 +1,140 @@
+package model
+
+import (
+	"time"
+)
+
+type ReportVisibility string
+
+const (
+	ReportVisibilityPrivate ReportVisibility = "private"
+	ReportVisibilityOrg     ReportVisibility = "org"
+)
+
+// ReportAttribute is one rated attribute on a report template.
+type ReportAttribute struct {
+	Key         string  `json:"key"`
+	Label       string  `json:"label"`
+	Description *string `json:"description,omitempty"`
+}
+
+type ReportTemplate struct {
+	ID               string            `json:"id" db:"id"`
+	AcademyProfileID *string           `json:"academy_profile_id,omitempty" db:"academy_profile_id"`
+	Name             string            `json:"name" db:"name"`
+	ScaleMax         int               `json:"scale_max" db:"scale_max"`
+	Attributes       []ReportAttribute `json:"attributes" db:"attributes"` // JSONB
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultReportTemplate is used by reports that do not reference an academy
+// template.
+var DefaultReportTemplate = ReportTemplate{
+	ID:       "default",
+	Name:     "Standard report",
+	ScaleMax: 10,
+	Attributes: []ReportAttribute{
+		{Key: "technique", Label: "Technique"},
+		{Key: "first_touch", Label: "First touch"},
+		{Key: "passing", Label: "Passing"},
+		{Key: "tactical_awareness", Label: "Tactical awareness"},
+		{Key: "decision_making", Label: "Decision making"},
+		{Key: "physical", Label: "Physical"},
+		{Key: "pace", Label: "Pace"},
+		{Key: "work_rate", Label: "Work rate"},
+		{Key: "mentality", Label: "Mentality"},
+	},
+}
+
+// Projected levels and recommendations, lowest to highest.
+var (
+	ProjectedLevels = []string{"amateur", "semi_professional", "professional", "top_division", "international"}
+	Recommendations = []string{"not_recommended", "monitor", "trial", "sign"}
+)
+
+// VideoRef points a report at a moment in one of the player's videos.
+type VideoRef struct {
+	VideoID          string  `json:"video_id" binding:"required,uuid"`
+	TimestampSeconds *int    `json:"timestamp_seconds,omitempty" binding:"omitempty,min=0"`
+	Note             *string `json:"note,omitempty" binding:"omitempty,max=500"`
+}
+
+// ScoutingReport is a report header together with the content of its
+// current version.
+type ScoutingReport struct {
+	ID               string           `json:"id" db:"id"`
+	PlayerID         string           `json:"player_id" db:"player_profile_id"`
+	AuthorID         string           `json:"author_id" db:"author_profile_id"`
+	AcademyProfileID *string          `json:"academy_profile_id,omitempty" db:"academy_profile_id"`
+	TemplateID       *string          `json:"template_id,omitempty" db:"template_id"`
+	Visibility       ReportVisibility `json:"visibility" db:"visibility"`
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	ScoutingReportVersion
+}
+
+type ScoutingReportVersion struct {
+	Version        int                `json:"version" db:"version"`
+	Ratings        map[string]float64 `json:"ratings" db:"ratings"` // JSONB
+	ScaleMax       int                `json:"scale_max" db:"scale_max"`
+	Strengths      *string            `json:"strengths,omitempty" db:"strengths"`
+	Weaknesses     *string            `json:"weaknesses,omitempty" db:"weaknesses"`
+	ProjectedLevel *string            `json:"projected_level,omitempty" db:"projected_level"`
+	Recommendation *string            `json:"recommendation,omitempty" db:"recommendation"`
+	VideoRefs      []VideoRef         `json:"video_refs" db:"video_refs"` // JSONB
+	EditedBy       *string            `json:"edited_by,omitempty" db:"edited_by"`
+	UpdatedAt      time.Time          `json:"updated_at" db:"created_at"`
+}
+
+type CreateReportRequest struct {
+	PlayerID         string             `json:"player_id" binding:"required,uuid"`
+	TemplateID       *string            `json:"template_id" binding:"omitempty,uuid"`
+	Visibility       ReportVisibility   `json:"visibility" binding:"required,oneof=private org"`
+	AcademyProfileID *string            `json:"academy_profile_id" binding:"omitempty,uuid"`
+	Ratings          map[string]float64 `json:"ratings" binding:"required"`
+	Strengths        *string            `json:"strengths" binding:"omitempty,max=5000"`
+	Weaknesses       *string            `json:"weaknesses" binding:"omitempty,max=5000"`
+	ProjectedLevel   *string            `json:"projected_level" binding:"omitempty,oneof=amateur semi_professional professional top_division international"`
+	Recommendation   *string            `json:"recommendation" binding:"omitempty,oneof=not_recommended monitor trial sign"`
+	VideoRefs        []VideoRef         `json:"video_refs" binding:"omitempty,max=50,dive"`
+}
+
+// UpdateReportRequest creates a new version. Omitted fields carry over from
+// the current version.
+type UpdateReportRequest struct {
+	Visibility     *ReportVisibility  `json:"visibility" binding:"omitempty,oneof=private org"`
+	Ratings        map[string]float64 `json:"ratings"`
+	Strengths      *string            `json:"strengths" binding:"omitempty,max=5000"`
+	Weaknesses     *string            `json:"weaknesses" binding:"omitempty,max=5000"`
+	ProjectedLevel *string            `json:"projected_level" binding:"omitempty,oneof=amateur semi_professional professional top_division international"`
+	Recommendation *string            `json:"recommendation" binding:"omitempty,oneof=not_recommended monitor trial sign"`
+	VideoRefs      []VideoRef         `json:"video_refs" binding:"omitempty,max=50,dive"`
+}
+
+type ReportTemplateRequest struct {
+	Name       string            `json:"name" binding:"required,min=1,max=100"`
+	ScaleMax   int               `json:"scale_max" binding:"required,min=3,max=100"`
+	Attributes []ReportAttribute `json:"attributes" binding:"required,min=1,max=50"`
+}
+
+// AttributeAggregate summarises one attribute across reports, normalised to
+// the 0-10 scale.
+type AttributeAggregate struct {
+	Key   string  `json:"key"`
+	Label string  `json:"label"`
+	Mean  float64 `json:"mean"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// ScoutingSummary aggregates the current version of every report on a
+// player that the viewer can read.
+type ScoutingSummary struct {
+	PlayerID        string                `json:"player_id"`
+	ReportCount     int                   `json:"report_count"`
+	ScoutCount      int                   `json:"scout_count"`
+	Attributes      []*AttributeAggregate `json:"attributes"`
+	Recommendations map[string]int        `json:"recommendations"`
+	ProjectedLevels map[string]int        `json:"projected_levels"`
+}
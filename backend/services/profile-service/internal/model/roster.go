@@ -0,0 +1,125 @@
+package model
+
+import (
+	"time"
+)
+
+type RosterImportStatus string
+type RosterRowStatus string
+
+const (
+	RosterImportPending   RosterImportStatus = "pending"
+	RosterImportRunning   RosterImportStatus = "running"
+	RosterImportPaused    RosterImportStatus = "paused"
+	RosterImportCompleted RosterImportStatus = "completed"
+	RosterImportCancelled RosterImportStatus = "cancelled"
+
+	RosterRowPending  RosterRowStatus = "pending"
+	RosterRowValid    RosterRowStatus = "valid"
+	RosterRowInvalid  RosterRowStatus = "invalid"
+	RosterRowImported RosterRowStatus = "imported"
+	RosterRowSkipped  RosterRowStatus = "skipped"
+
+	// EventRosterPlayerInvited is emitted when an import creates an
+	// invite-pending account, so the invite can be delivered.
+	EventRosterPlayerInvited = "profile.roster.player_invited"
+)
+
+// Roster import fields. Each maps to a CreateProfileRequest or
+// CreatePlayerDetailsRequest field, apart from email which identifies the
+// account to invite.
+const (
+	RosterFieldEmail           = "email"
+	RosterFieldDisplayName     = "display_name"
+	RosterFieldBio             = "bio"
+	RosterFieldLocationCountry = "location_country"
+	RosterFieldLocationCity    = "location_city"
+	RosterFieldPosition        = "position"
+	RosterFieldDateOfBirth     = "date_of_birth"
+	RosterFieldHeightCM        = "height_cm"
+	RosterFieldWeightKG        = "weight_kg"
+	RosterFieldPreferredFoot   = "preferred_foot"
+	RosterFieldCurrentTeam     = "current_team"
+)
+
+// RosterImportField describes a column that can be mapped on import.
+type RosterImportField struct {
+	Name     string   `json:"name"`
+	Required bool     `json:"required"`
+	Aliases  []string `json:"aliases,omitempty"`
+}
+
+// RosterImportFields lists the importable fields in template column order.
+// Headers are matched case-insensitively against the name and aliases when
+// no explicit mapping is given.
+var RosterImportFields = []RosterImportField{
+	{Name: RosterFieldEmail, Required: true, Aliases: []string{"e-mail", "email address"}},
+	{Name: RosterFieldDisplayName, Required: true, Aliases: []string{"name", "full name", "player", "player name"}},
+	{Name: RosterFieldPosition, Required: true, Aliases: []string{"pos"}},
+	{Name: RosterFieldDateOfBirth, Aliases: []string{"dob", "birth date", "birthdate", "date of birth"}},
+	{Name: RosterFieldHeightCM, Aliases: []string{"height", "height (cm)"}},
+	{Name: RosterFieldWeightKG, Aliases: []string{"weight", "weight (kg)"}},
+	{Name: RosterFieldPreferredFoot, Aliases: []string{"foot", "preferred foot"}},
+	{Name: RosterFieldCurrentTeam, Aliases: []string{"team", "club", "current team"}},
+	{Name: RosterFieldLocationCountry, Aliases: []string{"country"}},
+	{Name: RosterFieldLocationCity, Aliases: []string{"city"}},
+	{Name: RosterFieldBio, Aliases: []string{"about"}},
+}
+
+// RosterImport is a bulk player import job owned by an academy.
+type RosterImport struct {
+	ID               string             `json:"id" db:"id"`
+	AcademyProfileID string             `json:"academy_profile_id" db:"academy_profile_id"`
+	Filename         string             `json:"filename" db:"filename"`
+	Format           string             `json:"format" db:"format"`
+	DryRun           bool               `json:"dry_run" db:"dry_run"`
+	Mapping          map[string]string  `json:"mapping" db:"mapping"` // field -> column header
+	Status           RosterImportStatus `json:"status" db:"status"`
+	Progress         RosterProgress     `json:"progress" db:"-"`
+	LastError        *string            `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt        time.Time          `json:"created_at" db:"created_at"`
+	StartedAt        *time.Time         `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt      *time.Time         `json:"completed_at,omitempty" db:"completed_at"`
+	UpdatedAt        time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// RosterProgress counts rows by outcome. Valid rows passed a dry run;
+// imported rows created a player profile.
+type RosterProgress struct {
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	Valid     int `json:"valid"`
+	Invalid   int `json:"invalid"`
+	Imported  int `json:"imported"`
+	Skipped   int `json:"skipped"`
+	Percent   int `json:"percent"`
+}
+
+type RosterImportRow struct {
+	ImportID    string            `json:"import_id" db:"import_id"`
+	RowNumber   int               `json:"row_number" db:"row_number"`
+	Data        map[string]string `json:"data" db:"data"`
+	Status      RosterRowStatus   `json:"status" db:"status"`
+	Errors      []string          `json:"errors" db:"errors"`
+	UserID      *string           `json:"user_id,omitempty" db:"user_id"`
+	ProfileID   *string           `json:"profile_id,omitempty" db:"profile_id"`
+	ProcessedAt *time.Time        `json:"processed_at,omitempty" db:"processed_at"`
+}
+
+// CreateRosterImportRequest holds the non-file parts of the multipart
+// upload. Mapping overrides header auto-detection, field -> column header.
+type CreateRosterImportRequest struct {
+	DryRun  bool
+	Mapping map[string]string
+}
+
+// RosterInvite is the payload of EventRosterPlayerInvited.
+type RosterInvite struct {
+	Email            string     `json:"email"`
+	UserID           string     `json:"user_id"`
+	ProfileID        string     `json:"profile_id"`
+	AcademyProfileID string     `json:"academy_profile_id"`
+	AcademyName      string     `json:"academy_name"`
+	InviteURL        string     `json:"invite_url"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+}
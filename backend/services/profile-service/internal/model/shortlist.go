@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+)
+
+type ShortlistStatus string
+
+const (
+	ShortlistStatusWatching     ShortlistStatus = "watching"
+	ShortlistStatusContacted    ShortlistStatus = "contacted"
+	ShortlistStatusTrialInvited ShortlistStatus = "trial_invited"
+	ShortlistStatusSigned       ShortlistStatus = "signed"
+	ShortlistStatusRejected     ShortlistStatus = "rejected"
+)
+
+// Shortlist is a scout's or academy's private list of players. Shortlists
+// are never exposed to the players on them.
+type Shortlist struct {
+	ID               string            `json:"id" db:"id"`
+	OwnerProfileID   string            `json:"owner_profile_id" db:"owner_profile_id"`
+	AcademyProfileID *string           `json:"academy_profile_id,omitempty" db:"academy_profile_id"`
+	Name             string            `json:"name" db:"name"`
+	Description      *string           `json:"description,omitempty" db:"description"`
+	EntryCount       int               `json:"entry_count" db:"-"`
+	Entries          []*ShortlistEntry `json:"entries,omitempty" db:"-"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+type ShortlistEntry struct {
+	ID          string           `json:"id" db:"id"`
+	ShortlistID string           `json:"shortlist_id" db:"shortlist_id"`
+	PlayerID    string           `json:"player_id" db:"player_profile_id"`
+	Player      *ShortlistPlayer `json:"player,omitempty" db:"-"`
+	SortOrder   int              `json:"sort_order" db:"sort_order"`
+	Status      ShortlistStatus  `json:"status" db:"status"`
+	Notes       *string          `json:"notes,omitempty" db:"notes"`
+	Tags        []string         `json:"tags" db:"tags"`
+	AddedBy     *string          `json:"added_by,omitempty" db:"added_by"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// ShortlistPlayer is the player summary shown on a shortlist entry.
+type ShortlistPlayer struct {
+	ProfileSummary
+	Position        *string  `json:"position,omitempty"`
+	OverallScore    *float64 `json:"overall_score,omitempty"`
+	LocationCountry *string  `json:"location_country,omitempty"`
+}
+
+type AcademyStaffMember struct {
+	Profile ProfileSummary `json:"profile"`
+	AddedAt time.Time      `json:"added_at"`
+}
+
+type CreateShortlistRequest struct {
+	Name        string  `json:"name" binding:"required,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+	// AcademyProfileID shares the list with an academy the caller belongs to
+	AcademyProfileID *string `json:"academy_profile_id" binding:"omitempty,uuid"`
+}
+
+type UpdateShortlistRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+}
+
+type AddShortlistEntryRequest struct {
+	PlayerID string           `json:"player_id" binding:"required,uuid"`
+	Status   *ShortlistStatus `json:"status" binding:"omitempty,oneof=watching contacted trial_invited signed rejected"`
+	Notes    *string          `json:"notes" binding:"omitempty,max=5000"`
+	Tags     []string         `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+type UpdateShortlistEntryRequest struct {
+	Status *ShortlistStatus `json:"status" binding:"omitempty,oneof=watching contacted trial_invited signed rejected"`
+	Notes  *string          `json:"notes" binding:"omitempty,max=5000"`
+	Tags   []string         `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// ReorderShortlistRequest lists every entry ID in the new order.
+type ReorderShortlistRequest struct {
+	EntryIDs []string `json:"entry_ids" binding:"required,dive,uuid"`
+}
+
+type AddStaffRequest struct {
+	ProfileID string `json:"profile_id" binding:"required,uuid"`
+}
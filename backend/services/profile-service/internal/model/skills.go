@@ -0,0 +1,148 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+type SkillCategory string
+type AssessmentSource string
+
+const (
+	SkillPace        SkillCategory = "pace"
+	SkillPassing     SkillCategory = "passing"
+	SkillDribbling   SkillCategory = "dribbling"
+	SkillShooting    SkillCategory = "shooting"
+	SkillDefending   SkillCategory = "defending"
+	SkillPhysical    SkillCategory = "physical"
+	SkillGoalkeeping SkillCategory = "goalkeeping"
+
+	AssessmentSourceAI    AssessmentSource = "ai"
+	AssessmentSourceCoach AssessmentSource = "coach"
+
+	// MaxSkillScore is the top of the 0-10 scale used for every skill and for
+	// overall_score (DECIMAL(3,1) in player_details).
+	MaxSkillScore = 10.0
+)
+
+// SkillTaxonomy lists the sub-skills that can be scored under each category.
+var SkillTaxonomy = map[SkillCategory][]string{
+	SkillPace:        {"acceleration", "sprint_speed"},
+	SkillPassing:     {"short_passing", "long_passing", "vision", "crossing"},
+	SkillDribbling:   {"ball_control", "agility", "balance", "close_control"},
+	SkillShooting:    {"finishing", "shot_power", "long_shots", "positioning"},
+	SkillDefending:   {"marking", "standing_tackle", "sliding_tackle", "interceptions", "heading"},
+	SkillPhysical:    {"stamina", "strength", "jumping", "aggression"},
+	SkillGoalkeeping: {"diving", "handling", "kicking", "reflexes", "positioning"},
+}
+
+// PositionSkillWeights weights each category's contribution to overall_score
+// for a position. Weights per position sum to 1.
+var PositionSkillWeights = map[string]map[SkillCategory]float64{
+	"goalkeeper": {
+		SkillGoalkeeping: 0.60,
+		SkillPassing:     0.15,
+		SkillPhysical:    0.15,
+		SkillPace:        0.10,
+	},
+	"defender": {
+		SkillDefending: 0.40,
+		SkillPhysical:  0.20,
+		SkillPace:      0.15,
+		SkillPassing:   0.15,
+		SkillDribbling: 0.05,
+		SkillShooting:  0.05,
+	},
+	"midfielder": {
+		SkillPassing:   0.30,
+		SkillDribbling: 0.20,
+		SkillDefending: 0.15,
+		SkillShooting:  0.15,
+		SkillPhysical:  0.10,
+		SkillPace:      0.10,
+	},
+	"forward": {
+		SkillShooting:  0.35,
+		SkillPace:      0.20,
+		SkillDribbling: 0.20,
+		SkillPassing:   0.10,
+		SkillPhysical:  0.10,
+		SkillDefending: 0.05,
+	},
+}
+
+// ValidSkill reports whether key is a category ("passing") or a sub-skill
+// in dotted form ("passing.vision").
+func ValidSkill(key string) bool {
+	category, sub := SplitSkill(key)
+	subSkills, ok := SkillTaxonomy[category]
+	if !ok {
+		return false
+	}
+	if sub == "" {
+		return true
+	}
+	for _, s := range subSkills {
+		if s == sub {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitSkill splits a dotted skill key into its category and sub-skill.
+func SplitSkill(key string) (SkillCategory, string) {
+	category, sub, _ := strings.Cut(key, ".")
+	return SkillCategory(category), sub
+}
+
+// CategoryScore is the aggregated score for a category and its sub-skills.
+type CategoryScore struct {
+	Score     float64            `json:"score"`
+	SubSkills map[string]float64 `json:"sub_skills,omitempty"`
+}
+
+// SkillScores is the typed shape of player_details.skill_scores.
+type SkillScores map[SkillCategory]CategoryScore
+
+type SkillAssessment struct {
+	ID           string             `json:"id" db:"id"`
+	ProfileID    string             `json:"profile_id" db:"profile_id"`
+	Source       AssessmentSource   `json:"source" db:"source"`
+	AssessorID   string             `json:"assessor_id" db:"assessor_id"`
+	VideoID      *string            `json:"video_id,omitempty" db:"video_id"`
+	ModelVersion *string            `json:"model_version,omitempty" db:"model_version"`
+	Confidence   float64            `json:"confidence" db:"confidence"`
+	Notes        *string            `json:"notes,omitempty" db:"notes"`
+	Scores       map[string]float64 `json:"scores"`
+	AssessedAt   time.Time          `json:"assessed_at" db:"assessed_at"`
+	CreatedAt    time.Time          `json:"created_at" db:"created_at"`
+}
+
+type SkillHistoryPoint struct {
+	AssessmentID string           `json:"assessment_id"`
+	Source       AssessmentSource `json:"source"`
+	AssessorID   string           `json:"assessor_id"`
+	Confidence   float64          `json:"confidence"`
+	Score        float64          `json:"score"`
+	AssessedAt   time.Time        `json:"assessed_at"`
+}
+
+// SubmitAssessmentRequest is used by scoring workers on the internal API and
+// the NATS consumer. Scores are keyed by category or "category.sub_skill".
+type SubmitAssessmentRequest struct {
+	ProfileID    string             `json:"profile_id" binding:"required,uuid"`
+	WorkerID     string             `json:"worker_id" binding:"required,max=100"`
+	VideoID      *string            `json:"video_id" binding:"omitempty,uuid"`
+	ModelVersion *string            `json:"model_version" binding:"omitempty,max=50"`
+	Confidence   float64            `json:"confidence" binding:"required,gte=0.01,lte=1"`
+	Scores       map[string]float64 `json:"scores" binding:"required,min=1"`
+	AssessedAt   *time.Time         `json:"assessed_at" binding:"omitempty"`
+}
+
+type CoachAssessmentRequest struct {
+	Confidence *float64           `json:"confidence" binding:"omitempty,gte=0.01,lte=1"`
+	Scores     map[string]float64 `json:"scores" binding:"required,min=1"`
+	Notes      *string            `json:"notes" binding:"omitempty,max=2000"`
+	VideoID    *string            `json:"video_id" binding:"omitempty,uuid"`
+}
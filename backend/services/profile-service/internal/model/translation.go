@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// ProfileTranslation holds a profile's display text in a locale other than
+// its primary language. Nil fields fall back to the primary text.
+type ProfileTranslation struct {
+	ProfileID        string    `json:"profile_id" db:"profile_id"`
+	Locale           string    `json:"locale" db:"locale"`
+	DisplayName      *string   `json:"display_name,omitempty" db:"display_name"`
+	Bio              *string   `json:"bio,omitempty" db:"bio"`
+	DetectedLanguage *string   `json:"detected_language,omitempty" db:"detected_language"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type UpsertTranslationRequest struct {
+	DisplayName *string `json:"display_name" binding:"omitempty,min=2,max=100"`
+	Bio         *string `json:"bio" binding:"omitempty,max=500"`
+}
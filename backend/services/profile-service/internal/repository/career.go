@@ -0,0 +1,400 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var (
+	ErrCareerEntryNotFound  = errors.New("career entry not found")
+	ErrSeasonStatsNotFound  = errors.New("season stats not found")
+	ErrSeasonStatsDuplicate = errors.New("season stats already exist for this competition")
+)
+
+const uniqueViolation = "23505"
+
+func (r *ProfileRepository) CreateCareerEntry(ctx context.Context, entry *model.CareerEntry) error {
+	query := `
+		INSERT INTO career_entries (id, profile_id, club_name, club_profile_id, country,
+		                            start_date, end_date, is_current, confirmed_at,
+		                            confirmed_by, rejected_at, rejected_by, created_at,
+		                            updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		entry.ID,
+		entry.ProfileID,
+		entry.ClubName,
+		entry.ClubProfileID,
+		entry.Country,
+		entry.StartDate,
+		entry.EndDate,
+		entry.IsCurrent,
+		entry.ConfirmedAt,
+		entry.ConfirmedBy,
+		entry.RejectedAt,
+		entry.RejectedBy,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create career entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetCareerEntry(ctx context.Context, id string) (*model.CareerEntry, error) {
+	query := `
+		SELECT id, profile_id, club_name, club_profile_id, country, start_date, end_date,
+		       is_current, confirmed_at, confirmed_by, rejected_at, rejected_by,
+		       created_at, updated_at
+		FROM career_entries
+		WHERE id = $1
+	`
+
+	var entry model.CareerEntry
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&entry.ID,
+		&entry.ProfileID,
+		&entry.ClubName,
+		&entry.ClubProfileID,
+		&entry.Country,
+		&entry.StartDate,
+		&entry.EndDate,
+		&entry.IsCurrent,
+		&entry.ConfirmedAt,
+		&entry.ConfirmedBy,
+		&entry.RejectedAt,
+		&entry.RejectedBy,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCareerEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get career entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+func (r *ProfileRepository) UpdateCareerEntry(ctx context.Context, entry *model.CareerEntry) error {
+	query := `
+		UPDATE career_entries
+		SET club_name = $1, club_profile_id = $2, country = $3, start_date = $4,
+		    end_date = $5, is_current = $6, confirmed_at = $7, confirmed_by = $8,
+		    rejected_at = $9, rejected_by = $10, updated_at = $11
+		WHERE id = $12
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		entry.ClubName,
+		entry.ClubProfileID,
+		entry.Country,
+		entry.StartDate,
+		entry.EndDate,
+		entry.IsCurrent,
+		entry.ConfirmedAt,
+		entry.ConfirmedBy,
+		entry.RejectedAt,
+		entry.RejectedBy,
+		entry.UpdatedAt,
+		entry.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update career entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCareerEntryNotFound
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) DeleteCareerEntry(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM career_entries WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete career entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCareerEntryNotFound
+	}
+
+	return nil
+}
+
+// ListCareerEntries returns a player's career, most recent spell first, with
+// each entry's season stats attached.
+func (r *ProfileRepository) ListCareerEntries(ctx context.Context, profileID string) ([]*model.CareerEntry, error) {
+	query := `
+		SELECT id, profile_id, club_name, club_profile_id, country, start_date, end_date,
+		       is_current, confirmed_at, confirmed_by, rejected_at, rejected_by,
+		       created_at, updated_at
+		FROM career_entries
+		WHERE profile_id = $1
+		ORDER BY is_current DESC, start_date DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list career entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*model.CareerEntry{}
+	byID := make(map[string]*model.CareerEntry)
+	for rows.Next() {
+		var entry model.CareerEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ProfileID,
+			&entry.ClubName,
+			&entry.ClubProfileID,
+			&entry.Country,
+			&entry.StartDate,
+			&entry.EndDate,
+			&entry.IsCurrent,
+			&entry.ConfirmedAt,
+			&entry.ConfirmedBy,
+			&entry.RejectedAt,
+			&entry.RejectedBy,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan career entry: %w", err)
+		}
+		entry.Seasons = []*model.SeasonStats{}
+		entries = append(entries, &entry)
+		byID[entry.ID] = &entry
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list career entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	statsQuery := `
+		SELECT s.id, s.career_entry_id, s.season, s.competition, s.appearances,
+		       s.minutes_played, s.goals, s.assists, s.yellow_cards, s.red_cards,
+		       s.clean_sheets, s.goals_conceded, s.saves, s.created_at, s.updated_at
+		FROM season_stats s
+		JOIN career_entries e ON e.id = s.career_entry_id
+		WHERE e.profile_id = $1
+		ORDER BY s.season DESC, s.competition
+	`
+
+	statRows, err := r.db.Query(ctx, statsQuery, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list season stats: %w", err)
+	}
+	defer statRows.Close()
+
+	for statRows.Next() {
+		stats, err := scanSeasonStats(statRows)
+		if err != nil {
+			return nil, err
+		}
+		if entry, ok := byID[stats.CareerEntryID]; ok {
+			entry.Seasons = append(entry.Seasons, stats)
+		}
+	}
+	if err := statRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list season stats: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *ProfileRepository) CreateSeasonStats(ctx context.Context, stats *model.SeasonStats) error {
+	query := `
+		INSERT INTO season_stats (id, career_entry_id, season, competition, appearances,
+		                          minutes_played, goals, assists, yellow_cards, red_cards,
+		                          clean_sheets, goals_conceded, saves, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		stats.ID,
+		stats.CareerEntryID,
+		stats.Season,
+		stats.Competition,
+		stats.Appearances,
+		stats.MinutesPlayed,
+		stats.Goals,
+		stats.Assists,
+		stats.YellowCards,
+		stats.RedCards,
+		stats.CleanSheets,
+		stats.GoalsConceded,
+		stats.Saves,
+		stats.CreatedAt,
+		stats.UpdatedAt,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrSeasonStatsDuplicate
+		}
+		return fmt.Errorf("failed to create season stats: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetSeasonStats(ctx context.Context, id string) (*model.SeasonStats, error) {
+	query := `
+		SELECT id, career_entry_id, season, competition, appearances, minutes_played,
+		       goals, assists, yellow_cards, red_cards, clean_sheets, goals_conceded,
+		       saves, created_at, updated_at
+		FROM season_stats
+		WHERE id = $1
+	`
+
+	stats, err := scanSeasonStats(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSeasonStatsNotFound
+		}
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *ProfileRepository) UpdateSeasonStats(ctx context.Context, stats *model.SeasonStats) error {
+	query := `
+		UPDATE season_stats
+		SET season = $1, competition = $2, appearances = $3, minutes_played = $4,
+		    goals = $5, assists = $6, yellow_cards = $7, red_cards = $8,
+		    clean_sheets = $9, goals_conceded = $10, saves = $11, updated_at = $12
+		WHERE id = $13
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		stats.Season,
+		stats.Competition,
+		stats.Appearances,
+		stats.MinutesPlayed,
+		stats.Goals,
+		stats.Assists,
+		stats.YellowCards,
+		stats.RedCards,
+		stats.CleanSheets,
+		stats.GoalsConceded,
+		stats.Saves,
+		stats.UpdatedAt,
+		stats.ID,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrSeasonStatsDuplicate
+		}
+		return fmt.Errorf("failed to update season stats: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSeasonStatsNotFound
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) DeleteSeasonStats(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM season_stats WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete season stats: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSeasonStatsNotFound
+	}
+
+	return nil
+}
+
+// GetCareerTotals sums season stats across every career entry of a player.
+// Goalkeeper totals are returned as NULL when no season recorded them.
+func (r *ProfileRepository) GetCareerTotals(ctx context.Context, profileID string) (*model.CareerTotals, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(DISTINCT COALESCE(club_profile_id::text, lower(club_name)))
+			   FROM career_entries WHERE profile_id = $1),
+			COUNT(DISTINCT s.season),
+			COALESCE(SUM(s.appearances), 0),
+			COALESCE(SUM(s.minutes_played), 0),
+			COALESCE(SUM(s.goals), 0),
+			COALESCE(SUM(s.assists), 0),
+			COALESCE(SUM(s.yellow_cards), 0),
+			COALESCE(SUM(s.red_cards), 0),
+			SUM(s.clean_sheets),
+			SUM(s.goals_conceded),
+			SUM(s.saves)
+		FROM career_entries e
+		LEFT JOIN season_stats s ON s.career_entry_id = e.id
+		WHERE e.profile_id = $1
+	`
+
+	var totals model.CareerTotals
+	err := r.db.QueryRow(ctx, query, profileID).Scan(
+		&totals.Clubs,
+		&totals.Seasons,
+		&totals.Appearances,
+		&totals.MinutesPlayed,
+		&totals.Goals,
+		&totals.Assists,
+		&totals.YellowCards,
+		&totals.RedCards,
+		&totals.CleanSheets,
+		&totals.GoalsConceded,
+		&totals.Saves,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get career totals: %w", err)
+	}
+
+	return &totals, nil
+}
+
+func scanSeasonStats(row pgx.Row) (*model.SeasonStats, error) {
+	var stats model.SeasonStats
+	err := row.Scan(
+		&stats.ID,
+		&stats.CareerEntryID,
+		&stats.Season,
+		&stats.Competition,
+		&stats.Appearances,
+		&stats.MinutesPlayed,
+		&stats.Goals,
+		&stats.Assists,
+		&stats.YellowCards,
+		&stats.RedCards,
+		&stats.CleanSheets,
+		&stats.GoalsConceded,
+		&stats.Saves,
+		&stats.CreatedAt,
+		&stats.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan season stats: %w", err)
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// GetCompletionFacts gathers what the completion checklist needs beyond the
+// profile row in a single round trip.
+func (r *ProfileRepository) GetCompletionFacts(ctx context.Context, profile *model.Profile) (*model.CompletionFacts, error) {
+	query := `
+		SELECT
+			EXISTS (SELECT 1 FROM player_details WHERE profile_id = $1),
+			EXISTS (SELECT 1 FROM player_details
+			        WHERE profile_id = $1 AND date_of_birth IS NOT NULL AND height_cm IS NOT NULL),
+			(SELECT COUNT(*) FROM career_entries WHERE profile_id = $1),
+			(SELECT COUNT(*) FROM academy_staff WHERE academy_profile_id = $1),
+			(SELECT COUNT(*) FROM completion_signals WHERE user_id = $2 AND signal = $3)
+	`
+
+	var facts model.CompletionFacts
+	err := r.db.QueryRow(ctx, query,
+		profile.ID,
+		profile.UserID,
+		model.SignalVideoUploaded,
+	).Scan(
+		&facts.HasPlayerDetails,
+		&facts.HasPhysicals,
+		&facts.CareerEntries,
+		&facts.StaffMembers,
+		&facts.Videos,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completion facts: %w", err)
+	}
+
+	return &facts, nil
+}
+
+// UpdateCompletionScore stores a recalculated score without touching the
+// rest of the profile.
+func (r *ProfileRepository) UpdateCompletionScore(ctx context.Context, profileID string, score int) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE profiles SET profile_completion_score = $1 WHERE id = $2`,
+		score, profileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update completion score: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+// CreateCompletionSignal records a signal. It reports false when the same
+// signal was already recorded.
+func (r *ProfileRepository) CreateCompletionSignal(ctx context.Context, signal *model.CompletionSignal) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`INSERT INTO completion_signals (user_id, signal, ref_id, created_at) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT DO NOTHING`,
+		signal.UserID, signal.Signal, signal.RefID, signal.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create completion signal: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteCompletionSignal removes a signal. It reports false when there was
+// no such signal.
+func (r *ProfileRepository) DeleteCompletionSignal(ctx context.Context, userID, signal, refID string) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM completion_signals WHERE user_id = $1 AND signal = $2 AND ref_id = $3`,
+		userID, signal, refID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete completion signal: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteCompletionSignals removes every signal of one kind for a user.
+func (r *ProfileRepository) DeleteCompletionSignals(ctx context.Context, userID, signal string) error {
+	_, err := r.db.Exec(ctx,
+		`DELETE FROM completion_signals WHERE user_id = $1 AND signal = $2`,
+		userID, signal,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete completion signals: %w", err)
+	}
+	return nil
+}
+
+// ListProfilesAfter pages through all profiles ordered by ID, starting
+// after afterID.
+func (r *ProfileRepository) ListProfilesAfter(ctx context.Context, afterID string, limit int) ([]*model.Profile, error) {
+	query := `
+		SELECT ` + profileColumns + `
+		FROM profiles p
+		WHERE p.id > $1::uuid
+		ORDER BY p.id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []*model.Profile{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
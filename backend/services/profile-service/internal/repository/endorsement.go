@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var (
+	ErrEndorsementNotFound  = errors.New("endorsement not found")
+	ErrEndorsementDuplicate = errors.New("skill is already endorsed by this profile")
+)
+
+const endorsementColumns = `
+	e.id, e.player_profile_id, e.endorser_profile_id, e.skill, e.comment,
+	e.endorser_trust_level, e.status, e.responded_at, e.revoked_at,
+	e.created_at, e.updated_at,
+	p.id, p.slug, p.type, p.display_name, p.avatar_url, p.trust_level`
+
+func scanEndorsement(row pgx.Row) (*model.Endorsement, error) {
+	var e model.Endorsement
+	var endorser model.ProfileSummary
+	err := row.Scan(
+		&e.ID,
+		&e.PlayerID,
+		&e.EndorserID,
+		&e.Skill,
+		&e.Comment,
+		&e.EndorserTrustLevel,
+		&e.Status,
+		&e.RespondedAt,
+		&e.RevokedAt,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&endorser.ID,
+		&endorser.Slug,
+		&endorser.Type,
+		&endorser.DisplayName,
+		&endorser.AvatarURL,
+		&endorser.TrustLevel,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Endorser = &endorser
+	return &e, nil
+}
+
+// CreateEndorsement inserts an endorsement, or renews a revoked one for the
+// same endorser, player and skill. It returns ErrEndorsementDuplicate while
+// an unrevoked endorsement exists.
+func (r *ProfileRepository) CreateEndorsement(ctx context.Context, e *model.Endorsement) error {
+	query := `
+		INSERT INTO endorsements (id, player_profile_id, endorser_profile_id, skill, comment,
+		                          endorser_trust_level, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (endorser_profile_id, player_profile_id, skill) DO UPDATE
+		SET comment = EXCLUDED.comment,
+		    endorser_trust_level = EXCLUDED.endorser_trust_level,
+		    status = EXCLUDED.status,
+		    responded_at = NULL,
+		    revoked_at = NULL,
+		    created_at = EXCLUDED.created_at,
+		    updated_at = EXCLUDED.updated_at
+		WHERE endorsements.revoked_at IS NOT NULL
+		RETURNING id
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		e.ID,
+		e.PlayerID,
+		e.EndorserID,
+		e.Skill,
+		e.Comment,
+		e.EndorserTrustLevel,
+		e.Status,
+		e.CreatedAt,
+		e.UpdatedAt,
+	).Scan(&e.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEndorsementDuplicate
+		}
+		return fmt.Errorf("failed to create endorsement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetEndorsement(ctx context.Context, id string) (*model.Endorsement, error) {
+	query := `
+		SELECT ` + endorsementColumns + `
+		FROM endorsements e
+		JOIN profiles p ON p.id = e.endorser_profile_id
+		WHERE e.id = $1
+	`
+
+	e, err := scanEndorsement(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEndorsementNotFound
+		}
+		return nil, fmt.Errorf("failed to get endorsement: %w", err)
+	}
+
+	return e, nil
+}
+
+// ListEndorsements returns a player's unrevoked endorsements from active
+// endorsers in the given statuses, highest endorser trust level first.
+func (r *ProfileRepository) ListEndorsements(ctx context.Context, playerID string, statuses []model.EndorsementStatus) ([]*model.Endorsement, error) {
+	query := `
+		SELECT ` + endorsementColumns + `
+		FROM endorsements e
+		JOIN profiles p ON p.id = e.endorser_profile_id
+		WHERE e.player_profile_id = $1
+		  AND e.revoked_at IS NULL
+		  AND e.status = ANY($2::endorsement_status[])
+		  AND p.status = 'active'
+		ORDER BY e.endorser_trust_level DESC, e.created_at DESC, e.id
+	`
+
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	rows, err := r.db.Query(ctx, query, playerID, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endorsements: %w", err)
+	}
+	defer rows.Close()
+
+	endorsements := []*model.Endorsement{}
+	for rows.Next() {
+		e, err := scanEndorsement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan endorsement: %w", err)
+		}
+		endorsements = append(endorsements, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list endorsements: %w", err)
+	}
+
+	return endorsements, nil
+}
+
+// UpdateEndorsement stores the status, response and revocation fields.
+func (r *ProfileRepository) UpdateEndorsement(ctx context.Context, e *model.Endorsement) error {
+	query := `
+		UPDATE endorsements
+		SET status = $1, responded_at = $2, revoked_at = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		e.Status,
+		e.RespondedAt,
+		e.RevokedAt,
+		e.UpdatedAt,
+		e.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update endorsement: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEndorsementNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// CreateFollow inserts a follow edge and bumps both counters. It reports
+// false without changing anything when the edge already exists.
+func (r *ProfileRepository) CreateFollow(ctx context.Context, follow *model.Follow) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`INSERT INTO profile_follows (follower_id, followee_id, created_at) VALUES ($1, $2, $3)
+		 ON CONFLICT DO NOTHING`,
+		follow.FollowerID, follow.FolloweeID, follow.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create follow: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := r.adjustFollowCounts(ctx, follow.FollowerID, follow.FolloweeID, 1); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteFollow removes a follow edge and decrements both counters. It
+// reports false when there was no such edge.
+func (r *ProfileRepository) DeleteFollow(ctx context.Context, followerID, followeeID string) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM profile_follows WHERE follower_id = $1 AND followee_id = $2`,
+		followerID, followeeID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete follow: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := r.adjustFollowCounts(ctx, followerID, followeeID, -1); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// adjustFollowCounts updates the two profiles in id order so concurrent
+// follows in opposite directions cannot deadlock.
+func (r *ProfileRepository) adjustFollowCounts(ctx context.Context, followerID, followeeID string, delta int) error {
+	updates := []struct {
+		id     string
+		column string
+	}{
+		{followerID, "following_count"},
+		{followeeID, "follower_count"},
+	}
+	if followeeID < followerID {
+		updates[0], updates[1] = updates[1], updates[0]
+	}
+
+	for _, u := range updates {
+		query := fmt.Sprintf(`UPDATE profiles SET %[1]s = GREATEST(%[1]s + $1, 0) WHERE id = $2`, u.column)
+		if _, err := r.db.Exec(ctx, query, delta, u.id); err != nil {
+			return fmt.Errorf("failed to update follow counts: %w", err)
+		}
+	}
+	return nil
+}
+
+// detachFollows decrements the counters of every profile connected to id
+// before its edges are removed.
+func (r *ProfileRepository) detachFollows(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE profiles p SET follower_count = GREATEST(p.follower_count - 1, 0)
+		FROM profile_follows f
+		WHERE f.follower_id = $1 AND p.id = f.followee_id`, id)
+	if err != nil {
+		return fmt.Errorf("failed to detach followees: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		UPDATE profiles p SET following_count = GREATEST(p.following_count - 1, 0)
+		FROM profile_follows f
+		WHERE f.followee_id = $1 AND p.id = f.follower_id`, id)
+	if err != nil {
+		return fmt.Errorf("failed to detach followers: %w", err)
+	}
+
+	return nil
+}
+
+// IsBlockedEither reports whether either profile has blocked the other.
+func (r *ProfileRepository) IsBlockedEither(ctx context.Context, a, b string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM profile_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	var blocked bool
+	if err := r.db.QueryRow(ctx, query, a, b).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	return blocked, nil
+}
+
+// IsBlockedForUser reports whether the profile of userID and profileID have
+// blocked each other in either direction. Users without a profile are never
+// blocked.
+func (r *ProfileRepository) IsBlockedForUser(ctx context.Context, userID, profileID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM profile_blocks b
+			JOIN profiles v ON v.user_id = $1
+			WHERE (b.blocker_id = v.id AND b.blocked_id = $2) OR (b.blocker_id = $2 AND b.blocked_id = v.id)
+		)
+	`
+
+	var blocked bool
+	if err := r.db.QueryRow(ctx, query, userID, profileID).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	return blocked, nil
+}
+
+// LockProfilePair serialises follow and block changes between two profiles
+// until the end of the transaction, so a follow cannot slip in while the
+// pair is being blocked.
+func (r *ProfileRepository) LockProfilePair(ctx context.Context, a, b string) error {
+	if b < a {
+		a, b = b, a
+	}
+	if _, err := r.db.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, a+":"+b); err != nil {
+		return fmt.Errorf("failed to lock profile pair: %w", err)
+	}
+	return nil
+}
+
+// CreateBlock records the block and reports false if it already existed.
+func (r *ProfileRepository) CreateBlock(ctx context.Context, blockerID, blockedID string, now time.Time) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`INSERT INTO profile_blocks (blocker_id, blocked_id, created_at) VALUES ($1, $2, $3)
+		 ON CONFLICT DO NOTHING`,
+		blockerID, blockedID, now,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create block: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *ProfileRepository) DeleteBlock(ctx context.Context, blockerID, blockedID string) error {
+	_, err := r.db.Exec(ctx,
+		`DELETE FROM profile_blocks WHERE blocker_id = $1 AND blocked_id = $2`,
+		blockerID, blockedID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete block: %w", err)
+	}
+	return nil
+}
+
+func (r *ProfileRepository) CreateMute(ctx context.Context, muterID, mutedID string, now time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO profile_mutes (muter_id, muted_id, created_at) VALUES ($1, $2, $3)
+		 ON CONFLICT DO NOTHING`,
+		muterID, mutedID, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create mute: %w", err)
+	}
+	return nil
+}
+
+func (r *ProfileRepository) DeleteMute(ctx context.Context, muterID, mutedID string) error {
+	_, err := r.db.Exec(ctx,
+		`DELETE FROM profile_mutes WHERE muter_id = $1 AND muted_id = $2`,
+		muterID, mutedID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete mute: %w", err)
+	}
+	return nil
+}
+
+func (r *ProfileRepository) GetRelationship(ctx context.Context, fromID, toID string) (*model.Relationship, error) {
+	query := `
+		SELECT
+			EXISTS (SELECT 1 FROM profile_follows WHERE follower_id = $1 AND followee_id = $2),
+			EXISTS (SELECT 1 FROM profile_follows WHERE follower_id = $2 AND followee_id = $1),
+			EXISTS (SELECT 1 FROM profile_blocks WHERE blocker_id = $1 AND blocked_id = $2),
+			EXISTS (SELECT 1 FROM profile_mutes WHERE muter_id = $1 AND muted_id = $2)
+	`
+
+	var rel model.Relationship
+	err := r.db.QueryRow(ctx, query, fromID, toID).Scan(
+		&rel.Following,
+		&rel.FollowedBy,
+		&rel.Blocking,
+		&rel.Muting,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship: %w", err)
+	}
+	return &rel, nil
+}
+
+// connectionQuery describes one list: the edge table, the column holding
+// the list owner and the column holding the listed profile.
+type connectionQuery struct {
+	table       string
+	ownerColumn string
+	otherColumn string
+}
+
+var (
+	followersList = connectionQuery{"profile_follows", "followee_id", "follower_id"}
+	followingList = connectionQuery{"profile_follows", "follower_id", "followee_id"}
+	blocksList    = connectionQuery{"profile_blocks", "blocker_id", "blocked_id"}
+	mutesList     = connectionQuery{"profile_mutes", "muter_id", "muted_id"}
+)
+
+// ListFollowers and ListFollowing leave out profiles outside the viewer's
+// audiences and profiles blocked with viewerUserID in either direction.
+func (r *ProfileRepository) ListFollowers(ctx context.Context, profileID string, audiences []string, viewerUserID string, cursor *model.ConnectionCursor, limit int) ([]*model.Connection, error) {
+	return r.listConnections(ctx, followersList, profileID, audiences, viewerUserID, cursor, limit)
+}
+
+func (r *ProfileRepository) ListFollowing(ctx context.Context, profileID string, audiences []string, viewerUserID string, cursor *model.ConnectionCursor, limit int) ([]*model.Connection, error) {
+	return r.listConnections(ctx, followingList, profileID, audiences, viewerUserID, cursor, limit)
+}
+
+// ListBlocks and ListMutes are only shown to the owner, so every audience
+// is included.
+func (r *ProfileRepository) ListBlocks(ctx context.Context, profileID string, cursor *model.ConnectionCursor, limit int) ([]*model.Connection, error) {
+	return r.listConnections(ctx, blocksList, profileID, nil, "", cursor, limit)
+}
+
+func (r *ProfileRepository) ListMutes(ctx context.Context, profileID string, cursor *model.ConnectionCursor, limit int) ([]*model.Connection, error) {
+	return r.listConnections(ctx, mutesList, profileID, nil, "", cursor, limit)
+}
+
+// listConnections pages through one edge list newest first using keyset
+// pagination, so deep pages cost the same as the first. Inactive profiles
+// are always left out; a nil audiences slice disables the visibility filter
+// and an empty viewerUserID the block filter.
+func (r *ProfileRepository) listConnections(ctx context.Context, q connectionQuery, profileID string, audiences []string, viewerUserID string, cursor *model.ConnectionCursor, limit int) ([]*model.Connection, error) {
+	query := fmt.Sprintf(`
+		SELECT p.id, p.slug, p.type, p.display_name, p.avatar_url, p.trust_level, e.created_at
+		FROM %[1]s e
+		JOIN profiles p ON p.id = e.%[3]s
+		WHERE e.%[2]s = $1
+		  AND p.status = 'active'
+		  AND ($2::text[] IS NULL OR p.visibility::text = ANY($2))
+		  AND ($3::timestamp IS NULL OR (e.created_at, e.%[3]s) < ($3, $4::uuid))
+		  AND NOT EXISTS (
+		      SELECT 1 FROM profile_blocks b
+		      JOIN profiles v ON v.user_id = NULLIF($6::text, '')::uuid
+		      WHERE (b.blocker_id = v.id AND b.blocked_id = p.id) OR (b.blocker_id = p.id AND b.blocked_id = v.id)
+		  )
+		ORDER BY e.created_at DESC, e.%[3]s DESC
+		LIMIT $5
+	`, q.table, q.ownerColumn, q.otherColumn)
+
+	var since *time.Time
+	var afterID *string
+	if cursor != nil {
+		since = &cursor.Since
+		afterID = &cursor.ProfileID
+	}
+
+	rows, err := r.db.Query(ctx, query, profileID, audiences, since, afterID, limit, viewerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	defer rows.Close()
+
+	connections := []*model.Connection{}
+	for rows.Next() {
+		var c model.Connection
+		if err := rows.Scan(
+			&c.Profile.ID,
+			&c.Profile.Slug,
+			&c.Profile.Type,
+			&c.Profile.DisplayName,
+			&c.Profile.AvatarURL,
+			&c.Profile.TrustLevel,
+			&c.Since,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+		connections = append(connections, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	return connections, nil
+}
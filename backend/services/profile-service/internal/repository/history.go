@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// CreateProfileChange appends an entry to the profile change log.
+func (r *ProfileRepository) CreateProfileChange(ctx context.Context, change *model.ProfileChange) error {
+	changes, err := json.Marshal(change.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile changes: %w", err)
+	}
+
+	query := `
+		INSERT INTO profile_changes (id, profile_id, action, actor_user_id, actor_role, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = r.db.Exec(ctx, query,
+		change.ID,
+		change.ProfileID,
+		change.Action,
+		change.ActorUserID,
+		change.ActorRole,
+		changes,
+		change.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create profile change: %w", err)
+	}
+
+	return nil
+}
+
+// AnonymizeProfileChanges strips a purged profile's change log down to what
+// was changed, when and by whom. Diff values are dropped except for the
+// fields in keepValues, and the owner's own user ID is cleared from the
+// entries they made.
+func (r *ProfileRepository) AnonymizeProfileChanges(ctx context.Context, profileID, ownerUserID string, keepValues []string, now time.Time) error {
+	query := `
+		UPDATE profile_changes
+		SET changes = (
+		        SELECT COALESCE(jsonb_agg(
+		            CASE WHEN c->>'field' = ANY($3) THEN c ELSE jsonb_build_object('field', c->'field') END
+		            ORDER BY ord), '[]'::jsonb)
+		        FROM jsonb_array_elements(changes) WITH ORDINALITY AS e(c, ord)
+		    ),
+		    actor_user_id = NULLIF(actor_user_id, $2::uuid),
+		    anonymized_at = $4
+		WHERE profile_id = $1 AND anonymized_at IS NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, profileID, ownerUserID, keepValues, now); err != nil {
+		return fmt.Errorf("failed to anonymize profile changes: %w", err)
+	}
+
+	return nil
+}
+
+// ListProfileChanges pages through a profile's change log newest first. A
+// non-empty field limits it to entries that changed that field.
+func (r *ProfileRepository) ListProfileChanges(ctx context.Context, profileID, field string, cursor *model.ChangeCursor, limit int) ([]*model.ProfileChange, error) {
+	query := `
+		SELECT id, profile_id, action, actor_user_id, actor_role, changes, created_at
+		FROM profile_changes
+		WHERE profile_id = $1
+		  AND ($2 = '' OR changes @> jsonb_build_array(jsonb_build_object('field', $2::text)))
+		  AND ($3::timestamp IS NULL OR (created_at, id) < ($3, $4::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5
+	`
+
+	var createdAt *time.Time
+	var afterID *string
+	if cursor != nil {
+		createdAt = &cursor.CreatedAt
+		afterID = &cursor.ID
+	}
+
+	rows, err := r.db.Query(ctx, query, profileID, field, createdAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*model.ProfileChange{}
+	for rows.Next() {
+		var change model.ProfileChange
+		var raw []byte
+		if err := rows.Scan(
+			&change.ID,
+			&change.ProfileID,
+			&change.Action,
+			&change.ActorUserID,
+			&change.ActorRole,
+			&raw,
+			&change.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan profile change: %w", err)
+		}
+		if err := json.Unmarshal(raw, &change.Changes); err != nil {
+			return nil, fmt.Errorf("failed to decode profile changes: %w", err)
+		}
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list profile changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// UpdatePlayerDetails stores the editable player details and their
+// verification state.
+func (r *ProfileRepository) UpdatePlayerDetails(ctx context.Context, details *model.PlayerDetails) error {
+	query := `
+		UPDATE player_details
+		SET position = $1, date_of_birth = $2, height_cm = $3, weight_kg = $4,
+		    preferred_foot = $5, current_team = $6, verified_at = $7, verified_by = $8
+		WHERE profile_id = $9
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		details.Position,
+		details.DateOfBirth,
+		details.HeightCM,
+		details.WeightKG,
+		details.PreferredFoot,
+		details.CurrentTeam,
+		details.VerifiedAt,
+		details.VerifiedBy,
+		details.ProfileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update player details: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// UpdateStatus stores the profile's lifecycle status and the fields that
+// describe it.
+func (r *ProfileRepository) UpdateStatus(ctx context.Context, profile *model.Profile) error {
+	query := `
+		UPDATE profiles
+		SET status = $1, deactivated_at = $2, deactivated_by = $3,
+		    deactivation_reason = $4, purge_after = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		profile.Status,
+		profile.DeactivatedAt,
+		profile.DeactivatedBy,
+		profile.DeactivationReason,
+		profile.PurgeAfter,
+		profile.UpdatedAt,
+		profile.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update profile status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileNotFound
+	}
+
+	return nil
+}
+
+// ClaimPurgeableProfiles locks deactivated profiles whose grace period ended
+// before now. Rows locked by another instance are skipped, so repo must be
+// bound to the transaction that deletes them.
+func (r *ProfileRepository) ClaimPurgeableProfiles(ctx context.Context, now time.Time, limit int) ([]*model.Profile, error) {
+	query := `
+		SELECT ` + profileColumns + `
+		FROM profiles p
+		WHERE p.status = 'deactivated' AND p.purge_after <= $1
+		ORDER BY p.purge_after
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim purgeable profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []*model.Profile{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim purgeable profiles: %w", err)
+	}
+
+	return profiles, nil
+}
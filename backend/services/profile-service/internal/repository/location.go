@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// ListUnresolvedLocations returns profiles with a free-text country but no
+// country code, ordered by ID and starting after afterID.
+func (r *ProfileRepository) ListUnresolvedLocations(ctx context.Context, afterID string, limit int) ([]*model.Profile, error) {
+	query := `
+		SELECT ` + profileColumns + `
+		FROM profiles p
+		WHERE p.location_country_code IS NULL
+		  AND (p.location_country IS NOT NULL OR p.location_city IS NOT NULL)
+		  AND p.id > $1::uuid
+		ORDER BY p.id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unresolved locations: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []*model.Profile{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+// UpdateLocation stores the normalized location fields without touching
+// updated_at; normalization is not an edit by the owner.
+func (r *ProfileRepository) UpdateLocation(ctx context.Context, profile *model.Profile) error {
+	query := `
+		UPDATE profiles
+		SET location_country = $1, location_city = $2, location_country_code = $3,
+		    location_city_id = $4, location_lat = $5, location_lng = $6
+		WHERE id = $7
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		profile.LocationCountry,
+		profile.LocationCity,
+		profile.LocationCountryCode,
+		profile.LocationCityID,
+		profile.LocationLat,
+		profile.LocationLng,
+		profile.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update profile location: %w", err)
+	}
+
+	return nil
+}
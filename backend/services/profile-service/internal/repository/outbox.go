@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+// EnqueueEvent writes an event to the outbox. Call it on a transaction-bound
+// repository (see InTx) so the event commits or rolls back with the change.
+func (r *ProfileRepository) EnqueueEvent(ctx context.Context, event *model.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox_events (event_id, subject, aggregate_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err = r.db.Exec(ctx, query, event.ID, event.Type, event.ProfileID, payload, event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue event: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxEvents locks up to limit unpublished events in insertion order.
+// Rows locked by another relay instance and events set aside as failed are
+// skipped.
+func (r *ProfileRepository) ClaimOutboxEvents(ctx context.Context, limit int) ([]*model.OutboxEvent, error) {
+	query := `
+		SELECT id, event_id, subject, payload, attempts
+		FROM outbox_events
+		WHERE published_at IS NULL AND failed_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*model.OutboxEvent{}
+	for rows.Next() {
+		var e model.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.EventID, &e.Subject, &e.Payload, &e.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *ProfileRepository) MarkOutboxEventsPublished(ctx context.Context, ids []int64, publishedAt time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE outbox_events SET published_at = $1, last_error = NULL WHERE id = ANY($2)`,
+		publishedAt, ids,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox events published: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed records a failed publish attempt. Once maxAttempts
+// is reached the event is set aside and no longer claimed. It reports
+// whether the event was set aside.
+func (r *ProfileRepository) MarkOutboxEventFailed(ctx context.Context, id int64, cause error, maxAttempts int) (bool, error) {
+	var failed bool
+	err := r.db.QueryRow(ctx, `
+		UPDATE outbox_events
+		SET attempts = attempts + 1, last_error = $1,
+			failed_at = CASE WHEN attempts + 1 >= $3 THEN NOW() END
+		WHERE id = $2
+		RETURNING failed_at IS NOT NULL
+	`, cause.Error(), id, maxAttempts).Scan(&failed)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return failed, nil
+}
+
+// PurgePublishedEvents deletes events published before the given time.
+func (r *ProfileRepository) PurgePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM outbox_events WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/scouttalent/profile-service/internal/model"
 )
@@ -15,33 +17,138 @@ var (
 	ErrProfileAlreadyExists = errors.New("profile already exists")
 )
 
+// dbtx is satisfied by both *pgxpool.Pool and pgx.Tx so repository methods
+// can run inside or outside a transaction.
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// profileColumns is the column list scanned by scanProfile. Queries must
+// alias the profiles table as p.
+const profileColumns = `
+	p.id, p.user_id, p.type, p.slug, p.display_name, p.bio, p.avatar_url,
+	p.location_country, p.location_city, p.location_country_code,
+	p.location_city_id, p.location_lat, p.location_lng,
+	p.contact_email, p.contact_phone, p.trust_level, p.profile_completion_score, p.follower_count,
+	p.following_count, p.visibility,
+	p.city_visibility, p.contact_visibility, p.date_of_birth_visibility,
+	p.status, p.deactivated_at, p.deactivated_by, p.deactivation_reason,
+	p.purge_after, p.primary_language, p.bio_language, p.created_at, p.updated_at`
+
+func profileScanTargets(profile *model.Profile) []any {
+	return []any{
+		&profile.ID,
+		&profile.UserID,
+		&profile.Type,
+		&profile.Slug,
+		&profile.DisplayName,
+		&profile.Bio,
+		&profile.AvatarURL,
+		&profile.LocationCountry,
+		&profile.LocationCity,
+		&profile.LocationCountryCode,
+		&profile.LocationCityID,
+		&profile.LocationLat,
+		&profile.LocationLng,
+		&profile.ContactEmail,
+		&profile.ContactPhone,
+		&profile.TrustLevel,
+		&profile.ProfileCompletionScore,
+		&profile.FollowerCount,
+		&profile.FollowingCount,
+		&profile.Privacy.Visibility,
+		&profile.Privacy.CityVisibility,
+		&profile.Privacy.ContactVisibility,
+		&profile.Privacy.DateOfBirthVisibility,
+		&profile.Status,
+		&profile.DeactivatedAt,
+		&profile.DeactivatedBy,
+		&profile.DeactivationReason,
+		&profile.PurgeAfter,
+		&profile.PrimaryLanguage,
+		&profile.BioLanguage,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	}
+}
+
+func scanProfile(row pgx.Row) (*model.Profile, error) {
+	var profile model.Profile
+	if err := row.Scan(profileScanTargets(&profile)...); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
 type ProfileRepository struct {
 	pool *pgxpool.Pool
+	db   dbtx
 }
 
 func NewProfileRepository(pool *pgxpool.Pool) *ProfileRepository {
-	return &ProfileRepository{pool: pool}
+	return &ProfileRepository{pool: pool, db: pool}
+}
+
+// InTx runs fn with a repository bound to a single transaction, committing
+// if fn returns nil and rolling back otherwise.
+func (r *ProfileRepository) InTx(ctx context.Context, fn func(repo *ProfileRepository) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&ProfileRepository{pool: r.pool, db: tx}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 func (r *ProfileRepository) Create(ctx context.Context, profile *model.Profile) error {
 	query := `
-		INSERT INTO profiles (id, user_id, type, display_name, bio, avatar_url, 
-		                     location_country, location_city, trust_level, 
-		                     profile_completion_score, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO profiles (id, user_id, type, slug, display_name, bio, avatar_url, 
+		                     location_country, location_city, location_country_code,
+		                     location_city_id, location_lat, location_lng,
+		                     contact_email, contact_phone,
+		                     trust_level, profile_completion_score, visibility,
+		                     city_visibility, contact_visibility, date_of_birth_visibility,
+		                     primary_language, bio_language, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+		        $20, $21, $22, $23, $24, $25)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := r.db.Exec(ctx, query,
 		profile.ID,
 		profile.UserID,
 		profile.Type,
+		profile.Slug,
 		profile.DisplayName,
 		profile.Bio,
 		profile.AvatarURL,
 		profile.LocationCountry,
 		profile.LocationCity,
+		profile.LocationCountryCode,
+		profile.LocationCityID,
+		profile.LocationLat,
+		profile.LocationLng,
+		profile.ContactEmail,
+		profile.ContactPhone,
 		profile.TrustLevel,
 		profile.ProfileCompletionScore,
+		profile.Privacy.Visibility,
+		profile.Privacy.CityVisibility,
+		profile.Privacy.ContactVisibility,
+		profile.Privacy.DateOfBirthVisibility,
+		profile.PrimaryLanguage,
+		profile.BioLanguage,
 		profile.CreatedAt,
 		profile.UpdatedAt,
 	)
@@ -54,30 +161,9 @@ func (r *ProfileRepository) Create(ctx context.Context, profile *model.Profile)
 }
 
 func (r *ProfileRepository) GetByID(ctx context.Context, id string) (*model.Profile, error) {
-	query := `
-		SELECT id, user_id, type, display_name, bio, avatar_url, 
-		       location_country, location_city, trust_level, 
-		       profile_completion_score, created_at, updated_at
-		FROM profiles
-		WHERE id = $1
-	`
-
-	var profile model.Profile
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&profile.ID,
-		&profile.UserID,
-		&profile.Type,
-		&profile.DisplayName,
-		&profile.Bio,
-		&profile.AvatarURL,
-		&profile.LocationCountry,
-		&profile.LocationCity,
-		&profile.TrustLevel,
-		&profile.ProfileCompletionScore,
-		&profile.CreatedAt,
-		&profile.UpdatedAt,
-	)
+	query := `SELECT ` + profileColumns + ` FROM profiles p WHERE p.id = $1`
 
+	profile, err := scanProfile(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrProfileNotFound
@@ -85,34 +171,13 @@ func (r *ProfileRepository) GetByID(ctx context.Context, id string) (*model.Prof
 		return nil, fmt.Errorf("failed to get profile: %w", err)
 	}
 
-	return &profile, nil
+	return profile, nil
 }
 
 func (r *ProfileRepository) GetByUserID(ctx context.Context, userID string) (*model.Profile, error) {
-	query := `
-		SELECT id, user_id, type, display_name, bio, avatar_url, 
-		       location_country, location_city, trust_level, 
-		       profile_completion_score, created_at, updated_at
-		FROM profiles
-		WHERE user_id = $1
-	`
-
-	var profile model.Profile
-	err := r.pool.QueryRow(ctx, query, userID).Scan(
-		&profile.ID,
-		&profile.UserID,
-		&profile.Type,
-		&profile.DisplayName,
-		&profile.Bio,
-		&profile.AvatarURL,
-		&profile.LocationCountry,
-		&profile.LocationCity,
-		&profile.TrustLevel,
-		&profile.ProfileCompletionScore,
-		&profile.CreatedAt,
-		&profile.UpdatedAt,
-	)
+	query := `SELECT ` + profileColumns + ` FROM profiles p WHERE p.user_id = $1`
 
+	profile, err := scanProfile(r.db.QueryRow(ctx, query, userID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrProfileNotFound
@@ -120,7 +185,7 @@ func (r *ProfileRepository) GetByUserID(ctx context.Context, userID string) (*mo
 		return nil, fmt.Errorf("failed to get profile by user ID: %w", err)
 	}
 
-	return &profile, nil
+	return profile, nil
 }
 
 func (r *ProfileRepository) Update(ctx context.Context, profile *model.Profile) error {
@@ -128,18 +193,30 @@ func (r *ProfileRepository) Update(ctx context.Context, profile *model.Profile)
 		UPDATE profiles
 		SET display_name = $1, bio = $2, avatar_url = $3, 
 		    location_country = $4, location_city = $5, 
-		    profile_completion_score = $6, updated_at = $7
-		WHERE id = $8
+		    location_country_code = $6, location_city_id = $7,
+		    location_lat = $8, location_lng = $9,
+		    contact_email = $10, contact_phone = $11,
+		    profile_completion_score = $12, updated_at = $13,
+		    primary_language = $14, bio_language = $15
+		WHERE id = $16
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := r.db.Exec(ctx, query,
 		profile.DisplayName,
 		profile.Bio,
 		profile.AvatarURL,
 		profile.LocationCountry,
 		profile.LocationCity,
+		profile.LocationCountryCode,
+		profile.LocationCityID,
+		profile.LocationLat,
+		profile.LocationLng,
+		profile.ContactEmail,
+		profile.ContactPhone,
 		profile.ProfileCompletionScore,
 		profile.UpdatedAt,
+		profile.PrimaryLanguage,
+		profile.BioLanguage,
 		profile.ID,
 	)
 
@@ -150,6 +227,24 @@ func (r *ProfileRepository) Update(ctx context.Context, profile *model.Profile)
 	return nil
 }
 
+func (r *ProfileRepository) Delete(ctx context.Context, id string) error {
+	// Edges cascade with the profile; release the counters they held on the
+	// other side first.
+	if err := r.detachFollows(ctx, id); err != nil {
+		return err
+	}
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM profiles WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileNotFound
+	}
+
+	return nil
+}
+
 func (r *ProfileRepository) CreatePlayerDetails(ctx context.Context, details *model.PlayerDetails) error {
 	query := `
 		INSERT INTO player_details (profile_id, position, date_of_birth, height_cm, 
@@ -157,7 +252,7 @@ func (r *ProfileRepository) CreatePlayerDetails(ctx context.Context, details *mo
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := r.db.Exec(ctx, query,
 		details.ProfileID,
 		details.Position,
 		details.DateOfBirth,
@@ -176,32 +271,17 @@ func (r *ProfileRepository) CreatePlayerDetails(ctx context.Context, details *mo
 
 func (r *ProfileRepository) GetPlayerProfile(ctx context.Context, profileID string) (*model.PlayerProfile, error) {
 	query := `
-		SELECT 
-			p.id, p.user_id, p.type, p.display_name, p.bio, p.avatar_url,
-			p.location_country, p.location_city, p.trust_level,
-			p.profile_completion_score, p.created_at, p.updated_at,
+		SELECT ` + profileColumns + `,
 			pd.profile_id, pd.position, pd.date_of_birth, pd.height_cm,
 			pd.weight_kg, pd.preferred_foot, pd.current_team, pd.skill_scores,
-			pd.overall_score, pd.last_scored_at
+			pd.overall_score, pd.last_scored_at, pd.verified_at, pd.verified_by
 		FROM profiles p
 		JOIN player_details pd ON pd.profile_id = p.id
 		WHERE p.id = $1 AND p.type = 'player'
 	`
 
 	var player model.PlayerProfile
-	err := r.pool.QueryRow(ctx, query, profileID).Scan(
-		&player.ID,
-		&player.UserID,
-		&player.Type,
-		&player.DisplayName,
-		&player.Bio,
-		&player.AvatarURL,
-		&player.LocationCountry,
-		&player.LocationCity,
-		&player.TrustLevel,
-		&player.ProfileCompletionScore,
-		&player.CreatedAt,
-		&player.UpdatedAt,
+	targets := append(profileScanTargets(&player.Profile),
 		&player.PlayerDetails.ProfileID,
 		&player.PlayerDetails.Position,
 		&player.PlayerDetails.DateOfBirth,
@@ -212,7 +292,10 @@ func (r *ProfileRepository) GetPlayerProfile(ctx context.Context, profileID stri
 		&player.PlayerDetails.SkillScores,
 		&player.PlayerDetails.OverallScore,
 		&player.PlayerDetails.LastScoredAt,
+		&player.PlayerDetails.VerifiedAt,
+		&player.PlayerDetails.VerifiedBy,
 	)
+	err := r.db.QueryRow(ctx, query, profileID).Scan(targets...)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -224,36 +307,30 @@ func (r *ProfileRepository) GetPlayerProfile(ctx context.Context, profileID stri
 	return &player, nil
 }
 
-func (r *ProfileRepository) CalculateCompletionScore(ctx context.Context, profile *model.Profile) int {
-	score := 0
+// UpdatePrivacy stores the profile's visibility and field-level settings.
+func (r *ProfileRepository) UpdatePrivacy(ctx context.Context, profileID string, privacy model.PrivacySettings, updatedAt time.Time) error {
+	query := `
+		UPDATE profiles
+		SET visibility = $1, city_visibility = $2, contact_visibility = $3,
+		    date_of_birth_visibility = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		privacy.Visibility,
+		privacy.CityVisibility,
+		privacy.ContactVisibility,
+		privacy.DateOfBirthVisibility,
+		updatedAt,
+		profileID,
+	)
 
-	// Basic fields (60 points)
-	if profile.DisplayName != "" {
-		score += 10
-	}
-	if profile.Bio != nil && *profile.Bio != "" {
-		score += 15
-	}
-	if profile.AvatarURL != nil && *profile.AvatarURL != "" {
-		score += 15
-	}
-	if profile.LocationCountry != nil && *profile.LocationCountry != "" {
-		score += 10
-	}
-	if profile.LocationCity != nil && *profile.LocationCity != "" {
-		score += 10
+	if err != nil {
+		return fmt.Errorf("failed to update privacy settings: %w", err)
 	}
-
-	// Type-specific fields (40 points)
-	if profile.Type == model.UserTypePlayer {
-		// Check player details
-		var hasDetails bool
-		query := `SELECT EXISTS(SELECT 1 FROM player_details WHERE profile_id = $1)`
-		r.pool.QueryRow(ctx, query, profile.ID).Scan(&hasDetails)
-		if hasDetails {
-			score += 40
-		}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileNotFound
 	}
 
-	return score
-}
\ No newline at end of file
+	return nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var (
+	ErrSlugTaken          = errors.New("slug is already taken")
+	ErrShareTokenNotFound = errors.New("share token not found")
+)
+
+func (r *ProfileRepository) GetBySlug(ctx context.Context, slug string) (*model.Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM profiles p WHERE p.slug = $1`
+
+	profile, err := scanProfile(r.db.QueryRow(ctx, query, slug))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get profile by slug: %w", err)
+	}
+
+	return profile, nil
+}
+
+// GetProfileIDBySlugHistory resolves a retired slug to the profile that used it.
+func (r *ProfileRepository) GetProfileIDBySlugHistory(ctx context.Context, slug string) (string, error) {
+	var profileID string
+	err := r.db.QueryRow(ctx, `SELECT profile_id FROM profile_slug_history WHERE slug = $1`, slug).Scan(&profileID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrProfileNotFound
+		}
+		return "", fmt.Errorf("failed to resolve slug history: %w", err)
+	}
+	return profileID, nil
+}
+
+// SlugAvailable reports whether slug is unused by any current or retired
+// slug, ignoring those belonging to profileID.
+func (r *ProfileRepository) SlugAvailable(ctx context.Context, slug, profileID string) (bool, error) {
+	query := `
+		SELECT NOT EXISTS (
+			SELECT 1 FROM profiles WHERE slug = $1 AND id::text <> $2
+			UNION ALL
+			SELECT 1 FROM profile_slug_history WHERE slug = $1 AND profile_id::text <> $2
+		)
+	`
+
+	var available bool
+	if err := r.db.QueryRow(ctx, query, slug, profileID).Scan(&available); err != nil {
+		return false, fmt.Errorf("failed to check slug: %w", err)
+	}
+	return available, nil
+}
+
+// ChangeSlug moves the profile's current slug into history and sets the new
+// one. Reclaiming one of the profile's own retired slugs removes it from
+// history.
+func (r *ProfileRepository) ChangeSlug(ctx context.Context, profileID, oldSlug, newSlug string, now time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`DELETE FROM profile_slug_history WHERE slug = $1 AND profile_id = $2`,
+		newSlug, profileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reclaim slug: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx,
+		`INSERT INTO profile_slug_history (slug, profile_id, created_at) VALUES ($1, $2, $3)
+		 ON CONFLICT (slug) DO NOTHING`,
+		oldSlug, profileID, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record slug history: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx,
+		`UPDATE profiles SET slug = $1, updated_at = $2 WHERE id = $3`,
+		newSlug, now, profileID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrSlugTaken
+		}
+		return fmt.Errorf("failed to update slug: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) CreateShareToken(ctx context.Context, token *model.ShareToken, tokenHash string) error {
+	query := `
+		INSERT INTO profile_share_tokens (id, profile_id, token_hash, label, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		token.ID,
+		token.ProfileID,
+		tokenHash,
+		token.Label,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create share token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) ListShareTokens(ctx context.Context, profileID string) ([]*model.ShareToken, error) {
+	query := `
+		SELECT id, profile_id, label, expires_at, revoked_at, created_at
+		FROM profile_share_tokens
+		WHERE profile_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*model.ShareToken{}
+	for rows.Next() {
+		var t model.ShareToken
+		if err := rows.Scan(&t.ID, &t.ProfileID, &t.Label, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan share token: %w", err)
+		}
+		tokens = append(tokens, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list share tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (r *ProfileRepository) RevokeShareToken(ctx context.Context, profileID, tokenID string, now time.Time) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE profile_share_tokens SET revoked_at = $1
+		 WHERE id = $2 AND profile_id = $3 AND revoked_at IS NULL`,
+		now, tokenID, profileID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShareTokenNotFound
+	}
+	return nil
+}
+
+// ShareTokenValid reports whether tokenHash is an unexpired, unrevoked token
+// for profileID.
+func (r *ProfileRepository) ShareTokenValid(ctx context.Context, profileID, tokenHash string, now time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM profile_share_tokens
+			WHERE profile_id = $1 AND token_hash = $2
+			  AND revoked_at IS NULL AND expires_at > $3
+		)
+	`
+
+	var valid bool
+	if err := r.db.QueryRow(ctx, query, profileID, tokenHash, now).Scan(&valid); err != nil {
+		return false, fmt.Errorf("failed to check share token: %w", err)
+	}
+	return valid, nil
+}
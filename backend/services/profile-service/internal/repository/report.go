@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var (
+	ErrReportNotFound         = errors.New("scouting report not found")
+	ErrReportVersionNotFound  = errors.New("scouting report version not found")
+	ErrReportTemplateNotFound = errors.New("report template not found")
+	ErrReportTemplateInUse    = errors.New("report template is used by existing reports")
+)
+
+func (r *ProfileRepository) CreateReportTemplate(ctx context.Context, tmpl *model.ReportTemplate) error {
+	query := `
+		INSERT INTO report_templates (id, academy_profile_id, name, scale_max, attributes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		tmpl.ID,
+		tmpl.AcademyProfileID,
+		tmpl.Name,
+		tmpl.ScaleMax,
+		tmpl.Attributes,
+		tmpl.CreatedAt,
+		tmpl.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create report template: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetReportTemplate(ctx context.Context, id string) (*model.ReportTemplate, error) {
+	query := `
+		SELECT id, academy_profile_id, name, scale_max, attributes, created_at, updated_at
+		FROM report_templates
+		WHERE id = $1
+	`
+
+	var tmpl model.ReportTemplate
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&tmpl.ID,
+		&tmpl.AcademyProfileID,
+		&tmpl.Name,
+		&tmpl.ScaleMax,
+		&tmpl.Attributes,
+		&tmpl.CreatedAt,
+		&tmpl.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReportTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get report template: %w", err)
+	}
+
+	return &tmpl, nil
+}
+
+func (r *ProfileRepository) ListReportTemplates(ctx context.Context, academyID string) ([]*model.ReportTemplate, error) {
+	query := `
+		SELECT id, academy_profile_id, name, scale_max, attributes, created_at, updated_at
+		FROM report_templates
+		WHERE academy_profile_id = $1
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(ctx, query, academyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*model.ReportTemplate{}
+	for rows.Next() {
+		var tmpl model.ReportTemplate
+		if err := rows.Scan(
+			&tmpl.ID,
+			&tmpl.AcademyProfileID,
+			&tmpl.Name,
+			&tmpl.ScaleMax,
+			&tmpl.Attributes,
+			&tmpl.CreatedAt,
+			&tmpl.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan report template: %w", err)
+		}
+		templates = append(templates, &tmpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list report templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (r *ProfileRepository) UpdateReportTemplate(ctx context.Context, tmpl *model.ReportTemplate) error {
+	query := `
+		UPDATE report_templates
+		SET name = $1, scale_max = $2, attributes = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	tag, err := r.db.Exec(ctx, query, tmpl.Name, tmpl.ScaleMax, tmpl.Attributes, tmpl.UpdatedAt, tmpl.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update report template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrReportTemplateNotFound
+	}
+	return nil
+}
+
+// DeleteReportTemplate refuses to delete a template that reports still use.
+func (r *ProfileRepository) DeleteReportTemplate(ctx context.Context, id string) error {
+	query := `
+		DELETE FROM report_templates
+		WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM scouting_reports WHERE template_id = $1)
+	`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete report template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		if _, err := r.GetReportTemplate(ctx, id); err != nil {
+			return err
+		}
+		return ErrReportTemplateInUse
+	}
+	return nil
+}
+
+// CreateScoutingReport inserts the report header and its first version.
+func (r *ProfileRepository) CreateScoutingReport(ctx context.Context, report *model.ScoutingReport) error {
+	query := `
+		INSERT INTO scouting_reports (id, player_profile_id, author_profile_id, academy_profile_id,
+		                              template_id, visibility, current_version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		report.ID,
+		report.PlayerID,
+		report.AuthorID,
+		report.AcademyProfileID,
+		report.TemplateID,
+		report.Visibility,
+		report.Version,
+		report.CreatedAt,
+		report.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create scouting report: %w", err)
+	}
+
+	return r.insertReportVersion(ctx, report.ID, &report.ScoutingReportVersion)
+}
+
+// AddScoutingReportVersion bumps the report's current version, assigning
+// the new number to version, and stores the content.
+func (r *ProfileRepository) AddScoutingReportVersion(ctx context.Context, reportID string, visibility model.ReportVisibility, version *model.ScoutingReportVersion) error {
+	query := `
+		UPDATE scouting_reports
+		SET current_version = current_version + 1, visibility = $1, updated_at = $2
+		WHERE id = $3
+		RETURNING current_version
+	`
+
+	err := r.db.QueryRow(ctx, query, visibility, version.UpdatedAt, reportID).Scan(&version.Version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReportNotFound
+		}
+		return fmt.Errorf("failed to bump report version: %w", err)
+	}
+
+	return r.insertReportVersion(ctx, reportID, version)
+}
+
+func (r *ProfileRepository) insertReportVersion(ctx context.Context, reportID string, version *model.ScoutingReportVersion) error {
+	query := `
+		INSERT INTO scouting_report_versions (report_id, version, ratings, scale_max, strengths, weaknesses,
+		                                      projected_level, recommendation, video_refs, edited_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		reportID,
+		version.Version,
+		version.Ratings,
+		version.ScaleMax,
+		version.Strengths,
+		version.Weaknesses,
+		version.ProjectedLevel,
+		version.Recommendation,
+		version.VideoRefs,
+		version.EditedBy,
+		version.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create report version: %w", err)
+	}
+
+	return nil
+}
+
+const reportColumns = `
+	s.id, s.player_profile_id, s.author_profile_id, s.academy_profile_id, s.template_id,
+	s.visibility, s.created_at, v.version, v.ratings, v.scale_max, v.strengths,
+	v.weaknesses, v.projected_level, v.recommendation, v.video_refs, v.edited_by,
+	v.created_at`
+
+func scanReport(row pgx.Row) (*model.ScoutingReport, error) {
+	var report model.ScoutingReport
+	err := row.Scan(
+		&report.ID,
+		&report.PlayerID,
+		&report.AuthorID,
+		&report.AcademyProfileID,
+		&report.TemplateID,
+		&report.Visibility,
+		&report.CreatedAt,
+		&report.Version,
+		&report.Ratings,
+		&report.ScaleMax,
+		&report.Strengths,
+		&report.Weaknesses,
+		&report.ProjectedLevel,
+		&report.Recommendation,
+		&report.VideoRefs,
+		&report.EditedBy,
+		&report.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *ProfileRepository) GetScoutingReport(ctx context.Context, id string) (*model.ScoutingReport, error) {
+	query := `
+		SELECT ` + reportColumns + `
+		FROM scouting_reports s
+		JOIN scouting_report_versions v ON v.report_id = s.id AND v.version = s.current_version
+		WHERE s.id = $1
+	`
+
+	report, err := scanReport(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get scouting report: %w", err)
+	}
+
+	return report, nil
+}
+
+// GetScoutingReportVersion returns the report header with the content of a
+// past version.
+func (r *ProfileRepository) GetScoutingReportVersion(ctx context.Context, id string, version int) (*model.ScoutingReport, error) {
+	query := `
+		SELECT ` + reportColumns + `
+		FROM scouting_reports s
+		JOIN scouting_report_versions v ON v.report_id = s.id
+		WHERE s.id = $1 AND v.version = $2
+	`
+
+	report, err := scanReport(r.db.QueryRow(ctx, query, id, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReportVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get scouting report version: %w", err)
+	}
+
+	return report, nil
+}
+
+func (r *ProfileRepository) ListScoutingReportVersions(ctx context.Context, id string) ([]*model.ScoutingReportVersion, error) {
+	query := `
+		SELECT version, ratings, scale_max, strengths, weaknesses, projected_level,
+		       recommendation, video_refs, edited_by, created_at
+		FROM scouting_report_versions
+		WHERE report_id = $1
+		ORDER BY version DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*model.ScoutingReportVersion{}
+	for rows.Next() {
+		var v model.ScoutingReportVersion
+		if err := rows.Scan(
+			&v.Version,
+			&v.Ratings,
+			&v.ScaleMax,
+			&v.Strengths,
+			&v.Weaknesses,
+			&v.ProjectedLevel,
+			&v.Recommendation,
+			&v.VideoRefs,
+			&v.EditedBy,
+			&v.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan report version: %w", err)
+		}
+		versions = append(versions, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list report versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// ListScoutingReportsForPlayer returns the current version of every report
+// on the player that readerID may see: its own reports and org reports
+// shared with an academy it is, or is staff of.
+func (r *ProfileRepository) ListScoutingReportsForPlayer(ctx context.Context, playerID, readerID string) ([]*model.ScoutingReport, error) {
+	query := `
+		SELECT ` + reportColumns + `
+		FROM scouting_reports s
+		JOIN scouting_report_versions v ON v.report_id = s.id AND v.version = s.current_version
+		WHERE s.player_profile_id = $1
+		  AND (
+		      s.author_profile_id = $2
+		      OR (s.visibility = 'org' AND (
+		          s.academy_profile_id = $2
+		          OR s.academy_profile_id IN (
+		              SELECT academy_profile_id FROM academy_staff WHERE member_profile_id = $2
+		          )
+		      ))
+		  )
+		ORDER BY v.created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, playerID, readerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scouting reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []*model.ScoutingReport{}
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scouting report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list scouting reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+func (r *ProfileRepository) DeleteScoutingReport(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM scouting_reports WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete scouting report: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrReportNotFound
+	}
+	return nil
+}
@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var ErrRosterImportNotFound = errors.New("roster import not found")
+
+// rosterImportColumns is scanned by scanRosterImport. Queries must alias
+// roster_imports as i and join the row counts as c (see rosterImportCounts).
+const rosterImportColumns = `
+	i.id, i.academy_profile_id, i.filename, i.format, i.dry_run, i.mapping,
+	i.total_rows, i.status, i.last_error, i.created_at, i.started_at,
+	i.completed_at, i.updated_at,
+	c.processed, c.valid, c.invalid, c.imported, c.skipped`
+
+const rosterImportCounts = `
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE status <> 'pending') AS processed,
+		       COUNT(*) FILTER (WHERE status = 'valid') AS valid,
+		       COUNT(*) FILTER (WHERE status = 'invalid') AS invalid,
+		       COUNT(*) FILTER (WHERE status = 'imported') AS imported,
+		       COUNT(*) FILTER (WHERE status = 'skipped') AS skipped
+		FROM roster_import_rows
+		WHERE import_id = i.id
+	) c`
+
+func scanRosterImport(row pgx.Row) (*model.RosterImport, error) {
+	var imp model.RosterImport
+	p := &imp.Progress
+	if err := row.Scan(
+		&imp.ID,
+		&imp.AcademyProfileID,
+		&imp.Filename,
+		&imp.Format,
+		&imp.DryRun,
+		&imp.Mapping,
+		&p.Total,
+		&imp.Status,
+		&imp.LastError,
+		&imp.CreatedAt,
+		&imp.StartedAt,
+		&imp.CompletedAt,
+		&imp.UpdatedAt,
+		&p.Processed,
+		&p.Valid,
+		&p.Invalid,
+		&p.Imported,
+		&p.Skipped,
+	); err != nil {
+		return nil, err
+	}
+	if p.Total > 0 {
+		p.Percent = p.Processed * 100 / p.Total
+	}
+	return &imp, nil
+}
+
+// CreateRosterImport stores the job and its rows. Run it inside InTx so a
+// failed row insert does not leave an empty job behind.
+func (r *ProfileRepository) CreateRosterImport(ctx context.Context, imp *model.RosterImport, rows []*model.RosterImportRow) error {
+	query := `
+		INSERT INTO roster_imports (id, academy_profile_id, filename, format, dry_run, mapping,
+		                            total_rows, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		imp.ID,
+		imp.AcademyProfileID,
+		imp.Filename,
+		imp.Format,
+		imp.DryRun,
+		imp.Mapping,
+		imp.Progress.Total,
+		imp.Status,
+		imp.CreatedAt,
+		imp.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create roster import: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	numbers := make([]int, len(rows))
+	data := make([]string, len(rows))
+	for i, row := range rows {
+		encoded, err := json.Marshal(row.Data)
+		if err != nil {
+			return fmt.Errorf("failed to encode roster row: %w", err)
+		}
+		numbers[i] = row.RowNumber
+		data[i] = string(encoded)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO roster_import_rows (import_id, row_number, data)
+		SELECT $1, t.row_number, t.data::jsonb
+		FROM unnest($2::int[], $3::text[]) AS t(row_number, data)
+	`, imp.ID, numbers, data)
+	if err != nil {
+		return fmt.Errorf("failed to create roster import rows: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetRosterImport(ctx context.Context, id string) (*model.RosterImport, error) {
+	query := `SELECT ` + rosterImportColumns + `
+		FROM roster_imports i` + rosterImportCounts + `
+		WHERE i.id = $1`
+
+	imp, err := scanRosterImport(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRosterImportNotFound
+		}
+		return nil, fmt.Errorf("failed to get roster import: %w", err)
+	}
+
+	return imp, nil
+}
+
+func (r *ProfileRepository) ListRosterImports(ctx context.Context, academyID string, limit int) ([]*model.RosterImport, error) {
+	query := `SELECT ` + rosterImportColumns + `
+		FROM roster_imports i` + rosterImportCounts + `
+		WHERE i.academy_profile_id = $1
+		ORDER BY i.created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, academyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roster imports: %w", err)
+	}
+	defer rows.Close()
+
+	imports := []*model.RosterImport{}
+	for rows.Next() {
+		imp, err := scanRosterImport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan roster import: %w", err)
+		}
+		imports = append(imports, imp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list roster imports: %w", err)
+	}
+
+	return imports, nil
+}
+
+// ListRosterImportRows returns rows in file order. An empty status returns
+// every row.
+func (r *ProfileRepository) ListRosterImportRows(ctx context.Context, importID string, status model.RosterRowStatus, limit, offset int) ([]*model.RosterImportRow, error) {
+	query := `
+		SELECT import_id, row_number, data, status, errors, user_id, profile_id, processed_at
+		FROM roster_import_rows
+		WHERE import_id = $1 AND ($2 = '' OR status::text = $2)
+		ORDER BY row_number
+		LIMIT $3 OFFSET $4
+	`
+
+	return r.queryRosterRows(ctx, query, importID, string(status), limit, offset)
+}
+
+// PendingRosterRows returns the next unprocessed rows of an import in file
+// order.
+func (r *ProfileRepository) PendingRosterRows(ctx context.Context, importID string, limit int) ([]*model.RosterImportRow, error) {
+	query := `
+		SELECT import_id, row_number, data, status, errors, user_id, profile_id, processed_at
+		FROM roster_import_rows
+		WHERE import_id = $1 AND status = 'pending'
+		ORDER BY row_number
+		LIMIT $2
+	`
+
+	return r.queryRosterRows(ctx, query, importID, limit)
+}
+
+func (r *ProfileRepository) queryRosterRows(ctx context.Context, query string, args ...any) ([]*model.RosterImportRow, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roster import rows: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*model.RosterImportRow{}
+	for rows.Next() {
+		var row model.RosterImportRow
+		if err := rows.Scan(
+			&row.ImportID,
+			&row.RowNumber,
+			&row.Data,
+			&row.Status,
+			&row.Errors,
+			&row.UserID,
+			&row.ProfileID,
+			&row.ProcessedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan roster import row: %w", err)
+		}
+		result = append(result, &row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list roster import rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// EarlierRosterRowWithEmail returns the number of an earlier row in the same
+// import with the same email, or 0 if there is none.
+func (r *ProfileRepository) EarlierRosterRowWithEmail(ctx context.Context, importID string, rowNumber int, email string) (int, error) {
+	query := `
+		SELECT row_number
+		FROM roster_import_rows
+		WHERE import_id = $1 AND row_number < $2 AND lower(data->>'email') = lower($3)
+		ORDER BY row_number
+		LIMIT 1
+	`
+
+	var earlier int
+	err := r.db.QueryRow(ctx, query, importID, rowNumber, email).Scan(&earlier)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to check duplicate roster email: %w", err)
+	}
+
+	return earlier, nil
+}
+
+func (r *ProfileRepository) UpdateRosterRow(ctx context.Context, row *model.RosterImportRow) error {
+	query := `
+		UPDATE roster_import_rows
+		SET status = $1, errors = $2, user_id = $3, profile_id = $4, processed_at = $5
+		WHERE import_id = $6 AND row_number = $7
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		row.Status,
+		row.Errors,
+		row.UserID,
+		row.ProfileID,
+		row.ProcessedAt,
+		row.ImportID,
+		row.RowNumber,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update roster import row: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimRosterImport leases the oldest runnable import for the given duration
+// and marks it running. Imports whose lease expired, e.g. because the worker
+// holding them died, are claimable again. It returns nil when there is no
+// work.
+func (r *ProfileRepository) ClaimRosterImport(ctx context.Context, lease time.Duration) (*model.RosterImport, error) {
+	var id string
+	err := r.db.QueryRow(ctx, `
+		UPDATE roster_imports
+		SET status = 'running',
+		    locked_until = NOW() + $1 * INTERVAL '1 millisecond',
+		    started_at = COALESCE(started_at, NOW()),
+		    updated_at = NOW()
+		WHERE id = (
+			SELECT id
+			FROM roster_imports
+			WHERE status IN ('pending', 'running')
+			  AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`, lease.Milliseconds()).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim roster import: %w", err)
+	}
+
+	return r.GetRosterImport(ctx, id)
+}
+
+// ReleaseRosterImport gives up the lease so the next batch can be claimed.
+func (r *ProfileRepository) ReleaseRosterImport(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE roster_imports
+		SET locked_until = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to release roster import: %w", err)
+	}
+	return nil
+}
+
+// FinishRosterImport moves a running import to completed or paused. An
+// import cancelled while a batch was in flight stays cancelled.
+func (r *ProfileRepository) FinishRosterImport(ctx context.Context, id string, status model.RosterImportStatus, lastError *string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE roster_imports
+		SET status = $1,
+		    last_error = $2,
+		    locked_until = NULL,
+		    completed_at = CASE WHEN $1 = 'completed' THEN NOW() ELSE completed_at END,
+		    updated_at = NOW()
+		WHERE id = $3 AND status = 'running'
+	`, status, lastError, id)
+	if err != nil {
+		return fmt.Errorf("failed to finish roster import: %w", err)
+	}
+	return nil
+}
+
+// TransitionRosterImport moves an import to status if it is currently in one
+// of from, clearing any lease and last error. It reports whether the import
+// changed state.
+func (r *ProfileRepository) TransitionRosterImport(ctx context.Context, id string, from []model.RosterImportStatus, status model.RosterImportStatus) (bool, error) {
+	states := make([]string, len(from))
+	for i, s := range from {
+		states[i] = string(s)
+	}
+
+	tag, err := r.db.Exec(ctx, `
+		UPDATE roster_imports
+		SET status = $1, last_error = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = $2 AND status::text = ANY($3)
+	`, status, id, states)
+	if err != nil {
+		return false, fmt.Errorf("failed to update roster import status: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// CommitDryRun turns a completed dry run into a real import, re-queueing the
+// rows that passed validation. It reports false if the import is not a
+// completed dry run.
+func (r *ProfileRepository) CommitDryRun(ctx context.Context, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE roster_imports
+		SET dry_run = FALSE, status = 'pending', completed_at = NULL, last_error = NULL, updated_at = NOW()
+		WHERE id = $1 AND dry_run AND status = 'completed'
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to commit dry run: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = r.db.Exec(ctx, `
+		UPDATE roster_import_rows
+		SET status = 'pending', processed_at = NULL
+		WHERE import_id = $1 AND status = 'valid'
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to re-queue roster import rows: %w", err)
+	}
+
+	return true, nil
+}
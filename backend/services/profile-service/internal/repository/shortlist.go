@@ -0,0 +1,412 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var (
+	ErrShortlistNotFound       = errors.New("shortlist not found")
+	ErrShortlistEntryNotFound  = errors.New("shortlist entry not found")
+	ErrShortlistEntryDuplicate = errors.New("player is already on this shortlist")
+	ErrStaffMemberNotFound     = errors.New("staff member not found")
+)
+
+const shortlistColumns = `
+	s.id, s.owner_profile_id, s.academy_profile_id, s.name, s.description,
+	s.created_at, s.updated_at,
+	(SELECT COUNT(*) FROM shortlist_entries e WHERE e.shortlist_id = s.id)`
+
+func scanShortlist(row pgx.Row) (*model.Shortlist, error) {
+	var list model.Shortlist
+	err := row.Scan(
+		&list.ID,
+		&list.OwnerProfileID,
+		&list.AcademyProfileID,
+		&list.Name,
+		&list.Description,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+		&list.EntryCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (r *ProfileRepository) CreateShortlist(ctx context.Context, list *model.Shortlist) error {
+	query := `
+		INSERT INTO shortlists (id, owner_profile_id, academy_profile_id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		list.ID,
+		list.OwnerProfileID,
+		list.AcademyProfileID,
+		list.Name,
+		list.Description,
+		list.CreatedAt,
+		list.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shortlist: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetShortlist(ctx context.Context, id string) (*model.Shortlist, error) {
+	query := `SELECT ` + shortlistColumns + ` FROM shortlists s WHERE s.id = $1`
+
+	list, err := scanShortlist(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShortlistNotFound
+		}
+		return nil, fmt.Errorf("failed to get shortlist: %w", err)
+	}
+
+	return list, nil
+}
+
+// ListShortlistsForProfile returns the lists a profile owns plus those shared
+// with an academy it is, or is staff of.
+func (r *ProfileRepository) ListShortlistsForProfile(ctx context.Context, profileID string) ([]*model.Shortlist, error) {
+	query := `
+		SELECT ` + shortlistColumns + `
+		FROM shortlists s
+		WHERE s.owner_profile_id = $1
+		   OR s.academy_profile_id = $1
+		   OR s.academy_profile_id IN (
+		       SELECT academy_profile_id FROM academy_staff WHERE member_profile_id = $1
+		   )
+		ORDER BY s.updated_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shortlists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []*model.Shortlist{}
+	for rows.Next() {
+		list, err := scanShortlist(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shortlist: %w", err)
+		}
+		lists = append(lists, list)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list shortlists: %w", err)
+	}
+
+	return lists, nil
+}
+
+func (r *ProfileRepository) UpdateShortlist(ctx context.Context, list *model.Shortlist) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE shortlists SET name = $1, description = $2, updated_at = $3 WHERE id = $4`,
+		list.Name, list.Description, list.UpdatedAt, list.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update shortlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShortlistNotFound
+	}
+	return nil
+}
+
+func (r *ProfileRepository) DeleteShortlist(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM shortlists WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete shortlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShortlistNotFound
+	}
+	return nil
+}
+
+func (r *ProfileRepository) touchShortlist(ctx context.Context, id string, now time.Time) error {
+	if _, err := r.db.Exec(ctx, `UPDATE shortlists SET updated_at = $1 WHERE id = $2`, now, id); err != nil {
+		return fmt.Errorf("failed to update shortlist: %w", err)
+	}
+	return nil
+}
+
+// AddShortlistEntry appends the entry to the end of the list.
+func (r *ProfileRepository) AddShortlistEntry(ctx context.Context, entry *model.ShortlistEntry) error {
+	query := `
+		INSERT INTO shortlist_entries (id, shortlist_id, player_profile_id, sort_order, status,
+		                               notes, tags, added_by, created_at, updated_at)
+		VALUES ($1, $2, $3,
+		        (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM shortlist_entries WHERE shortlist_id = $2),
+		        $4, $5, $6, $7, $8, $9)
+		RETURNING sort_order
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		entry.ID,
+		entry.ShortlistID,
+		entry.PlayerID,
+		entry.Status,
+		entry.Notes,
+		entry.Tags,
+		entry.AddedBy,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	).Scan(&entry.SortOrder)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrShortlistEntryDuplicate
+		}
+		return fmt.Errorf("failed to add shortlist entry: %w", err)
+	}
+
+	return r.touchShortlist(ctx, entry.ShortlistID, entry.UpdatedAt)
+}
+
+const shortlistEntryColumns = `
+	e.id, e.shortlist_id, e.player_profile_id, e.sort_order, e.status, e.notes,
+	e.tags, e.added_by, e.created_at, e.updated_at,
+	p.id, p.slug, p.type, p.display_name, p.avatar_url, p.trust_level,
+	p.location_country, pd.position, pd.overall_score`
+
+func scanShortlistEntry(row pgx.Row) (*model.ShortlistEntry, error) {
+	var entry model.ShortlistEntry
+	var player model.ShortlistPlayer
+	err := row.Scan(
+		&entry.ID,
+		&entry.ShortlistID,
+		&entry.PlayerID,
+		&entry.SortOrder,
+		&entry.Status,
+		&entry.Notes,
+		&entry.Tags,
+		&entry.AddedBy,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+		&player.ID,
+		&player.Slug,
+		&player.Type,
+		&player.DisplayName,
+		&player.AvatarURL,
+		&player.TrustLevel,
+		&player.LocationCountry,
+		&player.Position,
+		&player.OverallScore,
+	)
+	if err != nil {
+		return nil, err
+	}
+	entry.Player = &player
+	return &entry, nil
+}
+
+func (r *ProfileRepository) GetShortlistEntry(ctx context.Context, shortlistID, entryID string) (*model.ShortlistEntry, error) {
+	query := `
+		SELECT ` + shortlistEntryColumns + `
+		FROM shortlist_entries e
+		JOIN profiles p ON p.id = e.player_profile_id
+		LEFT JOIN player_details pd ON pd.profile_id = p.id
+		WHERE e.shortlist_id = $1 AND e.id = $2
+	`
+
+	entry, err := scanShortlistEntry(r.db.QueryRow(ctx, query, shortlistID, entryID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShortlistEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get shortlist entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *ProfileRepository) ListShortlistEntries(ctx context.Context, shortlistID string) ([]*model.ShortlistEntry, error) {
+	query := `
+		SELECT ` + shortlistEntryColumns + `
+		FROM shortlist_entries e
+		JOIN profiles p ON p.id = e.player_profile_id
+		LEFT JOIN player_details pd ON pd.profile_id = p.id
+		WHERE e.shortlist_id = $1 AND p.status = 'active'
+		ORDER BY e.sort_order, e.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, shortlistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shortlist entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*model.ShortlistEntry{}
+	for rows.Next() {
+		entry, err := scanShortlistEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shortlist entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list shortlist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *ProfileRepository) UpdateShortlistEntry(ctx context.Context, entry *model.ShortlistEntry) error {
+	query := `
+		UPDATE shortlist_entries
+		SET status = $1, notes = $2, tags = $3, updated_at = $4
+		WHERE id = $5 AND shortlist_id = $6
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		entry.Status,
+		entry.Notes,
+		entry.Tags,
+		entry.UpdatedAt,
+		entry.ID,
+		entry.ShortlistID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update shortlist entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShortlistEntryNotFound
+	}
+
+	return r.touchShortlist(ctx, entry.ShortlistID, entry.UpdatedAt)
+}
+
+func (r *ProfileRepository) DeleteShortlistEntry(ctx context.Context, shortlistID, entryID string) error {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM shortlist_entries WHERE id = $1 AND shortlist_id = $2`,
+		entryID, shortlistID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete shortlist entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShortlistEntryNotFound
+	}
+
+	return r.touchShortlist(ctx, shortlistID, time.Now())
+}
+
+// ReorderShortlist assigns sort_order by position in entryIDs. The caller
+// must pass every entry of the list exactly once.
+func (r *ProfileRepository) ReorderShortlist(ctx context.Context, shortlistID string, entryIDs []string, now time.Time) error {
+	query := `
+		UPDATE shortlist_entries e
+		SET sort_order = o.ord, updated_at = $3
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+		WHERE e.shortlist_id = $1 AND e.id = o.id
+	`
+
+	tag, err := r.db.Exec(ctx, query, shortlistID, entryIDs, now)
+	if err != nil {
+		return fmt.Errorf("failed to reorder shortlist: %w", err)
+	}
+	if int(tag.RowsAffected()) != len(entryIDs) {
+		return ErrShortlistEntryNotFound
+	}
+
+	return r.touchShortlist(ctx, shortlistID, now)
+}
+
+func (r *ProfileRepository) CountShortlistEntries(ctx context.Context, shortlistID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM shortlist_entries WHERE shortlist_id = $1`, shortlistID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count shortlist entries: %w", err)
+	}
+	return count, nil
+}
+
+func (r *ProfileRepository) IsAcademyStaff(ctx context.Context, academyID, memberID string) (bool, error) {
+	var isStaff bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM academy_staff WHERE academy_profile_id = $1 AND member_profile_id = $2)`,
+		academyID, memberID,
+	).Scan(&isStaff)
+	if err != nil {
+		return false, fmt.Errorf("failed to check academy staff: %w", err)
+	}
+	return isStaff, nil
+}
+
+func (r *ProfileRepository) AddAcademyStaff(ctx context.Context, academyID, memberID string, now time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO academy_staff (academy_profile_id, member_profile_id, added_at) VALUES ($1, $2, $3)
+		 ON CONFLICT DO NOTHING`,
+		academyID, memberID, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add academy staff: %w", err)
+	}
+	return nil
+}
+
+func (r *ProfileRepository) RemoveAcademyStaff(ctx context.Context, academyID, memberID string) error {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM academy_staff WHERE academy_profile_id = $1 AND member_profile_id = $2`,
+		academyID, memberID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove academy staff: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStaffMemberNotFound
+	}
+	return nil
+}
+
+func (r *ProfileRepository) ListAcademyStaff(ctx context.Context, academyID string) ([]*model.AcademyStaffMember, error) {
+	query := `
+		SELECT p.id, p.slug, p.type, p.display_name, p.avatar_url, p.trust_level, s.added_at
+		FROM academy_staff s
+		JOIN profiles p ON p.id = s.member_profile_id
+		WHERE s.academy_profile_id = $1 AND p.status = 'active'
+		ORDER BY s.added_at
+	`
+
+	rows, err := r.db.Query(ctx, query, academyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list academy staff: %w", err)
+	}
+	defer rows.Close()
+
+	staff := []*model.AcademyStaffMember{}
+	for rows.Next() {
+		var m model.AcademyStaffMember
+		if err := rows.Scan(
+			&m.Profile.ID,
+			&m.Profile.Slug,
+			&m.Profile.Type,
+			&m.Profile.DisplayName,
+			&m.Profile.AvatarURL,
+			&m.Profile.TrustLevel,
+			&m.AddedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan academy staff: %w", err)
+		}
+		staff = append(staff, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list academy staff: %w", err)
+	}
+
+	return staff, nil
+}
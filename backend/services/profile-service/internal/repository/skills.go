@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func (r *ProfileRepository) CreateSkillAssessment(ctx context.Context, assessment *model.SkillAssessment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO skill_assessments (id, profile_id, source, assessor_id, video_id,
+		                               model_version, confidence, notes, assessed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		assessment.ID,
+		assessment.ProfileID,
+		assessment.Source,
+		assessment.AssessorID,
+		assessment.VideoID,
+		assessment.ModelVersion,
+		assessment.Confidence,
+		assessment.Notes,
+		assessment.AssessedAt,
+		assessment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create skill assessment: %w", err)
+	}
+
+	for skill, score := range assessment.Scores {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO skill_assessment_scores (assessment_id, skill, score) VALUES ($1, $2, $3)`,
+			assessment.ID, skill, score,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create skill score: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit skill assessment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) ListSkillAssessments(ctx context.Context, profileID string, limit, offset int) ([]*model.SkillAssessment, error) {
+	query := `
+		SELECT id, profile_id, source, assessor_id, video_id, model_version,
+		       confidence, notes, assessed_at, created_at
+		FROM skill_assessments
+		WHERE profile_id = $1
+		ORDER BY assessed_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, profileID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skill assessments: %w", err)
+	}
+	defer rows.Close()
+
+	assessments := []*model.SkillAssessment{}
+	byID := make(map[string]*model.SkillAssessment)
+	ids := []string{}
+	for rows.Next() {
+		var a model.SkillAssessment
+		if err := rows.Scan(
+			&a.ID,
+			&a.ProfileID,
+			&a.Source,
+			&a.AssessorID,
+			&a.VideoID,
+			&a.ModelVersion,
+			&a.Confidence,
+			&a.Notes,
+			&a.AssessedAt,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan skill assessment: %w", err)
+		}
+		a.Scores = make(map[string]float64)
+		assessments = append(assessments, &a)
+		byID[a.ID] = &a
+		ids = append(ids, a.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list skill assessments: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return assessments, nil
+	}
+
+	scoreRows, err := r.db.Query(ctx,
+		`SELECT assessment_id, skill, score FROM skill_assessment_scores WHERE assessment_id = ANY($1)`,
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list skill scores: %w", err)
+	}
+	defer scoreRows.Close()
+
+	for scoreRows.Next() {
+		var assessmentID, skill string
+		var score float64
+		if err := scoreRows.Scan(&assessmentID, &skill, &score); err != nil {
+			return nil, fmt.Errorf("failed to scan skill score: %w", err)
+		}
+		if a, ok := byID[assessmentID]; ok {
+			a.Scores[skill] = score
+		}
+	}
+	if err := scoreRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list skill scores: %w", err)
+	}
+
+	return assessments, nil
+}
+
+// GetSkillHistory returns every recorded score for one skill key, newest first.
+func (r *ProfileRepository) GetSkillHistory(ctx context.Context, profileID, skill string, limit int) ([]*model.SkillHistoryPoint, error) {
+	query := `
+		SELECT a.id, a.source, a.assessor_id, a.confidence, s.score, a.assessed_at
+		FROM skill_assessment_scores s
+		JOIN skill_assessments a ON a.id = s.assessment_id
+		WHERE a.profile_id = $1 AND s.skill = $2
+		ORDER BY a.assessed_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, profileID, skill, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []*model.SkillHistoryPoint{}
+	for rows.Next() {
+		var point model.SkillHistoryPoint
+		if err := rows.Scan(
+			&point.AssessmentID,
+			&point.Source,
+			&point.AssessorID,
+			&point.Confidence,
+			&point.Score,
+			&point.AssessedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan skill history: %w", err)
+		}
+		history = append(history, &point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get skill history: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetWeightedSkillScores returns the confidence-weighted mean of each skill
+// key across the assessments since the given time. Every AI assessment
+// counts, but only each coach's latest one, so repeat submissions cannot
+// outweigh other assessors.
+func (r *ProfileRepository) GetWeightedSkillScores(ctx context.Context, profileID string, since time.Time) (map[string]float64, error) {
+	query := `
+		SELECT s.skill, SUM(s.score * a.confidence) / SUM(a.confidence)
+		FROM skill_assessment_scores s
+		JOIN (
+			SELECT id, confidence, source = 'ai' OR ROW_NUMBER() OVER (
+				PARTITION BY source, assessor_id ORDER BY assessed_at DESC, created_at DESC
+			) = 1 AS current
+			FROM skill_assessments
+			WHERE profile_id = $1 AND assessed_at >= $2
+		) a ON a.id = s.assessment_id
+		WHERE a.current
+		GROUP BY s.skill
+	`
+
+	rows, err := r.db.Query(ctx, query, profileID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate skill scores: %w", err)
+	}
+	defer rows.Close()
+
+	scores := make(map[string]float64)
+	for rows.Next() {
+		var skill string
+		var score float64
+		if err := rows.Scan(&skill, &score); err != nil {
+			return nil, fmt.Errorf("failed to scan skill score: %w", err)
+		}
+		scores[skill] = score
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to aggregate skill scores: %w", err)
+	}
+
+	return scores, nil
+}
+
+// LockPlayerSkills locks a player's player_details row until the end of the
+// transaction, so concurrent assessments recompute the skill scores one at a
+// time, and returns the player's position.
+func (r *ProfileRepository) LockPlayerSkills(ctx context.Context, profileID string) (string, error) {
+	var position string
+	err := r.db.QueryRow(ctx,
+		`SELECT position FROM player_details WHERE profile_id = $1 FOR UPDATE`,
+		profileID,
+	).Scan(&position)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrProfileNotFound
+		}
+		return "", fmt.Errorf("failed to lock player details: %w", err)
+	}
+
+	return position, nil
+}
+
+func (r *ProfileRepository) UpdateSkillScores(ctx context.Context, profileID string, scores model.SkillScores, overall *float64, scoredAt time.Time) error {
+	query := `
+		UPDATE player_details
+		SET skill_scores = $1, overall_score = $2, last_scored_at = $3
+		WHERE profile_id = $4
+	`
+
+	tag, err := r.db.Exec(ctx, query, scores, overall, scoredAt, profileID)
+	if err != nil {
+		return fmt.Errorf("failed to update skill scores: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var ErrTranslationNotFound = errors.New("translation not found")
+
+const translationColumns = `
+	t.profile_id, t.locale, t.display_name, t.bio, t.detected_language,
+	t.created_at, t.updated_at`
+
+func scanTranslation(row pgx.Row) (*model.ProfileTranslation, error) {
+	var t model.ProfileTranslation
+	err := row.Scan(
+		&t.ProfileID,
+		&t.Locale,
+		&t.DisplayName,
+		&t.Bio,
+		&t.DetectedLanguage,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// UpsertTranslation creates or replaces the profile's text in one locale.
+func (r *ProfileRepository) UpsertTranslation(ctx context.Context, t *model.ProfileTranslation) error {
+	query := `
+		INSERT INTO profile_translations (profile_id, locale, display_name, bio, detected_language,
+		                                  created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (profile_id, locale) DO UPDATE
+		SET display_name = EXCLUDED.display_name,
+		    bio = EXCLUDED.bio,
+		    detected_language = EXCLUDED.detected_language,
+		    updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		t.ProfileID,
+		t.Locale,
+		t.DisplayName,
+		t.Bio,
+		t.DetectedLanguage,
+		t.CreatedAt,
+		t.UpdatedAt,
+	).Scan(&t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) ListTranslations(ctx context.Context, profileID string) ([]*model.ProfileTranslation, error) {
+	query := `
+		SELECT ` + translationColumns + `
+		FROM profile_translations t
+		WHERE t.profile_id = $1
+		ORDER BY t.locale
+	`
+
+	rows, err := r.db.Query(ctx, query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []*model.ProfileTranslation{}
+	for rows.Next() {
+		t, err := scanTranslation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		translations = append(translations, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+func (r *ProfileRepository) DeleteTranslation(ctx context.Context, profileID, locale string) error {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM profile_translations WHERE profile_id = $1 AND locale = $2`,
+		profileID, locale,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete translation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTranslationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var (
+	ErrInvalidCareerEntry = errors.New("invalid career entry")
+	ErrInvalidSeasonStats = errors.New("invalid season stats")
+)
+
+var seasonPattern = regexp.MustCompile(`^([0-9]{4})(?:/([0-9]{4}))?$`)
+
+func (s *ProfileService) ListCareer(ctx context.Context, viewer model.Viewer, profileID string) ([]*model.CareerEntry, error) {
+	if _, err := s.requireVisiblePlayer(ctx, viewer, profileID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCareerEntries(ctx, profileID)
+}
+
+func (s *ProfileService) CreateCareerEntry(ctx context.Context, userID, profileID string, req model.CreateCareerEntryRequest) (*model.CareerEntry, error) {
+	player, err := s.requirePlayer(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if player.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	now := time.Now()
+	entry := &model.CareerEntry{
+		ID:            uuid.New().String(),
+		ProfileID:     profileID,
+		ClubName:      req.ClubName,
+		ClubProfileID: req.ClubProfileID,
+		Country:       req.Country,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+		IsCurrent:     req.IsCurrent,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Seasons:       []*model.SeasonStats{},
+	}
+
+	if err := validateCareerEntry(entry); err != nil {
+		return nil, err
+	}
+	if err := s.validateClubProfile(ctx, entry.ClubProfileID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateCareerEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+	if err := s.storeCompletionScore(ctx, player); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *ProfileService) UpdateCareerEntry(ctx context.Context, userID, profileID, entryID string, req model.UpdateCareerEntryRequest) (*model.CareerEntry, error) {
+	player, entry, err := s.getPlayerCareerEntry(ctx, profileID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if player.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	if req.ClubName != nil {
+		entry.ClubName = *req.ClubName
+	}
+	if req.ClubProfileID != nil {
+		entry.ClubProfileID = req.ClubProfileID
+	}
+	if req.Country != nil {
+		entry.Country = req.Country
+	}
+	if req.StartDate != nil {
+		entry.StartDate = *req.StartDate
+	}
+	if req.ClearEndDate {
+		if req.EndDate != nil {
+			return nil, fmt.Errorf("%w: end_date and clear_end_date are exclusive", ErrInvalidCareerEntry)
+		}
+		entry.EndDate = nil
+	}
+	if req.EndDate != nil {
+		entry.EndDate = req.EndDate
+	}
+	if req.IsCurrent != nil {
+		entry.IsCurrent = *req.IsCurrent
+	}
+
+	if err := validateCareerEntry(entry); err != nil {
+		return nil, err
+	}
+	if req.ClubProfileID != nil {
+		if err := s.validateClubProfile(ctx, entry.ClubProfileID); err != nil {
+			return nil, err
+		}
+	}
+
+	markCareerEdited(entry)
+	if err := s.repo.UpdateCareerEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *ProfileService) DeleteCareerEntry(ctx context.Context, userID, profileID, entryID string) error {
+	player, _, err := s.getPlayerCareerEntry(ctx, profileID, entryID)
+	if err != nil {
+		return err
+	}
+
+	if player.UserID != userID {
+		return ErrForbidden
+	}
+
+	if err := s.repo.DeleteCareerEntry(ctx, entryID); err != nil {
+		return err
+	}
+	return s.storeCompletionScore(ctx, player)
+}
+
+// ConfirmCareerEntry marks an entry as confirmed by the club it is linked to.
+// Only the academy profile referenced by club_profile_id may confirm.
+func (s *ProfileService) ConfirmCareerEntry(ctx context.Context, userID, profileID, entryID string) (*model.CareerEntry, error) {
+	_, entry, err := s.getPlayerCareerEntry(ctx, profileID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	club, err := s.requireLinkedClub(ctx, userID, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry.ConfirmedAt = &now
+	entry.ConfirmedBy = &club.ID
+	entry.RejectedAt = nil
+	entry.RejectedBy = nil
+	entry.UpdatedAt = now
+	if err := s.repo.UpdateCareerEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// RejectCareerEntry marks an entry as disputed by the club it is linked to.
+// The entry stays on the player's career until they edit or delete it.
+func (s *ProfileService) RejectCareerEntry(ctx context.Context, userID, profileID, entryID string) (*model.CareerEntry, error) {
+	_, entry, err := s.getPlayerCareerEntry(ctx, profileID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	club, err := s.requireLinkedClub(ctx, userID, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry.RejectedAt = &now
+	entry.RejectedBy = &club.ID
+	entry.ConfirmedAt = nil
+	entry.ConfirmedBy = nil
+	entry.UpdatedAt = now
+	if err := s.repo.UpdateCareerEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *ProfileService) CreateSeasonStats(ctx context.Context, userID, profileID, entryID string, req model.SeasonStatsRequest) (*model.SeasonStats, error) {
+	player, entry, err := s.getPlayerCareerEntry(ctx, profileID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if player.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	now := time.Now()
+	stats := &model.SeasonStats{
+		ID:            uuid.New().String(),
+		CareerEntryID: entryID,
+		CreatedAt:     now,
+	}
+	applySeasonStatsRequest(stats, req, now)
+
+	if err := s.validateSeasonStats(ctx, profileID, stats); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateSeasonStats(ctx, stats); err != nil {
+		return nil, err
+	}
+
+	if err := s.touchCareerEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (s *ProfileService) UpdateSeasonStats(ctx context.Context, userID, profileID, entryID, statsID string, req model.SeasonStatsRequest) (*model.SeasonStats, error) {
+	player, entry, err := s.getPlayerCareerEntry(ctx, profileID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if player.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	stats, err := s.repo.GetSeasonStats(ctx, statsID)
+	if err != nil {
+		return nil, err
+	}
+	if stats.CareerEntryID != entryID {
+		return nil, repository.ErrSeasonStatsNotFound
+	}
+
+	applySeasonStatsRequest(stats, req, time.Now())
+
+	if err := s.validateSeasonStats(ctx, profileID, stats); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSeasonStats(ctx, stats); err != nil {
+		return nil, err
+	}
+
+	if err := s.touchCareerEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (s *ProfileService) DeleteSeasonStats(ctx context.Context, userID, profileID, entryID, statsID string) error {
+	player, entry, err := s.getPlayerCareerEntry(ctx, profileID, entryID)
+	if err != nil {
+		return err
+	}
+
+	if player.UserID != userID {
+		return ErrForbidden
+	}
+
+	stats, err := s.repo.GetSeasonStats(ctx, statsID)
+	if err != nil {
+		return err
+	}
+	if stats.CareerEntryID != entryID {
+		return repository.ErrSeasonStatsNotFound
+	}
+
+	if err := s.repo.DeleteSeasonStats(ctx, statsID); err != nil {
+		return err
+	}
+
+	return s.touchCareerEntry(ctx, entry)
+}
+
+func (s *ProfileService) requirePlayer(ctx context.Context, profileID string) (*model.Profile, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Type != model.UserTypePlayer {
+		return nil, ErrNotPlayer
+	}
+	return profile, nil
+}
+
+func (s *ProfileService) requireVisiblePlayer(ctx context.Context, viewer model.Viewer, profileID string) (*model.Profile, error) {
+	profile, err := s.visibleProfile(ctx, viewer, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Type != model.UserTypePlayer {
+		return nil, ErrNotPlayer
+	}
+	return profile, nil
+}
+
+func (s *ProfileService) getPlayerCareerEntry(ctx context.Context, profileID, entryID string) (*model.Profile, *model.CareerEntry, error) {
+	player, err := s.requirePlayer(ctx, profileID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, err := s.repo.GetCareerEntry(ctx, entryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry.ProfileID != profileID {
+		return nil, nil, repository.ErrCareerEntryNotFound
+	}
+
+	return player, entry, nil
+}
+
+// requireLinkedClub returns the caller's profile if it is the academy the
+// entry is linked to.
+func (s *ProfileService) requireLinkedClub(ctx context.Context, userID string, entry *model.CareerEntry) (*model.Profile, error) {
+	caller, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			return nil, ErrForbidden
+		}
+		return nil, err
+	}
+
+	if caller.Type != model.UserTypeAcademy || entry.ClubProfileID == nil || *entry.ClubProfileID != caller.ID {
+		return nil, ErrForbidden
+	}
+
+	return caller, nil
+}
+
+func (s *ProfileService) validateClubProfile(ctx context.Context, clubProfileID *string) error {
+	if clubProfileID == nil {
+		return nil
+	}
+
+	club, err := s.repo.GetByID(ctx, *clubProfileID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			return fmt.Errorf("%w: club profile not found", ErrInvalidCareerEntry)
+		}
+		return err
+	}
+	if club.Type != model.UserTypeAcademy {
+		return fmt.Errorf("%w: club profile is not an academy", ErrInvalidCareerEntry)
+	}
+
+	return nil
+}
+
+func (s *ProfileService) validateSeasonStats(ctx context.Context, profileID string, stats *model.SeasonStats) error {
+	match := seasonPattern.FindStringSubmatch(stats.Season)
+	if match == nil {
+		return fmt.Errorf("%w: season must be YYYY or YYYY/YYYY", ErrInvalidSeasonStats)
+	}
+	if match[2] != "" {
+		start, _ := strconv.Atoi(match[1])
+		end, _ := strconv.Atoi(match[2])
+		if end != start+1 {
+			return fmt.Errorf("%w: season years must be consecutive", ErrInvalidSeasonStats)
+		}
+	}
+
+	if stats.MinutesPlayed > stats.Appearances*130 {
+		return fmt.Errorf("%w: minutes played exceed appearances", ErrInvalidSeasonStats)
+	}
+
+	position := ""
+	player, err := s.repo.GetPlayerProfile(ctx, profileID)
+	if err == nil {
+		position = player.PlayerDetails.Position
+	} else if !errors.Is(err, repository.ErrProfileNotFound) {
+		return err
+	}
+
+	schema := model.StatSchemaForPosition(position)
+	if stats.CleanSheets != nil && !schema.CleanSheets {
+		return fmt.Errorf("%w: clean_sheets is not tracked for position %q", ErrInvalidSeasonStats, position)
+	}
+	if stats.GoalsConceded != nil && !schema.GoalsConceded {
+		return fmt.Errorf("%w: goals_conceded is only tracked for goalkeepers", ErrInvalidSeasonStats)
+	}
+	if stats.Saves != nil && !schema.Saves {
+		return fmt.Errorf("%w: saves is only tracked for goalkeepers", ErrInvalidSeasonStats)
+	}
+	if stats.CleanSheets != nil && *stats.CleanSheets > stats.Appearances {
+		return fmt.Errorf("%w: clean sheets exceed appearances", ErrInvalidSeasonStats)
+	}
+
+	return nil
+}
+
+// touchCareerEntry records that an entry's stats changed, which sends the
+// entry back to the club for review.
+func (s *ProfileService) touchCareerEntry(ctx context.Context, entry *model.CareerEntry) error {
+	markCareerEdited(entry)
+	return s.repo.UpdateCareerEntry(ctx, entry)
+}
+
+// markCareerEdited clears the club's confirmation or rejection after the
+// player changes an entry.
+func markCareerEdited(entry *model.CareerEntry) {
+	entry.UpdatedAt = time.Now()
+	entry.ConfirmedAt = nil
+	entry.ConfirmedBy = nil
+	entry.RejectedAt = nil
+	entry.RejectedBy = nil
+}
+
+func validateCareerEntry(entry *model.CareerEntry) error {
+	if entry.EndDate != nil && entry.EndDate.Before(entry.StartDate) {
+		return fmt.Errorf("%w: end_date is before start_date", ErrInvalidCareerEntry)
+	}
+	if entry.IsCurrent && entry.EndDate != nil {
+		return fmt.Errorf("%w: current entries cannot have an end_date", ErrInvalidCareerEntry)
+	}
+	if entry.StartDate.After(time.Now()) {
+		return fmt.Errorf("%w: start_date is in the future", ErrInvalidCareerEntry)
+	}
+	return nil
+}
+
+func applySeasonStatsRequest(stats *model.SeasonStats, req model.SeasonStatsRequest, now time.Time) {
+	stats.Season = req.Season
+	stats.Competition = req.Competition
+	stats.Appearances = req.Appearances
+	stats.MinutesPlayed = req.MinutesPlayed
+	stats.Goals = req.Goals
+	stats.Assists = req.Assists
+	stats.YellowCards = req.YellowCards
+	stats.RedCards = req.RedCards
+	stats.CleanSheets = req.CleanSheets
+	stats.GoalsConceded = req.GoalsConceded
+	stats.Saves = req.Saves
+	stats.UpdatedAt = now
+}
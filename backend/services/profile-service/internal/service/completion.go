@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var ErrInvalidSignal = errors.New("invalid completion signal")
+
+const completionRescoreBatch = 200
+
+// GetCompletionChecklist returns the profile's completion score and the
+// items behind it. Only the owner and admins may read it.
+func (s *ProfileService) GetCompletionChecklist(ctx context.Context, viewer model.Viewer, profileID string) (*model.CompletionChecklist, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEditProfile(viewer, profile) {
+		return nil, ErrForbidden
+	}
+
+	return buildChecklist(ctx, s.repo, profile)
+}
+
+func buildChecklist(ctx context.Context, repo *repository.ProfileRepository, profile *model.Profile) (*model.CompletionChecklist, error) {
+	facts, err := repo.GetCompletionFacts(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateChecklist(profile, facts), nil
+}
+
+// evaluateChecklist scores profile against its type's CompletionRules.
+func evaluateChecklist(profile *model.Profile, facts *model.CompletionFacts) *model.CompletionChecklist {
+	checklist := &model.CompletionChecklist{
+		ProfileID: profile.ID,
+		Type:      profile.Type,
+		Items:     []model.CompletionItem{},
+		Missing:   []model.CompletionItem{},
+	}
+
+	for _, rule := range model.CompletionRules[profile.Type] {
+		item := model.CompletionItem{CompletionRule: rule, Done: completionItemDone(rule.Key, profile, facts)}
+		checklist.Items = append(checklist.Items, item)
+		if item.Done {
+			checklist.Score += rule.Weight
+		} else {
+			checklist.Missing = append(checklist.Missing, item)
+		}
+	}
+
+	sort.SliceStable(checklist.Missing, func(i, j int) bool {
+		return checklist.Missing[i].Weight > checklist.Missing[j].Weight
+	})
+	return checklist
+}
+
+func completionItemDone(key string, profile *model.Profile, facts *model.CompletionFacts) bool {
+	switch key {
+	case model.CompletionDisplayName:
+		return profile.DisplayName != ""
+	case model.CompletionAvatar:
+		return derefString(profile.AvatarURL) != ""
+	case model.CompletionBio:
+		return derefString(profile.Bio) != ""
+	case model.CompletionLocation:
+		return derefString(profile.LocationCountry) != "" && derefString(profile.LocationCity) != ""
+	case model.CompletionContact:
+		return derefString(profile.ContactEmail) != "" || derefString(profile.ContactPhone) != ""
+	case model.CompletionPlayerDetails:
+		return facts.HasPlayerDetails
+	case model.CompletionPhysicals:
+		return facts.HasPhysicals
+	case model.CompletionCareer:
+		return facts.CareerEntries > 0
+	case model.CompletionFirstVideo:
+		return facts.Videos > 0
+	case model.CompletionStaff:
+		return facts.StaffMembers > 0
+	case model.CompletionTrustVerified:
+		return profile.TrustLevel == model.TrustLevelVerified || profile.TrustLevel == model.TrustLevelPro
+	default:
+		return false
+	}
+}
+
+// refreshCompletionScore recalculates profile.ProfileCompletionScore from
+// the checklist. The caller stores the profile.
+func refreshCompletionScore(ctx context.Context, repo *repository.ProfileRepository, profile *model.Profile) error {
+	checklist, err := buildChecklist(ctx, repo, profile)
+	if err != nil {
+		return err
+	}
+	profile.ProfileCompletionScore = checklist.Score
+	return nil
+}
+
+// storeCompletionScore recalculates and stores the score of a profile after
+// a change that does not otherwise update the profile row.
+func (s *ProfileService) storeCompletionScore(ctx context.Context, profile *model.Profile) error {
+	if err := refreshCompletionScore(ctx, s.repo, profile); err != nil {
+		return err
+	}
+	return s.repo.UpdateCompletionScore(ctx, profile.ID, profile.ProfileCompletionScore)
+}
+
+// RescoreProfiles recalculates every profile's stored completion score,
+// for scores written before the checklist rules last changed. It returns
+// the number of profiles whose score changed.
+func (s *ProfileService) RescoreProfiles(ctx context.Context) (int, error) {
+	updated := 0
+	afterID := "00000000-0000-0000-0000-000000000000"
+
+	for {
+		profiles, err := s.repo.ListProfilesAfter(ctx, afterID, completionRescoreBatch)
+		if err != nil {
+			return updated, err
+		}
+		if len(profiles) == 0 {
+			return updated, nil
+		}
+
+		for _, profile := range profiles {
+			afterID = profile.ID
+			stored := profile.ProfileCompletionScore
+			if err := refreshCompletionScore(ctx, s.repo, profile); err != nil {
+				return updated, err
+			}
+			if profile.ProfileCompletionScore == stored {
+				continue
+			}
+			if err := s.repo.UpdateCompletionScore(ctx, profile.ID, profile.ProfileCompletionScore); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+	}
+}
+
+// RecordVideoUploaded credits an uploaded video towards the owning
+// profile's completion. Redelivered events are ignored.
+func (s *ProfileService) RecordVideoUploaded(ctx context.Context, event model.VideoEvent) error {
+	return s.recordVideoSignal(ctx, event, true)
+}
+
+// RecordVideoDeleted withdraws the credit for a deleted video.
+func (s *ProfileService) RecordVideoDeleted(ctx context.Context, event model.VideoEvent) error {
+	return s.recordVideoSignal(ctx, event, false)
+}
+
+func (s *ProfileService) recordVideoSignal(ctx context.Context, event model.VideoEvent, present bool) error {
+	if event.ProfileID == "" || event.VideoID == "" {
+		return fmt.Errorf("%w: profile_id and video_id are required", ErrInvalidSignal)
+	}
+
+	profile, err := s.repo.GetByID(ctx, event.ProfileID)
+	if err != nil {
+		return err
+	}
+
+	signal := &model.CompletionSignal{
+		UserID:    profile.UserID,
+		Signal:    model.SignalVideoUploaded,
+		RefID:     event.VideoID,
+		CreatedAt: time.Now(),
+	}
+	return s.applySignal(ctx, profile, signal, present)
+}
+
+// applySignal records or removes signal and, when profile is not nil and
+// the signal changed, refreshes its stored completion score.
+func (s *ProfileService) applySignal(ctx context.Context, profile *model.Profile, signal *model.CompletionSignal, present bool) error {
+	var changed bool
+	var err error
+	if present {
+		changed, err = s.repo.CreateCompletionSignal(ctx, signal)
+	} else {
+		changed, err = s.repo.DeleteCompletionSignal(ctx, signal.UserID, signal.Signal, signal.RefID)
+	}
+	if err != nil || !changed || profile == nil {
+		return err
+	}
+
+	return s.storeCompletionScore(ctx, profile)
+}
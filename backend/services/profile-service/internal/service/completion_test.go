@@ -0,0 +1,150 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func TestCompletionRuleWeights(t *testing.T) {
+	for userType, rules := range model.CompletionRules {
+		total := 0
+		for _, rule := range rules {
+			total += rule.Weight
+		}
+		if total != 100 {
+			t.Errorf("%s weights sum to %d, want 100", userType, total)
+		}
+	}
+}
+
+func TestEvaluateChecklist(t *testing.T) {
+	complete := func(userType model.UserType) *model.Profile {
+		return &model.Profile{
+			ID:              "5d1e7a3c-9b2f-4c68-a0e4-7f3b1c9d2e55",
+			Type:            userType,
+			DisplayName:     "Jo Silva",
+			AvatarURL:       ptr("https://cdn.example.com/a.jpg"),
+			Bio:             ptr("Left-footed winger"),
+			LocationCountry: ptr("PT"),
+			LocationCity:    ptr("Porto"),
+			ContactEmail:    ptr("jo@example.com"),
+			TrustLevel:      model.TrustLevelVerified,
+		}
+	}
+	allFacts := &model.CompletionFacts{
+		HasPlayerDetails: true,
+		HasPhysicals:     true,
+		CareerEntries:    2,
+		StaffMembers:     1,
+		Videos:           3,
+	}
+
+	tests := []struct {
+		name    string
+		profile *model.Profile
+		facts   *model.CompletionFacts
+		score   int
+		missing []string
+	}{
+		{
+			name:    "complete player",
+			profile: complete(model.UserTypePlayer),
+			facts:   allFacts,
+			score:   100,
+		},
+		{
+			name:    "complete scout",
+			profile: complete(model.UserTypeScout),
+			facts:   &model.CompletionFacts{},
+			score:   100,
+		},
+		{
+			name:    "complete academy",
+			profile: complete(model.UserTypeAcademy),
+			facts:   allFacts,
+			score:   100,
+		},
+		{
+			name:    "empty player, heaviest missing first",
+			profile: &model.Profile{Type: model.UserTypePlayer},
+			facts:   &model.CompletionFacts{},
+			score:   0,
+			missing: []string{
+				model.CompletionFirstVideo,
+				model.CompletionPlayerDetails,
+				model.CompletionAvatar,
+				model.CompletionBio,
+				model.CompletionLocation,
+				model.CompletionContact,
+				model.CompletionPhysicals,
+				model.CompletionCareer,
+				model.CompletionDisplayName,
+			},
+		},
+		{
+			name: "location needs city and country",
+			profile: func() *model.Profile {
+				p := complete(model.UserTypeScout)
+				p.LocationCity = nil
+				return p
+			}(),
+			facts:   &model.CompletionFacts{},
+			score:   85,
+			missing: []string{model.CompletionLocation},
+		},
+		{
+			name: "contact by phone",
+			profile: func() *model.Profile {
+				p := complete(model.UserTypeScout)
+				p.ContactEmail = nil
+				p.ContactPhone = ptr("+351 900 000 000")
+				return p
+			}(),
+			facts: &model.CompletionFacts{},
+			score: 100,
+		},
+		{
+			name: "unverified scout",
+			profile: func() *model.Profile {
+				p := complete(model.UserTypeScout)
+				p.TrustLevel = model.TrustLevelEstablished
+				return p
+			}(),
+			facts:   &model.CompletionFacts{},
+			score:   75,
+			missing: []string{model.CompletionTrustVerified},
+		},
+		{
+			name:    "player without physicals or videos",
+			profile: complete(model.UserTypePlayer),
+			facts:   &model.CompletionFacts{HasPlayerDetails: true, CareerEntries: 1},
+			score:   70,
+			missing: []string{model.CompletionFirstVideo, model.CompletionPhysicals},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklist := evaluateChecklist(tt.profile, tt.facts)
+			if checklist.Score != tt.score {
+				t.Errorf("score = %d, want %d", checklist.Score, tt.score)
+			}
+			if got, want := len(checklist.Items), len(model.CompletionRules[tt.profile.Type]); got != want {
+				t.Errorf("items = %d, want %d", got, want)
+			}
+			var missing []string
+			for _, item := range checklist.Missing {
+				missing = append(missing, item.Key)
+			}
+			if len(missing) != len(tt.missing) {
+				t.Fatalf("missing = %v, want %v", missing, tt.missing)
+			}
+			for i := range missing {
+				if missing[i] != tt.missing[i] {
+					t.Fatalf("missing = %v, want %v", missing, tt.missing)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var ErrInvalidEndorsement = errors.New("invalid endorsement")
+
+// EndorsePlayer records an endorsement of profileID's skill or trait by the
+// caller. Only verified scouts, coaches and academies may endorse, and each
+// may endorse a given skill once. New endorsements stay pending until the
+// player accepts them.
+func (s *ProfileService) EndorsePlayer(ctx context.Context, viewer model.Viewer, profileID string, req model.CreateEndorsementRequest) (*model.Endorsement, error) {
+	if !model.ValidEndorsementSkill(req.Skill) {
+		return nil, fmt.Errorf("%w: unknown skill or trait %q", ErrInvalidEndorsement, req.Skill)
+	}
+
+	endorser, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEndorse(endorser) {
+		return nil, ErrForbidden
+	}
+	if _, err := s.requireVisiblePlayer(ctx, viewer, profileID); err != nil {
+		return nil, err
+	}
+
+	blocked, err := s.repo.IsBlockedEither(ctx, endorser.ID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, ErrBlocked
+	}
+
+	now := time.Now()
+	endorsement := &model.Endorsement{
+		ID:                 uuid.New().String(),
+		PlayerID:           profileID,
+		EndorserID:         endorser.ID,
+		Skill:              req.Skill,
+		Comment:            req.Comment,
+		EndorserTrustLevel: endorser.TrustLevel,
+		Status:             model.EndorsementStatusPending,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.CreateEndorsement(ctx, endorsement); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, repo, model.EventProfileEndorsed, profileID, endorsement)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetEndorsement(ctx, endorsement.ID)
+}
+
+// ListEndorsements returns a player's endorsements. The player and admins
+// also see pending and hidden ones; everyone else sees accepted ones only.
+func (s *ProfileService) ListEndorsements(ctx context.Context, viewer model.Viewer, profileID string) ([]*model.Endorsement, error) {
+	player, err := s.requireVisiblePlayer(ctx, viewer, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []model.EndorsementStatus{model.EndorsementStatusAccepted}
+	if canEditProfile(viewer, player) {
+		statuses = append(statuses, model.EndorsementStatusPending, model.EndorsementStatusHidden)
+	}
+	return s.repo.ListEndorsements(ctx, profileID, statuses)
+}
+
+// RespondToEndorsement lets the endorsed player accept or hide an
+// endorsement. Either choice can be changed later.
+func (s *ProfileService) RespondToEndorsement(ctx context.Context, viewer model.Viewer, profileID, endorsementID string, req model.RespondEndorsementRequest) (*model.Endorsement, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner(viewer, profile) {
+		return nil, ErrForbidden
+	}
+
+	endorsement, err := s.getPlayerEndorsement(ctx, profileID, endorsementID)
+	if err != nil {
+		return nil, err
+	}
+	if endorsement.Status == req.Status {
+		return endorsement, nil
+	}
+
+	now := time.Now()
+	endorsement.Status = req.Status
+	endorsement.RespondedAt = &now
+	endorsement.UpdatedAt = now
+	if err := s.repo.UpdateEndorsement(ctx, endorsement); err != nil {
+		return nil, err
+	}
+
+	return endorsement, nil
+}
+
+// RevokeEndorsement withdraws an endorsement. Only the endorser and admins
+// may revoke; the endorser can endorse the same skill again later.
+func (s *ProfileService) RevokeEndorsement(ctx context.Context, viewer model.Viewer, profileID, endorsementID string) error {
+	endorsement, err := s.getPlayerEndorsement(ctx, profileID, endorsementID)
+	if err != nil {
+		return err
+	}
+	if !viewer.IsAdmin() {
+		endorser, err := s.actorProfile(ctx, viewer.UserID)
+		if err != nil {
+			return err
+		}
+		if endorser.ID != endorsement.EndorserID {
+			return ErrForbidden
+		}
+	}
+
+	now := time.Now()
+	endorsement.RevokedAt = &now
+	endorsement.UpdatedAt = now
+
+	return s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.UpdateEndorsement(ctx, endorsement); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, repo, model.EventEndorsementRevoked, profileID, endorsement)
+	})
+}
+
+// getPlayerEndorsement loads an unrevoked endorsement and checks that it
+// belongs to profileID.
+func (s *ProfileService) getPlayerEndorsement(ctx context.Context, profileID, endorsementID string) (*model.Endorsement, error) {
+	endorsement, err := s.repo.GetEndorsement(ctx, endorsementID)
+	if err != nil {
+		return nil, err
+	}
+	if endorsement.PlayerID != profileID || endorsement.RevokedAt != nil {
+		return nil, repository.ErrEndorsementNotFound
+	}
+	return endorsement, nil
+}
+
+// canEndorse reports whether profile may endorse players: an active scout
+// (which includes coaches) or academy at the verified or pro trust level.
+func canEndorse(profile *model.Profile) bool {
+	if !isActive(profile) {
+		return false
+	}
+	if profile.Type != model.UserTypeScout && profile.Type != model.UserTypeAcademy {
+		return false
+	}
+	return profile.TrustLevel == model.TrustLevelVerified || profile.TrustLevel == model.TrustLevelPro
+}
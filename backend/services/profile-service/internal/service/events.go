@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+const eventSource = "profile-service"
+
+// enqueueEvent records a profile event in the outbox. repo must be bound to
+// the transaction that performs the change so the two commit together.
+func enqueueEvent(ctx context.Context, repo *repository.ProfileRepository, eventType, profileID string, snapshot any) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s snapshot: %w", eventType, err)
+	}
+
+	event := &model.Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		Version:    model.EventSchemaVersion,
+		Source:     eventSource,
+		ProfileID:  profileID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+
+	return repo.EnqueueEvent(ctx, event)
+}
+
+// enqueuePlayerDetailsUpdated snapshots the full player profile as seen by
+// repo and records a profile.player_details.updated event.
+func enqueuePlayerDetailsUpdated(ctx context.Context, repo *repository.ProfileRepository, profileID string) (*model.PlayerProfile, error) {
+	player, err := repo.GetPlayerProfile(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enqueueEvent(ctx, repo, model.EventProfilePlayerDetailsUpdated, profileID, player); err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var (
+	ErrBlocked       = errors.New("profile is blocked")
+	ErrSelfRelation  = errors.New("cannot follow, block or mute your own profile")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Follow makes the caller's profile follow profileID. Following an already
+// followed profile is a no-op.
+func (s *ProfileService) Follow(ctx context.Context, viewer model.Viewer, profileID string) (*model.Relationship, error) {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.ID == profileID {
+		return nil, ErrSelfRelation
+	}
+	if _, err := s.visibleProfile(ctx, viewer, profileID); err != nil {
+		return nil, err
+	}
+
+	follow := &model.Follow{
+		FollowerID: actor.ID,
+		FolloweeID: profileID,
+		CreatedAt:  time.Now(),
+	}
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		// Check for a block under the pair lock Block also takes, so a
+		// concurrent block cannot leave a follow behind
+		if err := repo.LockProfilePair(ctx, actor.ID, profileID); err != nil {
+			return err
+		}
+		blocked, err := repo.IsBlockedEither(ctx, actor.ID, profileID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrBlocked
+		}
+
+		created, err := repo.CreateFollow(ctx, follow)
+		if err != nil || !created {
+			return err
+		}
+		return enqueueEvent(ctx, repo, model.EventProfileFollowed, profileID, follow)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRelationship(ctx, actor.ID, profileID)
+}
+
+func (s *ProfileService) Unfollow(ctx context.Context, viewer model.Viewer, profileID string) error {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		return removeFollow(ctx, repo, actor.ID, profileID)
+	})
+}
+
+// Block removes follows in both directions, stops either profile from
+// following the other and hides each from the other until unblocked.
+func (s *ProfileService) Block(ctx context.Context, viewer model.Viewer, profileID string) (*model.Relationship, error) {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.ID == profileID {
+		return nil, ErrSelfRelation
+	}
+	if _, err := s.repo.GetByID(ctx, profileID); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.LockProfilePair(ctx, actor.ID, profileID); err != nil {
+			return err
+		}
+		if _, err := repo.CreateBlock(ctx, actor.ID, profileID, time.Now()); err != nil {
+			return err
+		}
+		if err := removeFollow(ctx, repo, actor.ID, profileID); err != nil {
+			return err
+		}
+		return removeFollow(ctx, repo, profileID, actor.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRelationship(ctx, actor.ID, profileID)
+}
+
+func (s *ProfileService) Unblock(ctx context.Context, viewer model.Viewer, profileID string) error {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteBlock(ctx, actor.ID, profileID)
+}
+
+// Mute hides profileID's activity from the caller without unfollowing.
+// Feed and notification consumers read profile_mutes.
+func (s *ProfileService) Mute(ctx context.Context, viewer model.Viewer, profileID string) (*model.Relationship, error) {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.ID == profileID {
+		return nil, ErrSelfRelation
+	}
+	if _, err := s.repo.GetByID(ctx, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateMute(ctx, actor.ID, profileID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRelationship(ctx, actor.ID, profileID)
+}
+
+func (s *ProfileService) Unmute(ctx context.Context, viewer model.Viewer, profileID string) error {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteMute(ctx, actor.ID, profileID)
+}
+
+// GetRelationship describes how the caller's profile relates to profileID.
+func (s *ProfileService) GetRelationship(ctx context.Context, viewer model.Viewer, profileID string) (*model.Relationship, error) {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := s.repo.GetRelationship(ctx, actor.ID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	// Profiles the caller blocked are hidden from them everywhere else, but
+	// the caller still needs to see the block to manage it
+	if !rel.Blocking {
+		if _, err := s.visibleProfile(ctx, viewer, profileID); err != nil {
+			return nil, err
+		}
+	}
+	return rel, nil
+}
+
+// ListFollowers and ListFollowing only include profiles the viewer could
+// open themselves, leaving out profiles blocked with the viewer.
+func (s *ProfileService) ListFollowers(ctx context.Context, viewer model.Viewer, profileID, cursor string, limit int) (*model.ConnectionPage, error) {
+	if _, err := s.visibleProfile(ctx, viewer, profileID); err != nil {
+		return nil, err
+	}
+	return connectionPage(cursor, limit, func(c *model.ConnectionCursor, n int) ([]*model.Connection, error) {
+		return s.repo.ListFollowers(ctx, profileID, viewer.Audiences(), viewer.UserID, c, n)
+	})
+}
+
+func (s *ProfileService) ListFollowing(ctx context.Context, viewer model.Viewer, profileID, cursor string, limit int) (*model.ConnectionPage, error) {
+	if _, err := s.visibleProfile(ctx, viewer, profileID); err != nil {
+		return nil, err
+	}
+	return connectionPage(cursor, limit, func(c *model.ConnectionCursor, n int) ([]*model.Connection, error) {
+		return s.repo.ListFollowing(ctx, profileID, viewer.Audiences(), viewer.UserID, c, n)
+	})
+}
+
+func (s *ProfileService) ListBlocks(ctx context.Context, viewer model.Viewer, cursor string, limit int) (*model.ConnectionPage, error) {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return connectionPage(cursor, limit, func(c *model.ConnectionCursor, n int) ([]*model.Connection, error) {
+		return s.repo.ListBlocks(ctx, actor.ID, c, n)
+	})
+}
+
+func (s *ProfileService) ListMutes(ctx context.Context, viewer model.Viewer, cursor string, limit int) (*model.ConnectionPage, error) {
+	actor, err := s.actorProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return connectionPage(cursor, limit, func(c *model.ConnectionCursor, n int) ([]*model.Connection, error) {
+		return s.repo.ListMutes(ctx, actor.ID, c, n)
+	})
+}
+
+// actorProfile resolves the caller's own profile. Users without a profile
+// cannot take part in the graph.
+func (s *ProfileService) actorProfile(ctx context.Context, userID string) (*model.Profile, error) {
+	profile, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			return nil, ErrForbidden
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+func removeFollow(ctx context.Context, repo *repository.ProfileRepository, followerID, followeeID string) error {
+	deleted, err := repo.DeleteFollow(ctx, followerID, followeeID)
+	if err != nil || !deleted {
+		return err
+	}
+	follow := &model.Follow{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		CreatedAt:  time.Now(),
+	}
+	return enqueueEvent(ctx, repo, model.EventProfileUnfollowed, followeeID, follow)
+}
+
+// connectionPage fetches one extra row to tell whether another page exists.
+func connectionPage(cursor string, limit int, fetch func(*model.ConnectionCursor, int) ([]*model.Connection, error)) (*model.ConnectionPage, error) {
+	after, err := decodeConnectionCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := fetch(after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.ConnectionPage{Connections: connections}
+	if len(connections) > limit {
+		page.Connections = connections[:limit]
+		last := page.Connections[limit-1]
+		page.NextCursor = encodeConnectionCursor(last.Since, last.Profile.ID)
+	}
+	return page, nil
+}
+
+func encodeConnectionCursor(since time.Time, profileID string) string {
+	raw := strconv.FormatInt(since.UnixMicro(), 10) + ":" + profileID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeConnectionCursor(cursor string) (*model.ConnectionCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	micros, profileID, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	ts, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(profileID); err != nil {
+		return nil, fmt.Errorf("%w: bad profile id", ErrInvalidCursor)
+	}
+
+	return &model.ConnectionCursor{
+		Since:     time.UnixMicro(ts).UTC(),
+		ProfileID: profileID,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDecodeConnectionCursor(t *testing.T) {
+	const profileID = "3f0c6a52-8d1e-4b7a-9c2f-5e6d7a8b9c0d"
+	since := time.Date(2024, 3, 9, 14, 30, 15, 123456000, time.UTC)
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+		want   *time.Time
+		err    error
+	}{
+		{
+			name:   "empty",
+			cursor: "",
+		},
+		{
+			name:   "round trip",
+			cursor: encodeConnectionCursor(since, profileID),
+			want:   &since,
+		},
+		{
+			name:   "not base64",
+			cursor: "not a cursor!",
+			err:    ErrInvalidCursor,
+		},
+		{
+			name:   "padded base64",
+			cursor: base64.URLEncoding.EncodeToString([]byte("1:" + profileID)),
+			err:    ErrInvalidCursor,
+		},
+		{
+			name:   "missing separator",
+			cursor: encode("1710000000000000"),
+			err:    ErrInvalidCursor,
+		},
+		{
+			name:   "bad timestamp",
+			cursor: encode("yesterday:" + profileID),
+			err:    ErrInvalidCursor,
+		},
+		{
+			name:   "bad profile id",
+			cursor: encode("1710000000000000:42"),
+			err:    ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeConnectionCursor(tt.cursor)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("cursor = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("cursor = nil")
+			}
+			if !got.Since.Equal(*tt.want) {
+				t.Errorf("since = %v, want %v", got.Since, *tt.want)
+			}
+			if got.ProfileID != profileID {
+				t.Errorf("profile_id = %s, want %s", got.ProfileID, profileID)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+// GetProfileHistory pages through a profile's change log. Only the owner and
+// admins may read it; admins can still read the anonymized log of a purged
+// profile.
+func (s *ProfileService) GetProfileHistory(ctx context.Context, viewer model.Viewer, profileID, field, cursor string, limit int) (*model.ProfileChangePage, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	switch {
+	case errors.Is(err, repository.ErrProfileNotFound) && viewer.IsAdmin():
+	case err != nil:
+		return nil, err
+	case !canEditProfile(viewer, profile):
+		return nil, ErrForbidden
+	}
+
+	after, err := decodeChangeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.repo.ListProfileChanges(ctx, profileID, field, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.ProfileChangePage{Changes: changes}
+	if len(changes) > limit {
+		page.Changes = changes[:limit]
+		last := page.Changes[limit-1]
+		page.NextCursor = encodeConnectionCursor(last.CreatedAt, last.ID)
+	}
+	return page, nil
+}
+
+// decodeChangeCursor reads cursors written by encodeConnectionCursor, which
+// fits any (timestamp, uuid) keyset.
+func decodeChangeCursor(cursor string) (*model.ChangeCursor, error) {
+	after, err := decodeConnectionCursor(cursor)
+	if err != nil || after == nil {
+		return nil, err
+	}
+	return &model.ChangeCursor{CreatedAt: after.Since, ID: after.ProfileID}, nil
+}
+
+// UpdatePlayerDetails edits a player's details. Changing a verified field
+// clears the verification so scouts never see edited data as verified.
+func (s *ProfileService) UpdatePlayerDetails(ctx context.Context, viewer model.Viewer, profileID string, req model.UpdatePlayerDetailsRequest) (*model.PlayerProfile, error) {
+	player, err := s.repo.GetPlayerProfile(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEditProfile(viewer, &player.Profile) {
+		return nil, ErrForbidden
+	}
+
+	before := player.PlayerDetails
+	details := player.PlayerDetails
+	if req.Position != nil {
+		details.Position = *req.Position
+	}
+	if req.DateOfBirth != nil {
+		details.DateOfBirth = req.DateOfBirth
+	}
+	if req.HeightCM != nil {
+		details.HeightCM = req.HeightCM
+	}
+	if req.WeightKG != nil {
+		details.WeightKG = req.WeightKG
+	}
+	if req.PreferredFoot != nil {
+		details.PreferredFoot = req.PreferredFoot
+	}
+	if req.CurrentTeam != nil {
+		details.CurrentTeam = req.CurrentTeam
+	}
+
+	changes, err := diffFields(playerAuditFields(&before), playerAuditFields(&details))
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return s.GetPlayerProfile(ctx, viewer, profileID)
+	}
+	if details.VerifiedAt != nil && touchesVerifiedField(changes) {
+		details.VerifiedAt = nil
+		details.VerifiedBy = nil
+		changes, err = diffFields(playerAuditFields(&before), playerAuditFields(&details))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	profile := &player.Profile
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.UpdatePlayerDetails(ctx, &details); err != nil {
+			return err
+		}
+		if err := recordChange(ctx, repo, profileID, viewer, model.ChangeActionPlayerDetailsUpdated, changes); err != nil {
+			return err
+		}
+
+		if err := refreshCompletionScore(ctx, repo, profile); err != nil {
+			return err
+		}
+		profile.UpdatedAt = time.Now()
+		if err := repo.Update(ctx, profile); err != nil {
+			return err
+		}
+
+		player, err = enqueuePlayerDetailsUpdated(ctx, repo, profileID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	redactPlayerProfile(viewer, player)
+	return player, nil
+}
+
+// VerifyPlayerDetails records that an admin checked the player's verified
+// fields against documents.
+func (s *ProfileService) VerifyPlayerDetails(ctx context.Context, viewer model.Viewer, profileID string) (*model.PlayerProfile, error) {
+	if !viewer.IsAdmin() {
+		return nil, ErrForbidden
+	}
+
+	now := time.Now()
+	return s.setPlayerVerification(ctx, viewer, profileID, model.ChangeActionPlayerVerified, &now, &viewer.UserID)
+}
+
+// RevokePlayerVerification clears a player's verification.
+func (s *ProfileService) RevokePlayerVerification(ctx context.Context, viewer model.Viewer, profileID string) (*model.PlayerProfile, error) {
+	if !viewer.IsAdmin() {
+		return nil, ErrForbidden
+	}
+
+	return s.setPlayerVerification(ctx, viewer, profileID, model.ChangeActionVerificationRevoked, nil, nil)
+}
+
+func (s *ProfileService) setPlayerVerification(ctx context.Context, viewer model.Viewer, profileID, action string, verifiedAt *time.Time, verifiedBy *string) (*model.PlayerProfile, error) {
+	player, err := s.repo.GetPlayerProfile(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := player.PlayerDetails
+	details.VerifiedAt = verifiedAt
+	details.VerifiedBy = verifiedBy
+
+	changes, err := diffFields(playerAuditFields(&player.PlayerDetails), playerAuditFields(&details))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.UpdatePlayerDetails(ctx, &details); err != nil {
+			return err
+		}
+		if err := recordChange(ctx, repo, profileID, viewer, action, changes); err != nil {
+			return err
+		}
+
+		player, err = enqueuePlayerDetailsUpdated(ctx, repo, profileID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
+
+func canEditProfile(viewer model.Viewer, profile *model.Profile) bool {
+	return isOwner(viewer, profile) || viewer.IsAdmin()
+}
+
+func touchesVerifiedField(changes []model.FieldChange) bool {
+	for _, change := range changes {
+		if slices.Contains(model.VerifiedPlayerFields, change.Field) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordChange appends changes to the change log. An actor without a user
+// ID is the system. Empty change sets are not recorded.
+func recordChange(ctx context.Context, repo *repository.ProfileRepository, profileID string, actor model.Viewer, action string, changes []model.FieldChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	change := &model.ProfileChange{
+		ID:        uuid.New().String(),
+		ProfileID: profileID,
+		Action:    action,
+		ActorRole: actor.Role,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+	if actor.UserID != "" {
+		change.ActorUserID = &actor.UserID
+	}
+	if change.ActorRole == "" {
+		change.ActorRole = model.ActorSystem
+	}
+
+	return repo.CreateProfileChange(ctx, change)
+}
+
+// diffFields compares two field snapshots by their JSON encoding and returns
+// the fields that differ, ordered by name.
+func diffFields(before, after map[string]any) ([]model.FieldChange, error) {
+	fields := make([]string, 0, len(after))
+	for field := range after {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	changes := []model.FieldChange{}
+	for _, field := range fields {
+		oldValue, err := json.Marshal(before[field])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", field, err)
+		}
+		newValue, err := json.Marshal(after[field])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", field, err)
+		}
+		if !bytes.Equal(oldValue, newValue) {
+			changes = append(changes, model.FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	return changes, nil
+}
+
+// profileAuditFields are the owner-editable profile fields tracked in the
+// change log. Derived fields such as coordinates and scores are left out.
+func profileAuditFields(profile *model.Profile) map[string]any {
+	return map[string]any{
+		"display_name":     profile.DisplayName,
+		"bio":              profile.Bio,
+		"avatar_url":       profile.AvatarURL,
+		"location_country": profile.LocationCountry,
+		"location_city":    profile.LocationCity,
+		"contact_email":    profile.ContactEmail,
+		"contact_phone":    profile.ContactPhone,
+		"primary_language": profile.PrimaryLanguage,
+	}
+}
+
+func privacyAuditFields(privacy model.PrivacySettings) map[string]any {
+	return map[string]any{
+		"visibility":               privacy.Visibility,
+		"city_visibility":          privacy.CityVisibility,
+		"contact_visibility":       privacy.ContactVisibility,
+		"date_of_birth_visibility": privacy.DateOfBirthVisibility,
+	}
+}
+
+func playerAuditFields(details *model.PlayerDetails) map[string]any {
+	return map[string]any{
+		"position":       details.Position,
+		"date_of_birth":  details.DateOfBirth,
+		"height_cm":      details.HeightCM,
+		"weight_kg":      details.WeightKG,
+		"preferred_foot": details.PreferredFoot,
+		"current_team":   details.CurrentTeam,
+		"verified_at":    details.VerifiedAt,
+		"verified_by":    details.VerifiedBy,
+	}
+}
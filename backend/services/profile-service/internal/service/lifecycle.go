@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var (
+	ErrProfileState   = errors.New("profile status does not allow this")
+	ErrRestoreExpired = errors.New("restore period has ended")
+)
+
+const (
+	// DeactivationGracePeriod is how long an owner can restore a
+	// deactivated profile before it is deleted for good.
+	DeactivationGracePeriod = 30 * 24 * time.Hour
+	purgeBatchSize          = 50
+)
+
+// DeactivateProfile hides the caller's own profile everywhere and schedules
+// it for deletion after DeactivationGracePeriod.
+func (s *ProfileService) DeactivateProfile(ctx context.Context, viewer model.Viewer, profileID string, req model.DeactivateProfileRequest) (*model.Profile, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner(viewer, profile) {
+		return nil, ErrForbidden
+	}
+	if profile.Status != model.ProfileStatusActive {
+		return nil, ErrProfileState
+	}
+
+	now := time.Now()
+	purgeAfter := now.Add(DeactivationGracePeriod)
+	profile.DeactivationReason = req.Reason
+	profile.PurgeAfter = &purgeAfter
+
+	return s.changeStatus(ctx, viewer, profile, model.ProfileStatusDeactivated, model.ChangeActionProfileDeactivated, model.EventProfileDeactivated, now)
+}
+
+// TakedownProfile hides a profile on an admin's decision. Taken down
+// profiles are not purged and only an admin can reinstate them.
+func (s *ProfileService) TakedownProfile(ctx context.Context, viewer model.Viewer, profileID string, req model.TakedownProfileRequest) (*model.Profile, error) {
+	if !viewer.IsAdmin() {
+		return nil, ErrForbidden
+	}
+
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Status == model.ProfileStatusTakenDown {
+		return nil, ErrProfileState
+	}
+
+	profile.DeactivationReason = &req.Reason
+	profile.PurgeAfter = nil
+
+	return s.changeStatus(ctx, viewer, profile, model.ProfileStatusTakenDown, model.ChangeActionProfileTakenDown, model.EventProfileDeactivated, time.Now())
+}
+
+// RestoreProfile reactivates a profile. Owners can restore their own
+// deactivated profile within the grace period; admins can also reinstate
+// taken down profiles.
+func (s *ProfileService) RestoreProfile(ctx context.Context, viewer model.Viewer, profileID string) (*model.Profile, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case viewer.IsAdmin():
+		if profile.Status == model.ProfileStatusActive {
+			return nil, ErrProfileState
+		}
+	case isOwner(viewer, profile):
+		if profile.Status != model.ProfileStatusDeactivated {
+			return nil, ErrProfileState
+		}
+		if profile.PurgeAfter != nil && !now.Before(*profile.PurgeAfter) {
+			return nil, ErrRestoreExpired
+		}
+	default:
+		return nil, ErrForbidden
+	}
+
+	profile.DeactivationReason = nil
+	profile.PurgeAfter = nil
+
+	return s.changeStatus(ctx, viewer, profile, model.ProfileStatusActive, model.ChangeActionProfileRestored, model.EventProfileRestored, now)
+}
+
+// changeStatus moves profile to status, logging the change and publishing
+// eventType so discovery and media-service can hide or show its content.
+func (s *ProfileService) changeStatus(ctx context.Context, actor model.Viewer, profile *model.Profile, status model.ProfileStatus, action, eventType string, now time.Time) (*model.Profile, error) {
+	before := statusAuditFields(profile)
+
+	profile.Status = status
+	profile.UpdatedAt = now
+	if status == model.ProfileStatusActive {
+		profile.DeactivatedAt = nil
+		profile.DeactivatedBy = nil
+	} else {
+		profile.DeactivatedAt = &now
+		profile.DeactivatedBy = &actor.UserID
+	}
+
+	changes, err := diffFields(before, statusAuditFields(profile))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.UpdateStatus(ctx, profile); err != nil {
+			return err
+		}
+		if err := recordChange(ctx, repo, profile.ID, actor, action, changes); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, repo, eventType, profile.ID, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// PurgeDeactivatedProfiles hard-deletes one batch of profiles whose grace
+// period has ended, emitting profile.deleted for each. Player and scout
+// details and every other row owned by the profile cascade with it; the
+// change log is anonymized and kept, so takedowns stay on record. It reports
+// whether there may be more to purge.
+func (s *ProfileService) PurgeDeactivatedProfiles(ctx context.Context) (bool, error) {
+	var purged int
+	err := s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		now := time.Now()
+		profiles, err := repo.ClaimPurgeableProfiles(ctx, now, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			if err := repo.AnonymizeProfileChanges(ctx, profile.ID, profile.UserID, purgedAuditFields, now); err != nil {
+				return err
+			}
+			if err := repo.Delete(ctx, profile.ID); err != nil {
+				return err
+			}
+			// Media-service deletes the videos on profile.deleted
+			if err := repo.DeleteCompletionSignals(ctx, profile.UserID, model.SignalVideoUploaded); err != nil {
+				return err
+			}
+			if err := enqueueEvent(ctx, repo, model.EventProfileDeleted, profile.ID, profile); err != nil {
+				return err
+			}
+		}
+		purged = len(profiles)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return purged == purgeBatchSize, nil
+}
+
+func isActive(profile *model.Profile) bool {
+	return profile.Status == model.ProfileStatusActive
+}
+
+// purgedAuditFields are the change log fields whose values survive a purge:
+// the lifecycle record, not the profile's content.
+var purgedAuditFields = []string{"status", "deactivation_reason", "purge_after"}
+
+func statusAuditFields(profile *model.Profile) map[string]any {
+	return map[string]any{
+		"status":              profile.Status,
+		"deactivation_reason": profile.DeactivationReason,
+		"purge_after":         profile.PurgeAfter,
+	}
+}
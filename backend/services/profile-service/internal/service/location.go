@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/scouttalent/profile-service/internal/geo"
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+var ErrInvalidLocation = errors.New("invalid location")
+
+const (
+	defaultCitySuggestions = 10
+	maxCitySuggestions     = 50
+	locationBackfillBatch  = 200
+)
+
+// ListCountries returns the countries accepted as location_country.
+func (s *ProfileService) ListCountries() []*geo.Country {
+	return geo.Default().Countries()
+}
+
+// SuggestCities backs location autocomplete. country may be a code or name.
+func (s *ProfileService) SuggestCities(query, country string, limit int) ([]*geo.City, error) {
+	gaz := geo.Default()
+
+	code := ""
+	if country != "" {
+		c, ok := gaz.Country(country)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown country %q", ErrInvalidLocation, country)
+		}
+		code = c.Code
+	}
+	if limit <= 0 {
+		limit = defaultCitySuggestions
+	}
+	if limit > maxCitySuggestions {
+		limit = maxCitySuggestions
+	}
+
+	return gaz.SuggestCities(query, code, limit), nil
+}
+
+// resolveLocation normalizes the requested location against the gazetteer
+// and stores it on profile. A city ID from autocomplete wins over free text.
+func resolveLocation(profile *model.Profile, country, city, cityID *string) error {
+	gaz := geo.Default()
+
+	var loc *geo.Location
+	var err error
+	if cityID != nil && *cityID != "" {
+		loc, err = gaz.ResolveCityID(*cityID)
+	} else {
+		loc, err = gaz.Resolve(derefString(country), derefString(city))
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLocation, err)
+	}
+
+	setLocation(profile, loc)
+	return nil
+}
+
+// updateLocation applies a partial location update. Omitted fields keep
+// their stored values, except that changing the country drops a city that
+// was not resubmitted with it.
+func updateLocation(profile *model.Profile, req model.UpdateProfileRequest) error {
+	if req.LocationCountry == nil && req.LocationCity == nil && req.LocationCityID == nil {
+		return nil
+	}
+
+	country, city := profile.LocationCountry, profile.LocationCity
+	if req.LocationCountry != nil {
+		country = req.LocationCountry
+		if req.LocationCity == nil && req.LocationCityID == nil && countryChanged(profile, *req.LocationCountry) {
+			city = nil
+		}
+	}
+	if req.LocationCity != nil {
+		city = req.LocationCity
+	}
+
+	return resolveLocation(profile, country, city, req.LocationCityID)
+}
+
+func countryChanged(profile *model.Profile, country string) bool {
+	c, ok := geo.Default().Country(country)
+	if !ok || profile.LocationCountryCode == nil {
+		return true
+	}
+	return c.Code != *profile.LocationCountryCode
+}
+
+func setLocation(profile *model.Profile, loc *geo.Location) {
+	profile.LocationCountry = nil
+	profile.LocationCountryCode = nil
+	profile.LocationCity = nil
+	profile.LocationCityID = nil
+	profile.LocationLat = nil
+	profile.LocationLng = nil
+
+	// Copy out of the gazetteer, which is shared between requests
+	if loc.Country != nil {
+		country := *loc.Country
+		profile.LocationCountry = &country.Name
+		profile.LocationCountryCode = &country.Code
+	}
+	if loc.CityName != "" {
+		name := loc.CityName
+		profile.LocationCity = &name
+	}
+	if loc.City != nil {
+		city := *loc.City
+		profile.LocationCityID = &city.ID
+		profile.LocationLat = &city.Lat
+		profile.LocationLng = &city.Lng
+	}
+}
+
+// BackfillLocations normalizes profiles stored before locations were
+// resolved against the gazetteer. Profiles whose country is not recognised
+// are left as they are. It returns the number of profiles updated.
+func (s *ProfileService) BackfillLocations(ctx context.Context) (int, error) {
+	updated := 0
+	afterID := "00000000-0000-0000-0000-000000000000"
+
+	for {
+		profiles, err := s.repo.ListUnresolvedLocations(ctx, afterID, locationBackfillBatch)
+		if err != nil {
+			return updated, err
+		}
+		if len(profiles) == 0 {
+			return updated, nil
+		}
+
+		for _, profile := range profiles {
+			afterID = profile.ID
+			if err := resolveLocation(profile, profile.LocationCountry, profile.LocationCity, nil); err != nil {
+				continue
+			}
+			if profile.LocationCountryCode == nil {
+				continue
+			}
+			if err := s.repo.UpdateLocation(ctx, profile); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+	}
+}
+
+// clearCityLocation hides the city together with the fields that would
+// reveal it.
+func clearCityLocation(profile *model.Profile) {
+	profile.LocationCity = nil
+	profile.LocationCityID = nil
+	profile.LocationLat = nil
+	profile.LocationLng = nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+func (s *ProfileService) GetPrivacy(ctx context.Context, userID, profileID string) (*model.PrivacySettings, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return &profile.Privacy, nil
+}
+
+func (s *ProfileService) UpdatePrivacy(ctx context.Context, userID, profileID string, req model.UpdatePrivacyRequest) (*model.PrivacySettings, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	privacy := profile.Privacy
+	if req.Visibility != nil {
+		privacy.Visibility = *req.Visibility
+	}
+	if req.CityVisibility != nil {
+		privacy.CityVisibility = *req.CityVisibility
+	}
+	if req.ContactVisibility != nil {
+		privacy.ContactVisibility = *req.ContactVisibility
+	}
+	if req.DateOfBirthVisibility != nil {
+		privacy.DateOfBirthVisibility = *req.DateOfBirthVisibility
+	}
+
+	changes, err := diffFields(privacyAuditFields(profile.Privacy), privacyAuditFields(privacy))
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Privacy = privacy
+	profile.UpdatedAt = time.Now()
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.UpdatePrivacy(ctx, profileID, privacy, profile.UpdatedAt); err != nil {
+			return err
+		}
+		owner := model.Viewer{UserID: userID, Role: string(profile.Type)}
+		if err := recordChange(ctx, repo, profileID, owner, model.ChangeActionPrivacyUpdated, changes); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, repo, model.EventProfileUpdated, profile.ID, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &privacy, nil
+}
+
+// visibleProfile loads a profile and checks the viewer may see it. Profiles
+// outside the viewer's audience are reported as not found.
+func (s *ProfileService) visibleProfile(ctx context.Context, viewer model.Viewer, profileID string) (*model.Profile, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.canViewProfile(ctx, viewer, profile)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, repository.ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+// canViewProfile reports whether the viewer may see the profile at all.
+// Inactive profiles are only visible to their owner and admins, and
+// profiles blocked with the viewer's own in either direction are hidden.
+func (s *ProfileService) canViewProfile(ctx context.Context, viewer model.Viewer, profile *model.Profile) (bool, error) {
+	if isOwner(viewer, profile) || viewer.IsAdmin() {
+		return true, nil
+	}
+	if !isActive(profile) || !viewer.CanSee(profile.Privacy.Visibility) {
+		return false, nil
+	}
+	if viewer.UserID == "" {
+		return true, nil
+	}
+	blocked, err := s.repo.IsBlockedForUser(ctx, viewer.UserID, profile.ID)
+	if err != nil {
+		return false, err
+	}
+	return !blocked, nil
+}
+
+func isOwner(viewer model.Viewer, profile *model.Profile) bool {
+	return viewer.UserID != "" && viewer.UserID == profile.UserID
+}
+
+// redactProfile clears fields the viewer is not allowed to see.
+func redactProfile(viewer model.Viewer, profile *model.Profile) {
+	if isOwner(viewer, profile) {
+		return
+	}
+	if !viewer.CanSee(profile.Privacy.CityVisibility) {
+		clearCityLocation(profile)
+	}
+	if !viewer.CanSee(profile.Privacy.ContactVisibility) {
+		profile.ContactEmail = nil
+		profile.ContactPhone = nil
+	}
+}
+
+// redactPlayerProfile applies redactProfile and replaces the date of birth
+// with the player's age for viewers outside DateOfBirthVisibility.
+func redactPlayerProfile(viewer model.Viewer, player *model.PlayerProfile) {
+	details := &player.PlayerDetails
+	if details.DateOfBirth != nil {
+		age := ageAt(*details.DateOfBirth, time.Now())
+		details.Age = &age
+	}
+
+	if isOwner(viewer, &player.Profile) {
+		return
+	}
+	redactProfile(viewer, &player.Profile)
+	if !viewer.CanSee(player.Privacy.DateOfBirthVisibility) {
+		details.DateOfBirth = nil
+	}
+}
+
+func ageAt(dob, now time.Time) int {
+	age := now.Year() - dob.Year()
+	if now.Month() < dob.Month() || (now.Month() == dob.Month() && now.Day() < dob.Day()) {
+		age--
+	}
+	return age
+}
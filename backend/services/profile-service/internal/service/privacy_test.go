@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func TestRedactProfile(t *testing.T) {
+	newProfile := func(city, contact model.Audience) *model.Profile {
+		cityName, cityID := "Porto", "porto-pt"
+		lat, lng := 41.15, -8.61
+		email, phone := "player@example.com", "+351900000000"
+		return &model.Profile{
+			UserID:         "owner",
+			LocationCity:   &cityName,
+			LocationCityID: &cityID,
+			LocationLat:    &lat,
+			LocationLng:    &lng,
+			ContactEmail:   &email,
+			ContactPhone:   &phone,
+			Privacy: model.PrivacySettings{
+				Visibility:        model.AudiencePublic,
+				CityVisibility:    city,
+				ContactVisibility: contact,
+			},
+		}
+	}
+
+	scout := model.Viewer{UserID: "scout", Role: string(model.UserTypeScout), TrustLevel: string(model.TrustLevelNewcomer)}
+	verifiedScout := model.Viewer{UserID: "verified", Role: string(model.UserTypeScout), TrustLevel: string(model.TrustLevelVerified)}
+
+	tests := []struct {
+		name        string
+		viewer      model.Viewer
+		city        model.Audience
+		contact     model.Audience
+		wantCity    bool
+		wantContact bool
+	}{
+		{
+			name:        "owner sees hidden fields",
+			viewer:      model.Viewer{UserID: "owner", Role: string(model.UserTypePlayer)},
+			city:        model.AudienceHidden,
+			contact:     model.AudienceHidden,
+			wantCity:    true,
+			wantContact: true,
+		},
+		{
+			name:        "anonymous viewer with defaults",
+			viewer:      model.Viewer{},
+			city:        model.AudienceScoutsAndAcademies,
+			contact:     model.AudienceVerifiedScouts,
+			wantCity:    false,
+			wantContact: false,
+		},
+		{
+			name:        "anonymous viewer with public fields",
+			viewer:      model.Viewer{},
+			city:        model.AudiencePublic,
+			contact:     model.AudiencePublic,
+			wantCity:    true,
+			wantContact: true,
+		},
+		{
+			name:        "scout sees city but not contact",
+			viewer:      scout,
+			city:        model.AudienceScoutsAndAcademies,
+			contact:     model.AudienceVerifiedScouts,
+			wantCity:    true,
+			wantContact: false,
+		},
+		{
+			name:        "verified scout with defaults",
+			viewer:      verifiedScout,
+			city:        model.AudienceScoutsAndAcademies,
+			contact:     model.AudienceVerifiedScouts,
+			wantCity:    true,
+			wantContact: true,
+		},
+		{
+			name:        "hidden from everyone else",
+			viewer:      verifiedScout,
+			city:        model.AudienceHidden,
+			contact:     model.AudienceHidden,
+			wantCity:    false,
+			wantContact: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := newProfile(tt.city, tt.contact)
+			redactProfile(tt.viewer, profile)
+
+			hasCity := profile.LocationCity != nil
+			if hasCity != tt.wantCity {
+				t.Errorf("city visible = %v, want %v", hasCity, tt.wantCity)
+			}
+			if !tt.wantCity && (profile.LocationCityID != nil || profile.LocationLat != nil || profile.LocationLng != nil) {
+				t.Errorf("city redacted but city_id or coordinates kept")
+			}
+
+			hasContact := profile.ContactEmail != nil
+			if hasContact != tt.wantContact {
+				t.Errorf("contact visible = %v, want %v", hasContact, tt.wantContact)
+			}
+			if (profile.ContactPhone != nil) != hasContact {
+				t.Errorf("contact_email and contact_phone redacted differently")
+			}
+		})
+	}
+}
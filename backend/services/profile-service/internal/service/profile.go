@@ -2,20 +2,35 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/client"
+	"github.com/scouttalent/profile-service/internal/i18n"
 	"github.com/scouttalent/profile-service/internal/model"
 	"github.com/scouttalent/profile-service/internal/repository"
 )
 
+var (
+	ErrNotPlayer = errors.New("profile is not a player")
+	ErrForbidden = errors.New("not allowed to modify this profile")
+)
+
 type ProfileService struct {
-	repo *repository.ProfileRepository
+	repo          *repository.ProfileRepository
+	media         *client.MediaClient
+	auth          *client.AuthClient
+	publicBaseURL string
 }
 
-func NewProfileService(repo *repository.ProfileRepository) *ProfileService {
-	return &ProfileService{repo: repo}
+func NewProfileService(repo *repository.ProfileRepository, media *client.MediaClient, auth *client.AuthClient, publicBaseURL string) *ProfileService {
+	return &ProfileService{
+		repo:          repo,
+		media:         media,
+		auth:          auth,
+		publicBaseURL: publicBaseURL,
+	}
 }
 
 func (s *ProfileService) CreateProfile(ctx context.Context, userID string, userType model.UserType, req model.CreateProfileRequest) (*model.Profile, error) {
@@ -31,40 +46,122 @@ func (s *ProfileService) CreateProfile(ctx context.Context, userID string, userT
 		Type:                   userType,
 		DisplayName:            req.DisplayName,
 		Bio:                    req.Bio,
-		LocationCountry:        req.LocationCountry,
-		LocationCity:           req.LocationCity,
 		TrustLevel:             model.TrustLevelNewcomer,
 		ProfileCompletionScore: 0,
+		Privacy:                model.DefaultPrivacySettings(),
 		CreatedAt:              time.Now(),
 		UpdatedAt:              time.Now(),
 	}
 
-	if err := s.repo.Create(ctx, profile); err != nil {
+	if err := resolveLocation(profile, req.LocationCountry, req.LocationCity, req.LocationCityID); err != nil {
 		return nil, err
 	}
+	if err := setPrimaryLanguage(profile, req.PrimaryLanguage); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		return s.insertProfile(ctx, repo, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// insertProfile assigns a slug, stores the profile with its initial
+// completion score and records profile.created. repo must be bound to a
+// transaction.
+func (s *ProfileService) insertProfile(ctx context.Context, repo *repository.ProfileRepository, profile *model.Profile) error {
+	profile.Status = model.ProfileStatusActive
+
+	// Without an explicit choice the bio's language becomes the primary one
+	profile.BioLanguage = detectLanguage(profile.Bio)
+	if profile.PrimaryLanguage == "" {
+		profile.PrimaryLanguage = i18n.DefaultLocale
+		if profile.BioLanguage != nil {
+			profile.PrimaryLanguage = *profile.BioLanguage
+		}
+	}
+
+	var err error
+	profile.Slug, err = s.generateSlug(ctx, repo, profile.DisplayName, profile.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.Create(ctx, profile); err != nil {
+		return err
+	}
 
 	// Calculate initial completion score
-	profile.ProfileCompletionScore = s.repo.CalculateCompletionScore(ctx, profile)
-	if err := s.repo.Update(ctx, profile); err != nil {
+	if err := refreshCompletionScore(ctx, repo, profile); err != nil {
+		return err
+	}
+	if err := repo.Update(ctx, profile); err != nil {
+		return err
+	}
+
+	return enqueueEvent(ctx, repo, model.EventProfileCreated, profile.ID, profile)
+}
+
+func (s *ProfileService) GetProfile(ctx context.Context, viewer model.Viewer, profileID string) (*model.Profile, error) {
+	profile, err := s.visibleProfile(ctx, viewer, profileID)
+	if err != nil {
 		return nil, err
 	}
 
+	redactProfile(viewer, profile)
+	if err := s.localizeProfile(ctx, viewer, profile); err != nil {
+		return nil, err
+	}
 	return profile, nil
 }
 
-func (s *ProfileService) GetProfile(ctx context.Context, profileID string) (*model.Profile, error) {
-	return s.repo.GetByID(ctx, profileID)
+// GetInternalProfile returns the policy-relevant fields of a profile for
+// service-to-service callers.
+func (s *ProfileService) GetInternalProfile(ctx context.Context, profileID string) (*model.InternalProfile, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	internal := &model.InternalProfile{
+		ID:         profile.ID,
+		Type:       profile.Type,
+		TrustLevel: profile.TrustLevel,
+		Status:     profile.Status,
+	}
+
+	if profile.Type == model.UserTypePlayer {
+		player, err := s.repo.GetPlayerProfile(ctx, profile.ID)
+		if err != nil && !errors.Is(err, repository.ErrProfileNotFound) {
+			return nil, err
+		}
+		if player != nil && player.PlayerDetails.DateOfBirth != nil {
+			internal.Minor = ageAt(*player.PlayerDetails.DateOfBirth, time.Now()) < model.AdultAge
+		}
+	}
+
+	return internal, nil
 }
 
 func (s *ProfileService) GetProfileByUserID(ctx context.Context, userID string) (*model.Profile, error) {
 	return s.repo.GetByUserID(ctx, userID)
 }
 
-func (s *ProfileService) UpdateProfile(ctx context.Context, profileID string, req model.UpdateProfileRequest) (*model.Profile, error) {
+// UpdateProfile edits a profile on behalf of its owner or an admin and
+// records the changed fields in the change log.
+func (s *ProfileService) UpdateProfile(ctx context.Context, viewer model.Viewer, profileID string, req model.UpdateProfileRequest) (*model.Profile, error) {
 	profile, err := s.repo.GetByID(ctx, profileID)
 	if err != nil {
 		return nil, err
 	}
+	if !canEditProfile(viewer, profile) {
+		return nil, ErrForbidden
+	}
+	before := profileAuditFields(profile)
 
 	// Update fields if provided
 	if req.DisplayName != nil {
@@ -72,38 +169,65 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, profileID string, re
 	}
 	if req.Bio != nil {
 		profile.Bio = req.Bio
+		profile.BioLanguage = detectLanguage(req.Bio)
+	}
+	if err := setPrimaryLanguage(profile, req.PrimaryLanguage); err != nil {
+		return nil, err
 	}
 	if req.AvatarURL != nil {
 		profile.AvatarURL = req.AvatarURL
 	}
-	if req.LocationCountry != nil {
-		profile.LocationCountry = req.LocationCountry
+	if err := updateLocation(profile, req); err != nil {
+		return nil, err
 	}
-	if req.LocationCity != nil {
-		profile.LocationCity = req.LocationCity
+	if req.ContactEmail != nil {
+		profile.ContactEmail = req.ContactEmail
+	}
+	if req.ContactPhone != nil {
+		profile.ContactPhone = req.ContactPhone
 	}
 
 	profile.UpdatedAt = time.Now()
 
-	// Recalculate completion score
-	profile.ProfileCompletionScore = s.repo.CalculateCompletionScore(ctx, profile)
+	changes, err := diffFields(before, profileAuditFields(profile))
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.repo.Update(ctx, profile); err != nil {
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		// Recalculate completion score
+		if err := refreshCompletionScore(ctx, repo, profile); err != nil {
+			return err
+		}
+
+		if err := repo.Update(ctx, profile); err != nil {
+			return err
+		}
+		if err := recordChange(ctx, repo, profile.ID, viewer, model.ChangeActionProfileUpdated, changes); err != nil {
+			return err
+		}
+
+		return enqueueEvent(ctx, repo, model.EventProfileUpdated, profile.ID, profile)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return profile, nil
 }
 
-func (s *ProfileService) CreatePlayerDetails(ctx context.Context, profileID string, req model.CreatePlayerDetailsRequest) (*model.PlayerProfile, error) {
+func (s *ProfileService) CreatePlayerDetails(ctx context.Context, viewer model.Viewer, profileID string, req model.CreatePlayerDetailsRequest) (*model.PlayerProfile, error) {
 	// Verify profile exists and is a player
 	profile, err := s.repo.GetByID(ctx, profileID)
 	if err != nil {
 		return nil, err
 	}
+	if !canEditProfile(viewer, profile) {
+		return nil, ErrForbidden
+	}
 
 	if profile.Type != model.UserTypePlayer {
-		return nil, fmt.Errorf("profile is not a player")
+		return nil, ErrNotPlayer
 	}
 
 	details := &model.PlayerDetails{
@@ -116,20 +240,84 @@ func (s *ProfileService) CreatePlayerDetails(ctx context.Context, profileID stri
 		CurrentTeam:   req.CurrentTeam,
 	}
 
-	if err := s.repo.CreatePlayerDetails(ctx, details); err != nil {
+	var player *model.PlayerProfile
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		player, err = insertPlayerDetails(ctx, repo, viewer, profile, details)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
+
+// insertPlayerDetails stores details for a player profile, logs them as
+// created by actor, refreshes the completion score and records
+// profile.player_details.updated. repo must be bound to a transaction.
+func insertPlayerDetails(ctx context.Context, repo *repository.ProfileRepository, actor model.Viewer, profile *model.Profile, details *model.PlayerDetails) (*model.PlayerProfile, error) {
+	if err := repo.CreatePlayerDetails(ctx, details); err != nil {
+		return nil, err
+	}
+
+	changes, err := diffFields(map[string]any{}, playerAuditFields(details))
+	if err != nil {
+		return nil, err
+	}
+	if err := recordChange(ctx, repo, profile.ID, actor, model.ChangeActionPlayerDetailsCreated, changes); err != nil {
 		return nil, err
 	}
 
 	// Recalculate completion score
-	profile.ProfileCompletionScore = s.repo.CalculateCompletionScore(ctx, profile)
+	if err := refreshCompletionScore(ctx, repo, profile); err != nil {
+		return nil, err
+	}
 	profile.UpdatedAt = time.Now()
-	if err := s.repo.Update(ctx, profile); err != nil {
+	if err := repo.Update(ctx, profile); err != nil {
 		return nil, err
 	}
 
-	return s.repo.GetPlayerProfile(ctx, profileID)
+	return enqueuePlayerDetailsUpdated(ctx, repo, profile.ID)
 }
 
-func (s *ProfileService) GetPlayerProfile(ctx context.Context, profileID string) (*model.PlayerProfile, error) {
-	return s.repo.GetPlayerProfile(ctx, profileID)
+func (s *ProfileService) GetPlayerProfile(ctx context.Context, viewer model.Viewer, profileID string) (*model.PlayerProfile, error) {
+	player, err := s.repo.GetPlayerProfile(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.canViewProfile(ctx, viewer, &player.Profile)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, repository.ErrProfileNotFound
+	}
+	redactPlayerProfile(viewer, player)
+	if err := s.localizeProfile(ctx, viewer, &player.Profile); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.GetCareerTotals(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	schema := model.StatSchemaForPosition(player.PlayerDetails.Position)
+	if !schema.CleanSheets {
+		totals.CleanSheets = nil
+	}
+	if !schema.GoalsConceded {
+		totals.GoalsConceded = nil
+	}
+	if !schema.Saves {
+		totals.Saves = nil
+	}
+	player.CareerTotals = totals
+
+	endorsements, err := s.repo.ListEndorsements(ctx, profileID, []model.EndorsementStatus{model.EndorsementStatusAccepted})
+	if err != nil {
+		return nil, err
+	}
+	player.Endorsements = endorsements
+
+	return player, nil
 }
\ No newline at end of file
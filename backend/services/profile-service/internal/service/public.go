@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var ErrInvalidSlug = errors.New("invalid slug")
+
+const (
+	maxSlugLength       = 60
+	publicVideoLimit    = 12
+	ogDescriptionLength = 200
+	siteName            = "ScoutTalent"
+)
+
+var (
+	slugPattern   = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$`)
+	slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// GetPublicProfile builds the anonymous view of the profile at slug. A valid
+// share token grants access to profiles that are not public, but fields are
+// still redacted as for an anonymous viewer. When slug is a retired slug the
+// profile's current slug is returned instead so callers can redirect.
+// Display text is served in the first of languages the profile has.
+func (s *ProfileService) GetPublicProfile(ctx context.Context, slug, shareToken string, languages []string) (*model.PublicProfile, string, error) {
+	profile, err := s.repo.GetBySlug(ctx, slug)
+	if errors.Is(err, repository.ErrProfileNotFound) {
+		profileID, histErr := s.repo.GetProfileIDBySlugHistory(ctx, slug)
+		if histErr != nil {
+			return nil, "", histErr
+		}
+		current, err := s.repo.GetByID(ctx, profileID)
+		if err != nil {
+			return nil, "", err
+		}
+		if !isActive(current) {
+			return nil, "", repository.ErrProfileNotFound
+		}
+		return nil, current.Slug, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if !isActive(profile) {
+		return nil, "", repository.ErrProfileNotFound
+	}
+
+	viewer := model.Viewer{Languages: languages}
+	if !viewer.CanSee(profile.Privacy.Visibility) {
+		if shareToken == "" {
+			return nil, "", repository.ErrProfileNotFound
+		}
+		valid, err := s.repo.ShareTokenValid(ctx, profile.ID, hashShareToken(shareToken), time.Now())
+		if err != nil {
+			return nil, "", err
+		}
+		if !valid {
+			return nil, "", repository.ErrProfileNotFound
+		}
+	}
+
+	redactProfile(viewer, profile)
+	if err := s.localizeProfile(ctx, viewer, profile); err != nil {
+		return nil, "", err
+	}
+	public := &model.PublicProfile{
+		ID:              profile.ID,
+		Slug:            profile.Slug,
+		Type:            profile.Type,
+		DisplayName:     profile.DisplayName,
+		Bio:             profile.Bio,
+		AvatarURL:       profile.AvatarURL,
+		LocationCountry: profile.LocationCountry,
+		LocationCity:    profile.LocationCity,
+		TrustLevel:      profile.TrustLevel,
+		Locale:          profile.Locale,
+		Videos:          []*model.PublicVideo{},
+	}
+
+	if profile.Type == model.UserTypePlayer {
+		player, err := s.repo.GetPlayerProfile(ctx, profile.ID)
+		if err != nil && !errors.Is(err, repository.ErrProfileNotFound) {
+			return nil, "", err
+		}
+		if player != nil {
+			redactPlayerProfile(viewer, player)
+			details := player.PlayerDetails
+			public.Player = &model.PublicPlayer{
+				Position:      details.Position,
+				Age:           details.Age,
+				HeightCM:      details.HeightCM,
+				WeightKG:      details.WeightKG,
+				PreferredFoot: details.PreferredFoot,
+				CurrentTeam:   details.CurrentTeam,
+				OverallScore:  details.OverallScore,
+			}
+		}
+	}
+
+	public.Meta = s.openGraphMeta(public)
+	return public, "", nil
+}
+
+// ListPublicVideos fetches the profile's public videos from media-service.
+func (s *ProfileService) ListPublicVideos(ctx context.Context, profileID string) ([]*model.PublicVideo, error) {
+	return s.media.ListPublicVideos(ctx, profileID, publicVideoLimit)
+}
+
+func (s *ProfileService) openGraphMeta(profile *model.PublicProfile) model.OpenGraphMeta {
+	description := fmt.Sprintf("%s on %s", profile.DisplayName, siteName)
+	if profile.Player != nil {
+		description = fmt.Sprintf("%s, %s on %s", profile.DisplayName, profile.Player.Position, siteName)
+	}
+	if profile.Bio != nil && *profile.Bio != "" {
+		description = truncateRunes(*profile.Bio, ogDescriptionLength)
+	}
+
+	return model.OpenGraphMeta{
+		Title:       profile.DisplayName,
+		Description: description,
+		Image:       profile.AvatarURL,
+		URL:         strings.TrimSuffix(s.publicBaseURL, "/") + "/p/" + profile.Slug,
+		Type:        "profile",
+		SiteName:    siteName,
+	}
+}
+
+// UpdateSlug changes the caller's public slug. The previous slug keeps
+// redirecting to the profile.
+func (s *ProfileService) UpdateSlug(ctx context.Context, userID, profileID string, req model.UpdateSlugRequest) (*model.Profile, error) {
+	slug := strings.ToLower(req.Slug)
+	if len(slug) > maxSlugLength || !slugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("%w: use lowercase letters, digits and single hyphens", ErrInvalidSlug)
+	}
+
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID != userID {
+		return nil, ErrForbidden
+	}
+	if profile.Slug == slug {
+		return profile, nil
+	}
+
+	available, err := s.repo.SlugAvailable(ctx, slug, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, repository.ErrSlugTaken
+	}
+
+	oldSlug := profile.Slug
+	profile.Slug = slug
+	profile.UpdatedAt = time.Now()
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.ChangeSlug(ctx, profileID, oldSlug, slug, profile.UpdatedAt); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, repo, model.EventProfileUpdated, profile.ID, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// generateSlug derives an unused slug from the display name, appending a
+// short random suffix when the plain form is taken.
+func (s *ProfileService) generateSlug(ctx context.Context, repo *repository.ProfileRepository, displayName, profileID string) (string, error) {
+	base := slugify(displayName)
+	candidate := base
+	for attempt := 0; attempt < 5; attempt++ {
+		available, err := repo.SlugAvailable(ctx, candidate, profileID)
+		if err != nil {
+			return "", err
+		}
+		if available {
+			return candidate, nil
+		}
+		candidate = base + "-" + randomHex(3)
+	}
+	return base + "-" + strings.ReplaceAll(profileID, "-", "")[:12], nil
+}
+
+func slugify(name string) string {
+	slug := strings.Trim(slugSeparator.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	// Leave room for a disambiguating suffix
+	if len(slug) > maxSlugLength-8 {
+		slug = strings.TrimRight(slug[:maxSlugLength-8], "-")
+	}
+	if slug == "" {
+		slug = "profile"
+	}
+	return slug
+}
+
+func (s *ProfileService) ListShareTokens(ctx context.Context, userID, profileID string) ([]*model.ShareToken, error) {
+	if err := s.requireOwner(ctx, userID, profileID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListShareTokens(ctx, profileID)
+}
+
+// CreateShareToken issues a secret link token for the caller's profile. Only
+// its hash is stored, so the plain token is returned once.
+func (s *ProfileService) CreateShareToken(ctx context.Context, userID, profileID string, req model.CreateShareTokenRequest) (*model.ShareToken, error) {
+	if err := s.requireOwner(ctx, userID, profileID); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	now := time.Now()
+	token := &model.ShareToken{
+		ID:        uuid.New().String(),
+		ProfileID: profileID,
+		Label:     req.Label,
+		ExpiresAt: now.Add(time.Duration(req.ExpiresInHours) * time.Hour),
+		CreatedAt: now,
+		Token:     base64.RawURLEncoding.EncodeToString(secret),
+	}
+
+	if err := s.repo.CreateShareToken(ctx, token, hashShareToken(token.Token)); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *ProfileService) RevokeShareToken(ctx context.Context, userID, profileID, tokenID string) error {
+	if err := s.requireOwner(ctx, userID, profileID); err != nil {
+		return err
+	}
+	return s.repo.RevokeShareToken(ctx, profileID, tokenID, time.Now())
+}
+
+func (s *ProfileService) requireOwner(ctx context.Context, userID, profileID string) error {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return err
+	}
+	if profile.UserID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return strings.Repeat("0", n*2)
+	}
+	return hex.EncodeToString(b)
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
@@ -0,0 +1,572 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/export"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var ErrInvalidReport = errors.New("invalid scouting report")
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// ListReportTemplates is open to the academy and its staff.
+func (s *ProfileService) ListReportTemplates(ctx context.Context, viewer model.Viewer, academyID string) ([]*model.ReportTemplate, error) {
+	actor, err := s.recruiterProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireAcademyMember(ctx, actor, academyID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListReportTemplates(ctx, academyID)
+}
+
+func (s *ProfileService) CreateReportTemplate(ctx context.Context, userID, academyID string, req model.ReportTemplateRequest) (*model.ReportTemplate, error) {
+	if _, err := s.academyOwnedBy(ctx, userID, academyID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tmpl := &model.ReportTemplate{
+		ID:               uuid.New().String(),
+		AcademyProfileID: &academyID,
+		Name:             req.Name,
+		ScaleMax:         req.ScaleMax,
+		Attributes:       req.Attributes,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := validateReportTemplate(tmpl); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateReportTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// UpdateReportTemplate only affects reports written afterwards; existing
+// versions keep the scale they were written on.
+func (s *ProfileService) UpdateReportTemplate(ctx context.Context, userID, academyID, templateID string, req model.ReportTemplateRequest) (*model.ReportTemplate, error) {
+	tmpl, err := s.academyTemplate(ctx, userID, academyID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.Name = req.Name
+	tmpl.ScaleMax = req.ScaleMax
+	tmpl.Attributes = req.Attributes
+	tmpl.UpdatedAt = time.Now()
+	if err := validateReportTemplate(tmpl); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateReportTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+func (s *ProfileService) DeleteReportTemplate(ctx context.Context, userID, academyID, templateID string) error {
+	if _, err := s.academyTemplate(ctx, userID, academyID, templateID); err != nil {
+		return err
+	}
+	return s.repo.DeleteReportTemplate(ctx, templateID)
+}
+
+func (s *ProfileService) academyTemplate(ctx context.Context, userID, academyID, templateID string) (*model.ReportTemplate, error) {
+	if _, err := s.academyOwnedBy(ctx, userID, academyID); err != nil {
+		return nil, err
+	}
+	tmpl, err := s.repo.GetReportTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.AcademyProfileID == nil || *tmpl.AcademyProfileID != academyID {
+		return nil, repository.ErrReportTemplateNotFound
+	}
+	return tmpl, nil
+}
+
+func (s *ProfileService) CreateReport(ctx context.Context, viewer model.Viewer, req model.CreateReportRequest) (*model.ScoutingReport, error) {
+	actor, err := s.recruiterProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := s.visibleProfile(ctx, viewer, req.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if player.Type != model.UserTypePlayer {
+		return nil, ErrNotPlayer
+	}
+
+	academyID := req.AcademyProfileID
+	if academyID == nil && actor.Type == model.UserTypeAcademy {
+		academyID = &actor.ID
+	}
+	if academyID != nil {
+		if err := s.requireAcademyMember(ctx, actor, *academyID); err != nil {
+			return nil, err
+		}
+	}
+	if req.Visibility == model.ReportVisibilityOrg && academyID == nil {
+		return nil, fmt.Errorf("%w: org reports need academy_profile_id", ErrInvalidReport)
+	}
+
+	tmpl, err := s.reportTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.AcademyProfileID != nil && (academyID == nil || *tmpl.AcademyProfileID != *academyID) {
+		return nil, fmt.Errorf("%w: template belongs to another academy", ErrInvalidReport)
+	}
+
+	now := time.Now()
+	report := &model.ScoutingReport{
+		ID:               uuid.New().String(),
+		PlayerID:         player.ID,
+		AuthorID:         actor.ID,
+		AcademyProfileID: academyID,
+		TemplateID:       req.TemplateID,
+		Visibility:       req.Visibility,
+		CreatedAt:        now,
+		ScoutingReportVersion: model.ScoutingReportVersion{
+			Version:        1,
+			Ratings:        req.Ratings,
+			ScaleMax:       tmpl.ScaleMax,
+			Strengths:      req.Strengths,
+			Weaknesses:     req.Weaknesses,
+			ProjectedLevel: req.ProjectedLevel,
+			Recommendation: req.Recommendation,
+			VideoRefs:      req.VideoRefs,
+			EditedBy:       &actor.ID,
+			UpdatedAt:      now,
+		},
+	}
+	if report.VideoRefs == nil {
+		report.VideoRefs = []model.VideoRef{}
+	}
+	if err := validateRatings(tmpl, report.Ratings); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		return repo.CreateScoutingReport(ctx, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *ProfileService) GetReport(ctx context.Context, viewer model.Viewer, reportID string) (*model.ScoutingReport, error) {
+	_, report, err := s.accessibleReport(ctx, viewer, reportID)
+	return report, err
+}
+
+// UpdateReport records a new version. Only the author may edit a report.
+func (s *ProfileService) UpdateReport(ctx context.Context, viewer model.Viewer, reportID string, req model.UpdateReportRequest) (*model.ScoutingReport, error) {
+	actor, report, err := s.accessibleReport(ctx, viewer, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.AuthorID != actor.ID {
+		return nil, ErrForbidden
+	}
+
+	if req.Visibility != nil {
+		if *req.Visibility == model.ReportVisibilityOrg && report.AcademyProfileID == nil {
+			return nil, fmt.Errorf("%w: report is not attached to an academy", ErrInvalidReport)
+		}
+		report.Visibility = *req.Visibility
+	}
+
+	tmpl, err := s.reportTemplate(ctx, report.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := report.ScoutingReportVersion
+	if req.Ratings != nil {
+		if err := validateRatings(tmpl, req.Ratings); err != nil {
+			return nil, err
+		}
+		version.Ratings = req.Ratings
+		version.ScaleMax = tmpl.ScaleMax
+	}
+	if req.Strengths != nil {
+		version.Strengths = req.Strengths
+	}
+	if req.Weaknesses != nil {
+		version.Weaknesses = req.Weaknesses
+	}
+	if req.ProjectedLevel != nil {
+		version.ProjectedLevel = req.ProjectedLevel
+	}
+	if req.Recommendation != nil {
+		version.Recommendation = req.Recommendation
+	}
+	if req.VideoRefs != nil {
+		version.VideoRefs = req.VideoRefs
+	}
+	version.EditedBy = &actor.ID
+	version.UpdatedAt = time.Now()
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		return repo.AddScoutingReportVersion(ctx, reportID, report.Visibility, &version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.ScoutingReportVersion = version
+	return report, nil
+}
+
+// DeleteReport is allowed for the author and for the academy the report is
+// attached to.
+func (s *ProfileService) DeleteReport(ctx context.Context, viewer model.Viewer, reportID string) error {
+	actor, report, err := s.accessibleReport(ctx, viewer, reportID)
+	if err != nil {
+		return err
+	}
+	isAcademy := report.AcademyProfileID != nil && *report.AcademyProfileID == actor.ID
+	if report.AuthorID != actor.ID && !isAcademy {
+		return ErrForbidden
+	}
+	return s.repo.DeleteScoutingReport(ctx, reportID)
+}
+
+func (s *ProfileService) ListReportVersions(ctx context.Context, viewer model.Viewer, reportID string) ([]*model.ScoutingReportVersion, error) {
+	if _, _, err := s.accessibleReport(ctx, viewer, reportID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListScoutingReportVersions(ctx, reportID)
+}
+
+func (s *ProfileService) GetReportVersion(ctx context.Context, viewer model.Viewer, reportID string, version int) (*model.ScoutingReport, error) {
+	if _, _, err := s.accessibleReport(ctx, viewer, reportID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetScoutingReportVersion(ctx, reportID, version)
+}
+
+func (s *ProfileService) ListPlayerReports(ctx context.Context, viewer model.Viewer, playerID string) ([]*model.ScoutingReport, error) {
+	actor, err := s.recruiterProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.visibleProfile(ctx, viewer, playerID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListScoutingReportsForPlayer(ctx, playerID, actor.ID)
+}
+
+// GetScoutingSummary aggregates every report on the player the viewer can
+// read. Ratings are normalised to 0-10 so reports written on different
+// template scales can be combined.
+func (s *ProfileService) GetScoutingSummary(ctx context.Context, viewer model.Viewer, playerID string) (*model.ScoutingSummary, error) {
+	reports, err := s.ListPlayerReports(ctx, viewer, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.ScoutingSummary{
+		PlayerID:        playerID,
+		ReportCount:     len(reports),
+		Attributes:      []*model.AttributeAggregate{},
+		Recommendations: make(map[string]int),
+		ProjectedLevels: make(map[string]int),
+	}
+
+	labels, err := s.attributeLabels(ctx, reports)
+	if err != nil {
+		return nil, err
+	}
+
+	scouts := make(map[string]bool)
+	sums := make(map[string]float64)
+	aggregates := make(map[string]*model.AttributeAggregate)
+	for _, report := range reports {
+		scouts[report.AuthorID] = true
+		if report.Recommendation != nil {
+			summary.Recommendations[*report.Recommendation]++
+		}
+		if report.ProjectedLevel != nil {
+			summary.ProjectedLevels[*report.ProjectedLevel]++
+		}
+
+		for key, rating := range report.Ratings {
+			normalised := rating * model.MaxSkillScore / float64(report.ScaleMax)
+			agg, ok := aggregates[key]
+			if !ok {
+				agg = &model.AttributeAggregate{Key: key, Label: labels[key], Min: normalised, Max: normalised}
+				aggregates[key] = agg
+			}
+			agg.Count++
+			agg.Min = math.Min(agg.Min, normalised)
+			agg.Max = math.Max(agg.Max, normalised)
+			sums[key] += normalised
+		}
+	}
+	summary.ScoutCount = len(scouts)
+
+	for key, agg := range aggregates {
+		agg.Mean = roundScore(sums[key] / float64(agg.Count))
+		agg.Min = roundScore(agg.Min)
+		agg.Max = roundScore(agg.Max)
+		summary.Attributes = append(summary.Attributes, agg)
+	}
+	sort.Slice(summary.Attributes, func(i, j int) bool {
+		return summary.Attributes[i].Key < summary.Attributes[j].Key
+	})
+
+	return summary, nil
+}
+
+// ExportReport renders the current version of a report as Markdown ("md")
+// or PDF ("pdf").
+func (s *ProfileService) ExportReport(ctx context.Context, viewer model.Viewer, reportID, format string) ([]byte, error) {
+	_, report, err := s.accessibleReport(ctx, viewer, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := s.repo.GetByID(ctx, report.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	author, err := s.repo.GetByID(ctx, report.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := s.reportTemplate(ctx, report.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	markdown := renderReportMarkdown(report, tmpl, player, author)
+	switch format {
+	case "md":
+		return []byte(markdown), nil
+	case "pdf":
+		return export.MarkdownToPDF(markdown), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown export format %q", ErrInvalidReport, format)
+	}
+}
+
+func renderReportMarkdown(report *model.ScoutingReport, tmpl *model.ReportTemplate, player, author *model.Profile) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Scouting report: %s\n\n", player.DisplayName)
+	fmt.Fprintf(&b, "- **Scout:** %s\n", author.DisplayName)
+	fmt.Fprintf(&b, "- **Template:** %s\n", tmpl.Name)
+	fmt.Fprintf(&b, "- **Version:** %d (%s)\n", report.Version, report.UpdatedAt.Format("2 Jan 2006"))
+	if report.ProjectedLevel != nil {
+		fmt.Fprintf(&b, "- **Projected level:** %s\n", humanize(*report.ProjectedLevel))
+	}
+	if report.Recommendation != nil {
+		fmt.Fprintf(&b, "- **Recommendation:** %s\n", humanize(*report.Recommendation))
+	}
+
+	fmt.Fprintf(&b, "\n## Ratings (out of %d)\n\n", report.ScaleMax)
+	b.WriteString("| Attribute | Rating |\n|---|---|\n")
+	for _, attr := range tmpl.Attributes {
+		rating, ok := report.Ratings[attr.Key]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "| %s | %s |\n", attr.Label, strconv.FormatFloat(rating, 'f', -1, 64))
+	}
+	// Keys no longer on the template are still part of the report
+	var extra []string
+	for key := range report.Ratings {
+		if !templateHasAttribute(tmpl, key) {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		fmt.Fprintf(&b, "| %s | %s |\n", humanize(key), strconv.FormatFloat(report.Ratings[key], 'f', -1, 64))
+	}
+
+	if report.Strengths != nil {
+		fmt.Fprintf(&b, "\n## Strengths\n\n%s\n", *report.Strengths)
+	}
+	if report.Weaknesses != nil {
+		fmt.Fprintf(&b, "\n## Weaknesses\n\n%s\n", *report.Weaknesses)
+	}
+
+	if len(report.VideoRefs) > 0 {
+		b.WriteString("\n## Video references\n\n")
+		for _, ref := range report.VideoRefs {
+			line := "Video " + ref.VideoID
+			if ref.TimestampSeconds != nil {
+				line += fmt.Sprintf(" at %d:%02d", *ref.TimestampSeconds/60, *ref.TimestampSeconds%60)
+			}
+			if ref.Note != nil {
+				line += ": " + *ref.Note
+			}
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	return b.String()
+}
+
+// accessibleReport loads a report the caller may read: its own, or an org
+// report shared with an academy it is or is staff of. Players never see
+// reports, including those about themselves.
+func (s *ProfileService) accessibleReport(ctx context.Context, viewer model.Viewer, reportID string) (*model.Profile, *model.ScoutingReport, error) {
+	actor, err := s.recruiterProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report, err := s.repo.GetScoutingReport(ctx, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if report.AuthorID == actor.ID {
+		return actor, report, nil
+	}
+	if report.Visibility == model.ReportVisibilityOrg && report.AcademyProfileID != nil {
+		err := s.requireAcademyMember(ctx, actor, *report.AcademyProfileID)
+		if err == nil {
+			return actor, report, nil
+		}
+		if !errors.Is(err, ErrForbidden) {
+			return nil, nil, err
+		}
+	}
+
+	return nil, nil, repository.ErrReportNotFound
+}
+
+// requireAcademyMember checks actor is the academy itself or on its staff.
+func (s *ProfileService) requireAcademyMember(ctx context.Context, actor *model.Profile, academyID string) error {
+	if actor.ID == academyID {
+		return nil
+	}
+	isStaff, err := s.repo.IsAcademyStaff(ctx, academyID, actor.ID)
+	if err != nil {
+		return err
+	}
+	if !isStaff {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (s *ProfileService) reportTemplate(ctx context.Context, templateID *string) (*model.ReportTemplate, error) {
+	if templateID == nil {
+		tmpl := model.DefaultReportTemplate
+		return &tmpl, nil
+	}
+	return s.repo.GetReportTemplate(ctx, *templateID)
+}
+
+// attributeLabels collects display labels for every attribute key used by
+// the reports, falling back to a humanised key.
+func (s *ProfileService) attributeLabels(ctx context.Context, reports []*model.ScoutingReport) (map[string]string, error) {
+	labels := make(map[string]string)
+	seen := make(map[string]bool)
+
+	add := func(tmpl *model.ReportTemplate) {
+		for _, attr := range tmpl.Attributes {
+			if _, ok := labels[attr.Key]; !ok {
+				labels[attr.Key] = attr.Label
+			}
+		}
+	}
+	add(&model.DefaultReportTemplate)
+
+	for _, report := range reports {
+		if report.TemplateID == nil || seen[*report.TemplateID] {
+			continue
+		}
+		seen[*report.TemplateID] = true
+		tmpl, err := s.repo.GetReportTemplate(ctx, *report.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		add(tmpl)
+	}
+
+	for _, report := range reports {
+		for key := range report.Ratings {
+			if _, ok := labels[key]; !ok {
+				labels[key] = humanize(key)
+			}
+		}
+	}
+
+	return labels, nil
+}
+
+func validateReportTemplate(tmpl *model.ReportTemplate) error {
+	seen := make(map[string]bool)
+	for i, attr := range tmpl.Attributes {
+		if !attributeKeyPattern.MatchString(attr.Key) {
+			return fmt.Errorf("%w: attribute %d key must be lowercase snake_case", ErrInvalidReport, i)
+		}
+		if seen[attr.Key] {
+			return fmt.Errorf("%w: duplicate attribute %q", ErrInvalidReport, attr.Key)
+		}
+		seen[attr.Key] = true
+		if strings.TrimSpace(attr.Label) == "" {
+			tmpl.Attributes[i].Label = humanize(attr.Key)
+		}
+	}
+	return nil
+}
+
+func validateRatings(tmpl *model.ReportTemplate, ratings map[string]float64) error {
+	for key, rating := range ratings {
+		if !templateHasAttribute(tmpl, key) {
+			return fmt.Errorf("%w: %q is not on template %q", ErrInvalidReport, key, tmpl.Name)
+		}
+		if rating < 0 || rating > float64(tmpl.ScaleMax) {
+			return fmt.Errorf("%w: rating for %q must be between 0 and %d", ErrInvalidReport, key, tmpl.ScaleMax)
+		}
+	}
+	return nil
+}
+
+func templateHasAttribute(tmpl *model.ReportTemplate, key string) bool {
+	for _, attr := range tmpl.Attributes {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// humanize turns snake_case keys into sentence-case labels.
+func humanize(key string) string {
+	s := strings.ReplaceAll(key, "_", " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,639 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/client"
+	"github.com/scouttalent/profile-service/internal/importer"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var (
+	ErrInvalidImport      = errors.New("invalid roster import")
+	ErrRosterImportState  = errors.New("roster import cannot be changed in its current state")
+	errRosterInvitesUnset = fmt.Errorf("%w: account invites are not configured, only dry runs are available", ErrInvalidImport)
+)
+
+const (
+	// MaxRosterFileSize and MaxRosterRows bound roster uploads.
+	MaxRosterFileSize = 10 << 20
+	MaxRosterRows     = 2000
+
+	rosterBatchSize = 25
+
+	// rosterLease must comfortably cover one batch, including an auth-service
+	// call per row.
+	rosterLease = 5 * time.Minute
+)
+
+// StartRosterImport parses an uploaded roster and queues it for the
+// background importer. Only the academy's owner can import.
+func (s *ProfileService) StartRosterImport(ctx context.Context, userID, academyID, filename string, data []byte, req model.CreateRosterImportRequest) (*model.RosterImport, error) {
+	if _, err := s.academyOwnedBy(ctx, userID, academyID); err != nil {
+		return nil, err
+	}
+	if !req.DryRun && !s.auth.Enabled() {
+		return nil, errRosterInvitesUnset
+	}
+
+	format, err := importer.FormatFromFilename(filename)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	sheet, err := importer.Parse(format, data, MaxRosterRows)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if len(sheet.Rows) == 0 {
+		return nil, fmt.Errorf("%w: file has no data rows", ErrInvalidImport)
+	}
+
+	columns, mapping, err := resolveRosterMapping(sheet.Headers, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	imp := &model.RosterImport{
+		ID:               uuid.New().String(),
+		AcademyProfileID: academyID,
+		Filename:         truncateRunes(filename, 255),
+		Format:           format,
+		DryRun:           req.DryRun,
+		Mapping:          mapping,
+		Status:           model.RosterImportPending,
+		Progress:         model.RosterProgress{Total: len(sheet.Rows)},
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	rows := make([]*model.RosterImportRow, 0, len(sheet.Rows))
+	for _, r := range sheet.Rows {
+		data := make(map[string]string, len(columns))
+		for field, col := range columns {
+			if value := r.Cells[col]; value != "" {
+				data[field] = value
+			}
+		}
+		rows = append(rows, &model.RosterImportRow{
+			ImportID:  imp.ID,
+			RowNumber: r.Line,
+			Data:      data,
+			Status:    model.RosterRowPending,
+		})
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		return repo.CreateRosterImport(ctx, imp, rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+func (s *ProfileService) ListRosterImports(ctx context.Context, userID, academyID string, limit int) ([]*model.RosterImport, error) {
+	if _, err := s.academyOwnedBy(ctx, userID, academyID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRosterImports(ctx, academyID, limit)
+}
+
+func (s *ProfileService) GetRosterImport(ctx context.Context, userID, academyID, importID string) (*model.RosterImport, error) {
+	if _, err := s.academyOwnedBy(ctx, userID, academyID); err != nil {
+		return nil, err
+	}
+	return s.academyRosterImport(ctx, academyID, importID)
+}
+
+// ListRosterImportRows returns the per-row report, optionally filtered by
+// status (e.g. only invalid rows after a dry run).
+func (s *ProfileService) ListRosterImportRows(ctx context.Context, userID, academyID, importID string, status model.RosterRowStatus, limit, offset int) ([]*model.RosterImportRow, error) {
+	switch status {
+	case "", model.RosterRowPending, model.RosterRowValid, model.RosterRowInvalid,
+		model.RosterRowImported, model.RosterRowSkipped:
+	default:
+		return nil, fmt.Errorf("%w: unknown row status %q", ErrInvalidImport, status)
+	}
+
+	if _, err := s.GetRosterImport(ctx, userID, academyID, importID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRosterImportRows(ctx, importID, status, limit, offset)
+}
+
+// CancelRosterImport stops an import. Rows already imported are kept.
+func (s *ProfileService) CancelRosterImport(ctx context.Context, userID, academyID, importID string) (*model.RosterImport, error) {
+	return s.transitionRosterImport(ctx, userID, academyID, importID, model.RosterImportCancelled,
+		model.RosterImportPending, model.RosterImportRunning, model.RosterImportPaused)
+}
+
+// ResumeRosterImport re-queues an import that was paused by an error. It
+// continues from the first unprocessed row.
+func (s *ProfileService) ResumeRosterImport(ctx context.Context, userID, academyID, importID string) (*model.RosterImport, error) {
+	return s.transitionRosterImport(ctx, userID, academyID, importID, model.RosterImportPending,
+		model.RosterImportPaused)
+}
+
+// CommitRosterImport runs a completed dry run for real, importing the rows
+// that passed validation.
+func (s *ProfileService) CommitRosterImport(ctx context.Context, userID, academyID, importID string) (*model.RosterImport, error) {
+	if _, err := s.GetRosterImport(ctx, userID, academyID, importID); err != nil {
+		return nil, err
+	}
+	if !s.auth.Enabled() {
+		return nil, errRosterInvitesUnset
+	}
+
+	var committed bool
+	err := s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		var err error
+		committed, err = repo.CommitDryRun(ctx, importID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !committed {
+		return nil, ErrRosterImportState
+	}
+
+	return s.repo.GetRosterImport(ctx, importID)
+}
+
+func (s *ProfileService) transitionRosterImport(ctx context.Context, userID, academyID, importID string, to model.RosterImportStatus, from ...model.RosterImportStatus) (*model.RosterImport, error) {
+	if _, err := s.GetRosterImport(ctx, userID, academyID, importID); err != nil {
+		return nil, err
+	}
+
+	changed, err := s.repo.TransitionRosterImport(ctx, importID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return nil, ErrRosterImportState
+	}
+
+	return s.repo.GetRosterImport(ctx, importID)
+}
+
+// academyRosterImport loads an import and hides imports of other academies.
+func (s *ProfileService) academyRosterImport(ctx context.Context, academyID, importID string) (*model.RosterImport, error) {
+	imp, err := s.repo.GetRosterImport(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	if imp.AcademyProfileID != academyID {
+		return nil, repository.ErrRosterImportNotFound
+	}
+	return imp, nil
+}
+
+// ProcessRosterImportBatch claims the next runnable import and processes one
+// batch of its rows. It reports whether there was an import to work on, so
+// the caller can keep draining the queue. Progress is committed row by row;
+// an error pauses the import at the failing row until it is resumed.
+func (s *ProfileService) ProcessRosterImportBatch(ctx context.Context) (bool, error) {
+	imp, err := s.repo.ClaimRosterImport(ctx, rosterLease)
+	if err != nil || imp == nil {
+		return false, err
+	}
+
+	pause := func(cause error) error {
+		msg := cause.Error()
+		return s.repo.FinishRosterImport(ctx, imp.ID, model.RosterImportPaused, &msg)
+	}
+
+	academy, err := s.repo.GetByID(ctx, imp.AcademyProfileID)
+	if err != nil {
+		return true, pause(err)
+	}
+
+	rows, err := s.repo.PendingRosterRows(ctx, imp.ID, rosterBatchSize)
+	if err != nil {
+		return true, pause(err)
+	}
+
+	for _, row := range rows {
+		if err := s.processRosterRow(ctx, imp, academy, row); err != nil {
+			return true, pause(fmt.Errorf("row %d: %w", row.RowNumber, err))
+		}
+	}
+
+	if len(rows) < rosterBatchSize {
+		return true, s.repo.FinishRosterImport(ctx, imp.ID, model.RosterImportCompleted, nil)
+	}
+	return true, s.repo.ReleaseRosterImport(ctx, imp.ID)
+}
+
+// processRosterRow validates one row and, unless the import is a dry run,
+// invites the account and creates the player profile. Row-level problems are
+// recorded on the row; only infrastructure errors are returned.
+func (s *ProfileService) processRosterRow(ctx context.Context, imp *model.RosterImport, academy *model.Profile, row *model.RosterImportRow) error {
+	now := time.Now()
+	row.ProcessedAt = &now
+	row.Errors = []string{}
+
+	record, problems := parseRosterRow(row.Data)
+	if record != nil {
+		earlier, err := s.repo.EarlierRosterRowWithEmail(ctx, imp.ID, row.RowNumber, record.Email)
+		if err != nil {
+			return err
+		}
+		if earlier > 0 {
+			problems = append(problems, fmt.Sprintf("email: same as row %d", earlier))
+		}
+	}
+	if len(problems) > 0 {
+		row.Status = model.RosterRowInvalid
+		row.Errors = problems
+		return s.repo.UpdateRosterRow(ctx, row)
+	}
+	if imp.DryRun {
+		row.Status = model.RosterRowValid
+		return s.repo.UpdateRosterRow(ctx, row)
+	}
+
+	invited, err := s.auth.InviteUser(ctx, record.Email, string(model.UserTypePlayer), "academy:"+academy.ID)
+	if err != nil {
+		return err
+	}
+	row.UserID = &invited.UserID
+
+	if invited.Role != string(model.UserTypePlayer) {
+		row.Status = model.RosterRowSkipped
+		row.Errors = []string{fmt.Sprintf("email belongs to an existing %s account", invited.Role)}
+		return s.repo.UpdateRosterRow(ctx, row)
+	}
+	// Only claim accounts this import created or that nobody has signed in
+	// to yet; anything else belongs to someone who joined on their own
+	if !invited.Created && invited.Status != client.AccountStatusInvited {
+		row.Status = model.RosterRowSkipped
+		row.Errors = []string{"email belongs to an existing account"}
+		return s.repo.UpdateRosterRow(ctx, row)
+	}
+	existing, err := s.repo.GetByUserID(ctx, invited.UserID)
+	if err == nil {
+		row.Status = model.RosterRowSkipped
+		row.Errors = []string{"account already has a profile"}
+		row.ProfileID = &existing.ID
+		return s.repo.UpdateRosterRow(ctx, row)
+	}
+	if !errors.Is(err, repository.ErrProfileNotFound) {
+		return err
+	}
+
+	profile := &model.Profile{
+		ID:          uuid.New().String(),
+		UserID:      invited.UserID,
+		Type:        model.UserTypePlayer,
+		DisplayName: record.Profile.DisplayName,
+		Bio:         record.Profile.Bio,
+		TrustLevel:  model.TrustLevelNewcomer,
+		Privacy:     model.DefaultPrivacySettings(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := resolveLocation(profile, record.Profile.LocationCountry, record.Profile.LocationCity, nil); err != nil {
+		return err
+	}
+	details := &model.PlayerDetails{
+		ProfileID:     profile.ID,
+		Position:      record.Player.Position,
+		DateOfBirth:   record.Player.DateOfBirth,
+		HeightCM:      record.Player.HeightCM,
+		WeightKG:      record.Player.WeightKG,
+		PreferredFoot: record.Player.PreferredFoot,
+		CurrentTeam:   record.Player.CurrentTeam,
+	}
+	if details.CurrentTeam == nil {
+		details.CurrentTeam = &academy.DisplayName
+	}
+
+	return s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := s.insertProfile(ctx, repo, profile); err != nil {
+			return err
+		}
+		if _, err := insertPlayerDetails(ctx, repo, rosterActor(academy), profile, details); err != nil {
+			return err
+		}
+
+		if invited.InviteToken != "" {
+			invite := &model.RosterInvite{
+				Email:            invited.Email,
+				UserID:           invited.UserID,
+				ProfileID:        profile.ID,
+				AcademyProfileID: academy.ID,
+				AcademyName:      academy.DisplayName,
+				InviteURL:        strings.TrimSuffix(s.publicBaseURL, "/") + "/invite?token=" + url.QueryEscape(invited.InviteToken),
+				ExpiresAt:        invited.ExpiresAt,
+			}
+			if err := enqueueEvent(ctx, repo, model.EventRosterPlayerInvited, profile.ID, invite); err != nil {
+				return err
+			}
+		}
+
+		row.Status = model.RosterRowImported
+		row.ProfileID = &profile.ID
+		return repo.UpdateRosterRow(ctx, row)
+	})
+}
+
+// WriteRosterReportCSV writes the per-row report so academies can fix the
+// invalid rows in their spreadsheet.
+func (s *ProfileService) WriteRosterReportCSV(w io.Writer, rows []*model.RosterImportRow) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"row", "status", "errors"}
+	for _, field := range model.RosterImportFields {
+		header = append(header, field.Name)
+	}
+	header = append(header, "profile_id")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := []string{
+			strconv.Itoa(row.RowNumber),
+			string(row.Status),
+			strings.Join(row.Errors, "; "),
+		}
+		for _, field := range model.RosterImportFields {
+			record = append(record, row.Data[field.Name])
+		}
+		record = append(record, derefString(row.ProfileID))
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// resolveRosterMapping works out which column feeds each field. Explicit
+// mappings (field -> header) win; remaining fields are matched against the
+// headers by name or alias. It returns field -> column index along with the
+// effective field -> header mapping.
+func resolveRosterMapping(headers []string, explicit map[string]string) (map[string]int, map[string]string, error) {
+	byHeader := make(map[string]int, len(headers))
+	for i, h := range headers {
+		key := normalizeHeader(h)
+		if _, dup := byHeader[key]; !dup && key != "" {
+			byHeader[key] = i
+		}
+	}
+
+	columns := make(map[string]int)
+	for field, header := range explicit {
+		if !validRosterField(field) {
+			return nil, nil, fmt.Errorf("%w: unknown field %q in mapping", ErrInvalidImport, field)
+		}
+		col, ok := byHeader[normalizeHeader(header)]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: column %q not found for field %q", ErrInvalidImport, header, field)
+		}
+		columns[field] = col
+	}
+
+	missing := []string{}
+	for _, field := range model.RosterImportFields {
+		if _, ok := columns[field.Name]; ok {
+			continue
+		}
+		for _, candidate := range append([]string{field.Name}, field.Aliases...) {
+			if col, ok := byHeader[normalizeHeader(candidate)]; ok {
+				columns[field.Name] = col
+				break
+			}
+		}
+		if _, ok := columns[field.Name]; !ok && field.Required {
+			missing = append(missing, field.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("%w: no column mapped for %s", ErrInvalidImport, strings.Join(missing, ", "))
+	}
+
+	mapping := make(map[string]string, len(columns))
+	for field, col := range columns {
+		mapping[field] = headers[col]
+	}
+	return columns, mapping, nil
+}
+
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return strings.Join(strings.FieldsFunc(h, func(r rune) bool {
+		return r == ' ' || r == '_'
+	}), " ")
+}
+
+func validRosterField(name string) bool {
+	for _, field := range model.RosterImportFields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// rosterRecord is a roster row converted to the requests used by the
+// regular profile endpoints.
+type rosterRecord struct {
+	Email   string
+	Profile model.CreateProfileRequest
+	Player  model.CreatePlayerDetailsRequest
+}
+
+// rosterAccount carries the email through the same validator as the request
+// types.
+type rosterAccount struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+// rosterPositions maps common spreadsheet abbreviations onto positions.
+var rosterPositions = map[string]string{
+	"gk": "goalkeeper", "goalie": "goalkeeper", "keeper": "goalkeeper",
+	"df": "defender", "def": "defender", "cb": "defender", "lb": "defender", "rb": "defender",
+	"mf": "midfielder", "mid": "midfielder", "cm": "midfielder", "dm": "midfielder", "am": "midfielder",
+	"fw": "forward", "fwd": "forward", "st": "forward", "cf": "forward", "striker": "forward",
+}
+
+// rosterDateLayouts are the accepted date_of_birth formats. Slash-separated
+// day/month orders are ambiguous and deliberately not accepted.
+var rosterDateLayouts = []string{"2006-01-02", "2006/01/02", "02.01.2006"}
+
+// excelEpoch is day zero of Excel's 1900 date system, which XLSX date cells
+// count from.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+const minExcelBirthSerial = 10000
+
+// parseRosterRow converts a row to profile requests and validates them with
+// the same binding rules as CreateProfile and CreatePlayerDetails. It returns
+// a nil record when the row cannot be converted at all.
+func parseRosterRow(data map[string]string) (*rosterRecord, []string) {
+	problems := []string{}
+	optional := func(field string) *string {
+		if v, ok := data[field]; ok {
+			return &v
+		}
+		return nil
+	}
+
+	record := &rosterRecord{
+		Email: strings.TrimSpace(data[model.RosterFieldEmail]),
+		Profile: model.CreateProfileRequest{
+			DisplayName:     data[model.RosterFieldDisplayName],
+			Bio:             optional(model.RosterFieldBio),
+			LocationCountry: optional(model.RosterFieldLocationCountry),
+			LocationCity:    optional(model.RosterFieldLocationCity),
+		},
+		Player: model.CreatePlayerDetailsRequest{
+			Position:    strings.ToLower(data[model.RosterFieldPosition]),
+			CurrentTeam: optional(model.RosterFieldCurrentTeam),
+		},
+	}
+	if position, ok := rosterPositions[record.Player.Position]; ok {
+		record.Player.Position = position
+	}
+	if foot := optional(model.RosterFieldPreferredFoot); foot != nil {
+		lower := strings.ToLower(*foot)
+		record.Player.PreferredFoot = &lower
+	}
+
+	var err error
+	if record.Player.HeightCM, err = parseRosterInt(data, model.RosterFieldHeightCM); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if record.Player.WeightKG, err = parseRosterInt(data, model.RosterFieldWeightKG); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if record.Player.DateOfBirth, err = parseRosterDate(data, model.RosterFieldDateOfBirth); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	problems = append(problems, validationProblems(&rosterAccount{Email: record.Email})...)
+	problems = append(problems, validationProblems(&record.Profile)...)
+	problems = append(problems, validationProblems(&record.Player)...)
+	if err := resolveLocation(&model.Profile{}, record.Profile.LocationCountry, record.Profile.LocationCity, nil); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return record, nil
+}
+
+func parseRosterInt(data map[string]string, field string) (*int, error) {
+	raw, ok := data[field]
+	if !ok {
+		return nil, nil
+	}
+	// Spreadsheet numbers can arrive as "180.0"; accept whole numbers only
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f != float64(int(f)) {
+		return nil, fmt.Errorf("%s: %q is not a whole number", field, raw)
+	}
+	n := int(f)
+	return &n, nil
+}
+
+func parseRosterDate(data map[string]string, field string) (*time.Time, error) {
+	raw, ok := data[field]
+	if !ok {
+		return nil, nil
+	}
+
+	for _, layout := range rosterDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return checkBirthDate(field, t)
+		}
+	}
+	// Small serials are far more likely to be a bare year or a typo than a
+	// birth date before 1927
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil && serial >= minExcelBirthSerial {
+		t := excelEpoch.AddDate(0, 0, int(serial))
+		return checkBirthDate(field, t)
+	}
+
+	return nil, fmt.Errorf("%s: %q is not a date, use YYYY-MM-DD", field, raw)
+}
+
+func checkBirthDate(field string, t time.Time) (*time.Time, error) {
+	if !t.Before(time.Now()) {
+		return nil, fmt.Errorf("%s: must be in the past", field)
+	}
+	return &t, nil
+}
+
+// validationProblems runs gin's binding validator over obj (a pointer to a
+// request struct) and describes each failure by its JSON field name.
+func validationProblems(obj any) []string {
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return []string{err.Error()}
+	}
+
+	t := reflect.TypeOf(obj).Elem()
+	problems := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		name := fe.Field()
+		if sf, ok := t.FieldByName(fe.StructField()); ok {
+			if tag, _, _ := strings.Cut(sf.Tag.Get("json"), ","); tag != "" {
+				name = tag
+			}
+		}
+		problems = append(problems, name+": "+describeValidation(fe))
+	}
+	return problems
+}
+
+func describeValidation(fe validator.FieldError) string {
+	unit := ""
+	if fe.Kind() == reflect.String {
+		unit = " characters"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min":
+		return "must be at least " + fe.Param() + unit
+	case "max":
+		return "must be at most " + fe.Param() + unit
+	default:
+		return "failed " + fe.Tag() + " validation"
+	}
+}
+
+// rosterActor attributes imported players to the academy that imported them.
+func rosterActor(academy *model.Profile) model.Viewer {
+	return model.Viewer{UserID: academy.UserID, Role: string(model.UserTypeAcademy)}
+}
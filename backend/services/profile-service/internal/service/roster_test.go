@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRosterDate(t *testing.T) {
+	nextYear := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+
+	tests := []struct {
+		name    string
+		raw     *string
+		want    string
+		wantErr bool
+	}{
+		{name: "missing", raw: nil},
+		{name: "iso", raw: ptr("2008-04-17"), want: "2008-04-17"},
+		{name: "slashes year first", raw: ptr("2008/04/17"), want: "2008-04-17"},
+		{name: "dotted day first", raw: ptr("17.04.2008"), want: "2008-04-17"},
+		{name: "excel serial", raw: ptr("36526"), want: "2000-01-01"},
+		{name: "excel serial with fraction", raw: ptr("36526.75"), want: "2000-01-01"},
+		{name: "ambiguous slashes", raw: ptr("04/05/2008"), wantErr: true},
+		{name: "bare year", raw: ptr("2008"), wantErr: true},
+		{name: "not a date", raw: ptr("yesterday"), wantErr: true},
+		{name: "invalid day", raw: ptr("2008-02-30"), wantErr: true},
+		{name: "future", raw: ptr(nextYear), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]string{}
+			if tt.raw != nil {
+				data["date_of_birth"] = *tt.raw
+			}
+
+			got, err := parseRosterDate(data, "date_of_birth")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseRosterDate = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("parseRosterDate = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Format("2006-01-02") != tt.want {
+				t.Errorf("parseRosterDate = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
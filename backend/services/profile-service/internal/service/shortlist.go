@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var ErrInvalidShortlist = errors.New("invalid shortlist")
+
+func (s *ProfileService) CreateShortlist(ctx context.Context, viewer model.Viewer, req model.CreateShortlistRequest) (*model.Shortlist, error) {
+	actor, err := s.recruiterProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AcademyProfileID != nil && *req.AcademyProfileID != actor.ID {
+		isStaff, err := s.repo.IsAcademyStaff(ctx, *req.AcademyProfileID, actor.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !isStaff {
+			return nil, ErrForbidden
+		}
+	}
+
+	now := time.Now()
+	list := &model.Shortlist{
+		ID:               uuid.New().String(),
+		OwnerProfileID:   actor.ID,
+		AcademyProfileID: req.AcademyProfileID,
+		Name:             strings.TrimSpace(req.Name),
+		Description:      req.Description,
+		Entries:          []*model.ShortlistEntry{},
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if list.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidShortlist)
+	}
+
+	if err := s.repo.CreateShortlist(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *ProfileService) ListShortlists(ctx context.Context, viewer model.Viewer) ([]*model.Shortlist, error) {
+	actor, err := s.recruiterProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListShortlistsForProfile(ctx, actor.ID)
+}
+
+// GetShortlist returns the list with its entries in order, optionally
+// filtered by pipeline status and tag.
+func (s *ProfileService) GetShortlist(ctx context.Context, viewer model.Viewer, shortlistID string, status model.ShortlistStatus, tag string) (*model.Shortlist, error) {
+	_, list, err := s.accessibleShortlist(ctx, viewer, shortlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.ListShortlistEntries(ctx, shortlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	list.Entries = make([]*model.ShortlistEntry, 0, len(entries))
+	for _, entry := range entries {
+		if status != "" && entry.Status != status {
+			continue
+		}
+		if tag != "" && !containsTag(entry.Tags, tag) {
+			continue
+		}
+		list.Entries = append(list.Entries, entry)
+	}
+
+	return list, nil
+}
+
+func (s *ProfileService) UpdateShortlist(ctx context.Context, viewer model.Viewer, shortlistID string, req model.UpdateShortlistRequest) (*model.Shortlist, error) {
+	actor, list, err := s.accessibleShortlist(ctx, viewer, shortlistID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageShortlist(actor, list) {
+		return nil, ErrForbidden
+	}
+
+	if req.Name != nil {
+		list.Name = strings.TrimSpace(*req.Name)
+		if list.Name == "" {
+			return nil, fmt.Errorf("%w: name is required", ErrInvalidShortlist)
+		}
+	}
+	if req.Description != nil {
+		list.Description = req.Description
+	}
+	list.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateShortlist(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *ProfileService) DeleteShortlist(ctx context.Context, viewer model.Viewer, shortlistID string) error {
+	actor, list, err := s.accessibleShortlist(ctx, viewer, shortlistID)
+	if err != nil {
+		return err
+	}
+	if !canManageShortlist(actor, list) {
+		return ErrForbidden
+	}
+	return s.repo.DeleteShortlist(ctx, shortlistID)
+}
+
+func (s *ProfileService) AddShortlistEntry(ctx context.Context, viewer model.Viewer, shortlistID string, req model.AddShortlistEntryRequest) (*model.ShortlistEntry, error) {
+	actor, _, err := s.accessibleShortlist(ctx, viewer, shortlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := s.visibleProfile(ctx, viewer, req.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if player.Type != model.UserTypePlayer {
+		return nil, ErrNotPlayer
+	}
+
+	status := model.ShortlistStatusWatching
+	if req.Status != nil {
+		status = *req.Status
+	}
+
+	now := time.Now()
+	entry := &model.ShortlistEntry{
+		ID:          uuid.New().String(),
+		ShortlistID: shortlistID,
+		PlayerID:    player.ID,
+		Status:      status,
+		Notes:       req.Notes,
+		Tags:        normalizeTags(req.Tags),
+		AddedBy:     &actor.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repo.AddShortlistEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetShortlistEntry(ctx, shortlistID, entry.ID)
+}
+
+func (s *ProfileService) UpdateShortlistEntry(ctx context.Context, viewer model.Viewer, shortlistID, entryID string, req model.UpdateShortlistEntryRequest) (*model.ShortlistEntry, error) {
+	if _, _, err := s.accessibleShortlist(ctx, viewer, shortlistID); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.repo.GetShortlistEntry(ctx, shortlistID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status != nil {
+		entry.Status = *req.Status
+	}
+	if req.Notes != nil {
+		entry.Notes = req.Notes
+	}
+	if req.Tags != nil {
+		entry.Tags = normalizeTags(req.Tags)
+	}
+	entry.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateShortlistEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *ProfileService) RemoveShortlistEntry(ctx context.Context, viewer model.Viewer, shortlistID, entryID string) error {
+	if _, _, err := s.accessibleShortlist(ctx, viewer, shortlistID); err != nil {
+		return err
+	}
+	return s.repo.DeleteShortlistEntry(ctx, shortlistID, entryID)
+}
+
+// ReorderShortlist sets the order of every entry on the list at once.
+func (s *ProfileService) ReorderShortlist(ctx context.Context, viewer model.Viewer, shortlistID string, req model.ReorderShortlistRequest) (*model.Shortlist, error) {
+	if _, _, err := s.accessibleShortlist(ctx, viewer, shortlistID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.EntryIDs))
+	for _, id := range req.EntryIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate entry %s", ErrInvalidShortlist, id)
+		}
+		seen[id] = true
+	}
+
+	err := s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		count, err := repo.CountShortlistEntries(ctx, shortlistID)
+		if err != nil {
+			return err
+		}
+		if count != len(req.EntryIDs) {
+			return fmt.Errorf("%w: entry_ids must list all %d entries", ErrInvalidShortlist, count)
+		}
+		return repo.ReorderShortlist(ctx, shortlistID, req.EntryIDs, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetShortlist(ctx, viewer, shortlistID, "", "")
+}
+
+// WriteShortlistCSV writes the list's entries in order as CSV.
+func (s *ProfileService) WriteShortlistCSV(w io.Writer, list *model.Shortlist) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"rank", "player", "position", "country", "overall_score",
+		"status", "tags", "notes", "added_at", "profile_url",
+	}); err != nil {
+		return err
+	}
+
+	baseURL := strings.TrimSuffix(s.publicBaseURL, "/")
+	for i, entry := range list.Entries {
+		player := entry.Player
+		overall := ""
+		if player.OverallScore != nil {
+			overall = strconv.FormatFloat(*player.OverallScore, 'f', 1, 64)
+		}
+		if err := cw.Write([]string{
+			strconv.Itoa(i + 1),
+			player.DisplayName,
+			derefString(player.Position),
+			derefString(player.LocationCountry),
+			overall,
+			string(entry.Status),
+			strings.Join(entry.Tags, ";"),
+			derefString(entry.Notes),
+			entry.CreatedAt.Format(time.RFC3339),
+			baseURL + "/p/" + player.Slug,
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (s *ProfileService) ListAcademyStaff(ctx context.Context, userID, academyID string) ([]*model.AcademyStaffMember, error) {
+	if _, err := s.academyOwnedBy(ctx, userID, academyID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAcademyStaff(ctx, academyID)
+}
+
+// AddAcademyStaff lets an academy attach a scout profile to its staff.
+func (s *ProfileService) AddAcademyStaff(ctx context.Context, userID, academyID string, req model.AddStaffRequest) ([]*model.AcademyStaffMember, error) {
+	academy, err := s.academyOwnedBy(ctx, userID, academyID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.GetByID(ctx, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Type != model.UserTypeScout {
+		return nil, fmt.Errorf("%w: staff members must be scout profiles", ErrInvalidShortlist)
+	}
+
+	if err := s.repo.AddAcademyStaff(ctx, academyID, member.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.storeCompletionScore(ctx, academy); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListAcademyStaff(ctx, academyID)
+}
+
+func (s *ProfileService) RemoveAcademyStaff(ctx context.Context, userID, academyID, memberID string) error {
+	academy, err := s.academyOwnedBy(ctx, userID, academyID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RemoveAcademyStaff(ctx, academyID, memberID); err != nil {
+		return err
+	}
+	return s.storeCompletionScore(ctx, academy)
+}
+
+func (s *ProfileService) academyOwnedBy(ctx context.Context, userID, academyID string) (*model.Profile, error) {
+	academy, err := s.repo.GetByID(ctx, academyID)
+	if err != nil {
+		return nil, err
+	}
+	if academy.UserID != userID || academy.Type != model.UserTypeAcademy {
+		return nil, ErrForbidden
+	}
+	return academy, nil
+}
+
+// recruiterProfile resolves the caller's profile and requires it to be a
+// scout or academy.
+func (s *ProfileService) recruiterProfile(ctx context.Context, userID string) (*model.Profile, error) {
+	actor, err := s.actorProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Type != model.UserTypeScout && actor.Type != model.UserTypeAcademy {
+		return nil, ErrForbidden
+	}
+	return actor, nil
+}
+
+// accessibleShortlist loads a list the caller may use: one it owns, one
+// shared with its academy, or one shared with an academy it is staff of.
+// Other lists are reported as not found.
+func (s *ProfileService) accessibleShortlist(ctx context.Context, viewer model.Viewer, shortlistID string) (*model.Profile, *model.Shortlist, error) {
+	actor, err := s.recruiterProfile(ctx, viewer.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, err := s.repo.GetShortlist(ctx, shortlistID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if list.OwnerProfileID == actor.ID {
+		return actor, list, nil
+	}
+	if list.AcademyProfileID != nil {
+		if *list.AcademyProfileID == actor.ID {
+			return actor, list, nil
+		}
+		isStaff, err := s.repo.IsAcademyStaff(ctx, *list.AcademyProfileID, actor.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isStaff {
+			return actor, list, nil
+		}
+	}
+
+	return nil, nil, repository.ErrShortlistNotFound
+}
+
+// canManageShortlist reports whether actor may rename or delete the list.
+// Staff can edit entries on shared lists but not the list itself.
+func canManageShortlist(actor *model.Profile, list *model.Shortlist) bool {
+	if list.OwnerProfileID == actor.ID {
+		return true
+	}
+	return list.AcademyProfileID != nil && *list.AcademyProfileID == actor.ID
+}
+
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func containsTag(tags []string, tag string) bool {
+	tag = strings.ToLower(tag)
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var ErrInvalidAssessment = errors.New("invalid skill assessment")
+
+const (
+	// skillScoreWindow limits which assessments feed the current skill scores
+	skillScoreWindow = 365 * 24 * time.Hour
+
+	defaultCoachConfidence = 0.8
+	minConfidence          = 0.01
+
+	// Column limits, checked here as well as by request binding because
+	// the NATS consumer does not bind
+	maxAssessorIDLength   = 100
+	maxModelVersionLength = 50
+	maxNotesLength        = 2000
+)
+
+// SubmitAIAssessment stores an assessment produced by a scoring worker and
+// recomputes the player's skill scores.
+func (s *ProfileService) SubmitAIAssessment(ctx context.Context, req model.SubmitAssessmentRequest) (*model.SkillAssessment, error) {
+	if req.WorkerID == "" {
+		return nil, fmt.Errorf("%w: worker_id is required", ErrInvalidAssessment)
+	}
+	if req.ModelVersion != nil && utf8.RuneCountInString(*req.ModelVersion) > maxModelVersionLength {
+		return nil, fmt.Errorf("%w: model_version is longer than %d characters", ErrInvalidAssessment, maxModelVersionLength)
+	}
+
+	assessedAt := time.Now()
+	if req.AssessedAt != nil {
+		assessedAt = *req.AssessedAt
+	}
+
+	assessment := &model.SkillAssessment{
+		ID:           uuid.New().String(),
+		ProfileID:    req.ProfileID,
+		Source:       model.AssessmentSourceAI,
+		AssessorID:   req.WorkerID,
+		VideoID:      req.VideoID,
+		ModelVersion: req.ModelVersion,
+		Confidence:   req.Confidence,
+		Scores:       req.Scores,
+		AssessedAt:   assessedAt,
+		CreatedAt:    time.Now(),
+	}
+
+	return s.recordAssessment(ctx, assessment)
+}
+
+// SubmitCoachAssessment stores an assessment from a scout or academy coach.
+// Only a coach's latest assessment of a player counts towards the player's
+// scores; earlier ones stay in the history.
+func (s *ProfileService) SubmitCoachAssessment(ctx context.Context, userID, profileID string, req model.CoachAssessmentRequest) (*model.SkillAssessment, error) {
+	coach, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			return nil, ErrForbidden
+		}
+		return nil, err
+	}
+	if coach.Type != model.UserTypeScout && coach.Type != model.UserTypeAcademy {
+		return nil, ErrForbidden
+	}
+	if coach.ID == profileID {
+		return nil, ErrForbidden
+	}
+
+	confidence := defaultCoachConfidence
+	if req.Confidence != nil {
+		confidence = *req.Confidence
+	}
+
+	now := time.Now()
+	assessment := &model.SkillAssessment{
+		ID:         uuid.New().String(),
+		ProfileID:  profileID,
+		Source:     model.AssessmentSourceCoach,
+		AssessorID: coach.ID,
+		VideoID:    req.VideoID,
+		Confidence: confidence,
+		Notes:      req.Notes,
+		Scores:     req.Scores,
+		AssessedAt: now,
+		CreatedAt:  now,
+	}
+
+	return s.recordAssessment(ctx, assessment)
+}
+
+func (s *ProfileService) ListSkillAssessments(ctx context.Context, viewer model.Viewer, profileID string, limit, offset int) ([]*model.SkillAssessment, error) {
+	if _, err := s.requireVisiblePlayer(ctx, viewer, profileID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListSkillAssessments(ctx, profileID, limit, offset)
+}
+
+func (s *ProfileService) GetSkillHistory(ctx context.Context, viewer model.Viewer, profileID, skill string, limit int) ([]*model.SkillHistoryPoint, error) {
+	if !model.ValidSkill(skill) {
+		return nil, fmt.Errorf("%w: unknown skill %q", ErrInvalidAssessment, skill)
+	}
+	if _, err := s.requireVisiblePlayer(ctx, viewer, profileID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSkillHistory(ctx, profileID, skill, limit)
+}
+
+func (s *ProfileService) recordAssessment(ctx context.Context, assessment *model.SkillAssessment) (*model.SkillAssessment, error) {
+	if err := validateAssessment(assessment); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		position, err := repo.LockPlayerSkills(ctx, assessment.ProfileID)
+		if err != nil {
+			return err
+		}
+		if err := repo.CreateSkillAssessment(ctx, assessment); err != nil {
+			return err
+		}
+		return recomputeSkillScores(ctx, repo, assessment.ProfileID, position)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assessment, nil
+}
+
+// recomputeSkillScores rebuilds player_details.skill_scores and overall_score
+// from the confidence-weighted assessments inside skillScoreWindow, counting
+// each coach's latest assessment only. It runs inside the transaction that
+// holds the player's skill row lock.
+func recomputeSkillScores(ctx context.Context, repo *repository.ProfileRepository, profileID, position string) error {
+	now := time.Now()
+	weighted, err := repo.GetWeightedSkillScores(ctx, profileID, now.Add(-skillScoreWindow))
+	if err != nil {
+		return err
+	}
+
+	scores := buildSkillScores(weighted)
+	overall := overallScore(position, scores)
+
+	if err := repo.UpdateSkillScores(ctx, profileID, scores, overall, now); err != nil {
+		return err
+	}
+	_, err = enqueuePlayerDetailsUpdated(ctx, repo, profileID)
+	return err
+}
+
+// buildSkillScores groups flat skill keys into categories. A category's score
+// is its directly assessed value when present, otherwise the mean of its
+// sub-skills.
+func buildSkillScores(flat map[string]float64) model.SkillScores {
+	scores := make(model.SkillScores)
+	direct := make(map[model.SkillCategory]bool)
+
+	for key, value := range flat {
+		category, sub := model.SplitSkill(key)
+		cs := scores[category]
+		if sub == "" {
+			cs.Score = roundScore(value)
+			direct[category] = true
+		} else {
+			if cs.SubSkills == nil {
+				cs.SubSkills = make(map[string]float64)
+			}
+			cs.SubSkills[sub] = roundScore(value)
+		}
+		scores[category] = cs
+	}
+
+	for category, cs := range scores {
+		if direct[category] || len(cs.SubSkills) == 0 {
+			continue
+		}
+		sum := 0.0
+		for _, v := range cs.SubSkills {
+			sum += v
+		}
+		cs.Score = roundScore(sum / float64(len(cs.SubSkills)))
+		scores[category] = cs
+	}
+
+	return scores
+}
+
+// overallScore applies the position weights to the category scores,
+// renormalising over the categories that have been assessed. It returns nil
+// when the position is unknown or no weighted category has a score.
+func overallScore(position string, scores model.SkillScores) *float64 {
+	weights, ok := model.PositionSkillWeights[position]
+	if !ok {
+		return nil
+	}
+
+	total, weightSum := 0.0, 0.0
+	for category, weight := range weights {
+		cs, ok := scores[category]
+		if !ok {
+			continue
+		}
+		total += cs.Score * weight
+		weightSum += weight
+	}
+	if weightSum == 0 {
+		return nil
+	}
+
+	overall := roundScore(total / weightSum)
+	return &overall
+}
+
+func validateAssessment(assessment *model.SkillAssessment) error {
+	if _, err := uuid.Parse(assessment.ProfileID); err != nil {
+		return fmt.Errorf("%w: invalid profile_id", ErrInvalidAssessment)
+	}
+	if utf8.RuneCountInString(assessment.AssessorID) > maxAssessorIDLength {
+		return fmt.Errorf("%w: assessor is longer than %d characters", ErrInvalidAssessment, maxAssessorIDLength)
+	}
+	if assessment.VideoID != nil {
+		if _, err := uuid.Parse(*assessment.VideoID); err != nil {
+			return fmt.Errorf("%w: invalid video_id", ErrInvalidAssessment)
+		}
+	}
+	if assessment.Notes != nil && utf8.RuneCountInString(*assessment.Notes) > maxNotesLength {
+		return fmt.Errorf("%w: notes are longer than %d characters", ErrInvalidAssessment, maxNotesLength)
+	}
+	// Confidence is stored as DECIMAL(3,2), so anything below 0.01 would be
+	// rounded to zero and break the weighted mean
+	if assessment.Confidence < minConfidence || assessment.Confidence > 1 {
+		return fmt.Errorf("%w: confidence must be between %.2f and 1", ErrInvalidAssessment, minConfidence)
+	}
+	if len(assessment.Scores) == 0 {
+		return fmt.Errorf("%w: at least one score is required", ErrInvalidAssessment)
+	}
+	for skill, score := range assessment.Scores {
+		if !model.ValidSkill(skill) {
+			return fmt.Errorf("%w: unknown skill %q", ErrInvalidAssessment, skill)
+		}
+		if score < 0 || score > model.MaxSkillScore {
+			return fmt.Errorf("%w: score for %q must be between 0 and %.0f", ErrInvalidAssessment, skill, model.MaxSkillScore)
+		}
+	}
+	if assessment.AssessedAt.After(time.Now().Add(5 * time.Minute)) {
+		return fmt.Errorf("%w: assessed_at is in the future", ErrInvalidAssessment)
+	}
+	return nil
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/model"
+)
+
+func TestBuildSkillScores(t *testing.T) {
+	tests := []struct {
+		name string
+		flat map[string]float64
+		want model.SkillScores
+	}{
+		{
+			name: "empty",
+			flat: map[string]float64{},
+			want: model.SkillScores{},
+		},
+		{
+			name: "category only",
+			flat: map[string]float64{"passing": 7.26},
+			want: model.SkillScores{
+				model.SkillPassing: {Score: 7.3},
+			},
+		},
+		{
+			name: "sub-skills average into category",
+			flat: map[string]float64{"pace.acceleration": 8, "pace.sprint_speed": 7},
+			want: model.SkillScores{
+				model.SkillPace: {
+					Score:     7.5,
+					SubSkills: map[string]float64{"acceleration": 8, "sprint_speed": 7},
+				},
+			},
+		},
+		{
+			name: "direct category score wins over sub-skills",
+			flat: map[string]float64{"shooting": 6, "shooting.finishing": 9.04},
+			want: model.SkillScores{
+				model.SkillShooting: {
+					Score:     6,
+					SubSkills: map[string]float64{"finishing": 9},
+				},
+			},
+		},
+		{
+			name: "categories are independent",
+			flat: map[string]float64{"defending.marking": 5, "physical": 8},
+			want: model.SkillScores{
+				model.SkillDefending: {Score: 5, SubSkills: map[string]float64{"marking": 5}},
+				model.SkillPhysical:  {Score: 8},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSkillScores(tt.flat)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildSkillScores = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverallScore(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		position string
+		scores   model.SkillScores
+		want     *float64
+	}{
+		{
+			name:     "unknown position",
+			position: "sweeper",
+			scores:   model.SkillScores{model.SkillPassing: {Score: 7}},
+			want:     nil,
+		},
+		{
+			name:     "no weighted category assessed",
+			position: "goalkeeper",
+			scores:   model.SkillScores{model.SkillShooting: {Score: 9}},
+			want:     nil,
+		},
+		{
+			name:     "no scores",
+			position: "forward",
+			scores:   model.SkillScores{},
+			want:     nil,
+		},
+		{
+			name:     "every weighted category",
+			position: "goalkeeper",
+			scores: model.SkillScores{
+				model.SkillGoalkeeping: {Score: 7},
+				model.SkillPassing:     {Score: 7},
+				model.SkillPhysical:    {Score: 7},
+				model.SkillPace:        {Score: 7},
+			},
+			want: score(7),
+		},
+		{
+			name:     "renormalised over assessed categories",
+			position: "forward",
+			scores: model.SkillScores{
+				model.SkillShooting: {Score: 8},
+				model.SkillPace:     {Score: 6},
+			},
+			// (8*0.35 + 6*0.20) / 0.55
+			want: score(7.3),
+		},
+		{
+			name:     "unweighted categories are ignored",
+			position: "defender",
+			scores: model.SkillScores{
+				model.SkillDefending:   {Score: 6},
+				model.SkillGoalkeeping: {Score: 10},
+			},
+			want: score(6),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := overallScore(tt.position, tt.scores)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("overallScore = %v, want nil", *got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("overallScore = nil, want %v", *tt.want)
+			}
+			if *got != *tt.want {
+				t.Errorf("overallScore = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAssessmentConfidence(t *testing.T) {
+	tests := []struct {
+		name       string
+		confidence float64
+		valid      bool
+	}{
+		{name: "zero", confidence: 0, valid: false},
+		{name: "rounds to zero", confidence: 0.004, valid: false},
+		{name: "minimum", confidence: 0.01, valid: true},
+		{name: "default coach", confidence: defaultCoachConfidence, valid: true},
+		{name: "one", confidence: 1, valid: true},
+		{name: "above one", confidence: 1.01, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := &model.SkillAssessment{
+				ProfileID:  "9b2f8d6e-1c4a-4e57-8f3b-2a6d9c0e7f41",
+				AssessorID: "worker-1",
+				Confidence: tt.confidence,
+				Scores:     map[string]float64{"passing": 7},
+				AssessedAt: time.Now(),
+			}
+			err := validateAssessment(assessment)
+			if tt.valid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidAssessment) {
+				t.Fatalf("err = %v, want %v", err, ErrInvalidAssessment)
+			}
+		})
+	}
+}
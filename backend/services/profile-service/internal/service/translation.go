@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/scouttalent/profile-service/internal/i18n"
+	"github.com/scouttalent/profile-service/internal/model"
+	"github.com/scouttalent/profile-service/internal/repository"
+)
+
+var (
+	ErrInvalidLocale      = errors.New("unsupported locale")
+	ErrInvalidTranslation = errors.New("invalid translation")
+)
+
+// ListTranslations returns the profile's text in every non-primary locale.
+// Only the owner and admins may read it.
+func (s *ProfileService) ListTranslations(ctx context.Context, viewer model.Viewer, profileID string) ([]*model.ProfileTranslation, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEditProfile(viewer, profile) {
+		return nil, ErrForbidden
+	}
+	return s.repo.ListTranslations(ctx, profileID)
+}
+
+// UpsertTranslation sets the profile's display name and bio in locale.
+// Omitted fields keep their stored translation. The primary language is
+// edited on the profile itself.
+func (s *ProfileService) UpsertTranslation(ctx context.Context, viewer model.Viewer, profileID, locale string, req model.UpsertTranslationRequest) (*model.ProfileTranslation, error) {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEditProfile(viewer, profile) {
+		return nil, ErrForbidden
+	}
+
+	locale, err = translationLocale(profile, locale)
+	if err != nil {
+		return nil, err
+	}
+	if req.DisplayName == nil && req.Bio == nil {
+		return nil, fmt.Errorf("%w: display_name or bio is required", ErrInvalidTranslation)
+	}
+
+	existing, err := s.findTranslation(ctx, profileID, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	translation := &model.ProfileTranslation{
+		ProfileID:   profileID,
+		Locale:      locale,
+		DisplayName: req.DisplayName,
+		Bio:         req.Bio,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if existing != nil {
+		if translation.DisplayName == nil {
+			translation.DisplayName = existing.DisplayName
+		}
+		if translation.Bio == nil {
+			translation.Bio = existing.Bio
+		}
+	}
+	translation.DetectedLanguage = detectLanguage(translation.Bio)
+
+	changes, err := diffFields(translationAuditFields(existing, locale), translationAuditFields(translation, locale))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.UpsertTranslation(ctx, translation); err != nil {
+			return err
+		}
+		if err := recordChange(ctx, repo, profileID, viewer, model.ChangeActionTranslationUpdated, changes); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, repo, model.EventProfileUpdated, profileID, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+func (s *ProfileService) DeleteTranslation(ctx context.Context, viewer model.Viewer, profileID, locale string) error {
+	profile, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return err
+	}
+	if !canEditProfile(viewer, profile) {
+		return ErrForbidden
+	}
+
+	locale, err = translationLocale(profile, locale)
+	if err != nil {
+		return err
+	}
+	existing, err := s.findTranslation(ctx, profileID, locale)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return repository.ErrTranslationNotFound
+	}
+
+	changes, err := diffFields(translationAuditFields(existing, locale), translationAuditFields(nil, locale))
+	if err != nil {
+		return err
+	}
+
+	return s.repo.InTx(ctx, func(repo *repository.ProfileRepository) error {
+		if err := repo.DeleteTranslation(ctx, profileID, locale); err != nil {
+			return err
+		}
+		if err := recordChange(ctx, repo, profileID, viewer, model.ChangeActionTranslationDeleted, changes); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, repo, model.EventProfileUpdated, profileID, profile)
+	})
+}
+
+func (s *ProfileService) findTranslation(ctx context.Context, profileID, locale string) (*model.ProfileTranslation, error) {
+	translations, err := s.repo.ListTranslations(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range translations {
+		if t.Locale == locale {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// translationLocale normalizes a locale path parameter and rejects the
+// profile's primary language.
+func translationLocale(profile *model.Profile, locale string) (string, error) {
+	code, ok := i18n.Normalize(locale)
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrInvalidLocale, locale)
+	}
+	if code == profile.PrimaryLanguage {
+		return "", fmt.Errorf("%w: %s is the primary language, edit the profile instead", ErrInvalidLocale, code)
+	}
+	return code, nil
+}
+
+// setPrimaryLanguage applies a requested primary language, if any.
+func setPrimaryLanguage(profile *model.Profile, requested *string) error {
+	if requested == nil {
+		return nil
+	}
+	code, ok := i18n.Normalize(*requested)
+	if !ok {
+		return fmt.Errorf("%w %q", ErrInvalidLocale, *requested)
+	}
+	profile.PrimaryLanguage = code
+	return nil
+}
+
+// detectLanguage runs language detection on a bio. It returns nil when
+// there is no bio or the language cannot be told.
+func detectLanguage(bio *string) *string {
+	if bio == nil {
+		return nil
+	}
+	locale, ok := i18n.Detect(*bio)
+	if !ok {
+		return nil
+	}
+	return &locale
+}
+
+// localizeProfile swaps in the display text of the viewer's preferred
+// locale, when the profile has it, and records which locale was served.
+func (s *ProfileService) localizeProfile(ctx context.Context, viewer model.Viewer, profile *model.Profile) error {
+	translations, err := s.repo.ListTranslations(ctx, profile.ID)
+	if err != nil {
+		return err
+	}
+
+	available := []string{profile.PrimaryLanguage}
+	for _, t := range translations {
+		if t.Locale != profile.PrimaryLanguage {
+			available = append(available, t.Locale)
+		}
+	}
+
+	profile.Locale = i18n.Negotiate(viewer.Languages, available, profile.PrimaryLanguage)
+	profile.AvailableLocales = available
+	for _, t := range translations {
+		if t.Locale != profile.Locale || t.Locale == profile.PrimaryLanguage {
+			continue
+		}
+		if t.DisplayName != nil {
+			profile.DisplayName = *t.DisplayName
+		}
+		if t.Bio != nil {
+			profile.Bio = t.Bio
+		}
+	}
+	return nil
+}
+
+func translationAuditFields(t *model.ProfileTranslation, locale string) map[string]any {
+	fields := map[string]any{
+		"display_name." + locale: nil,
+		"bio." + locale:          nil,
+	}
+	if t != nil {
+		fields["display_name."+locale] = t.DisplayName
+		fields["bio."+locale] = t.Bio
+	}
+	return fields
+}
@@ -0,0 +1,61 @@
+-- Career entries: one row per club spell in a player's career
+CREATE TABLE IF NOT EXISTS career_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+
+    club_name VARCHAR(200) NOT NULL,
+    -- Optional link to the club's academy profile on the platform
+    club_profile_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    country VARCHAR(100),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    is_current BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Club confirmation (set by the linked academy)
+    confirmed_at TIMESTAMP,
+    confirmed_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_career_dates CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_career_entries_profile ON career_entries(profile_id, start_date DESC);
+CREATE INDEX idx_career_entries_club_profile ON career_entries(club_profile_id);
+
+-- Per-season, per-competition statistics for a career entry
+CREATE TABLE IF NOT EXISTS season_stats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    career_entry_id UUID NOT NULL REFERENCES career_entries(id) ON DELETE CASCADE,
+    season VARCHAR(9) NOT NULL,
+    competition VARCHAR(200) NOT NULL,
+
+    -- Common stats
+    appearances INTEGER NOT NULL DEFAULT 0,
+    minutes_played INTEGER NOT NULL DEFAULT 0,
+    goals INTEGER NOT NULL DEFAULT 0,
+    assists INTEGER NOT NULL DEFAULT 0,
+    yellow_cards INTEGER NOT NULL DEFAULT 0,
+    red_cards INTEGER NOT NULL DEFAULT 0,
+
+    -- Goalkeeper / defensive stats
+    clean_sheets INTEGER,
+    goals_conceded INTEGER,
+    saves INTEGER,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_season_competition UNIQUE (career_entry_id, season, competition),
+    CONSTRAINT valid_season_format CHECK (season ~ '^[0-9]{4}(/[0-9]{4})?$'),
+    CONSTRAINT non_negative_stats CHECK (
+        appearances >= 0 AND minutes_played >= 0 AND goals >= 0 AND assists >= 0
+        AND yellow_cards >= 0 AND red_cards >= 0
+        AND COALESCE(clean_sheets, 0) >= 0
+        AND COALESCE(goals_conceded, 0) >= 0
+        AND COALESCE(saves, 0) >= 0
+    )
+);
+
+CREATE INDEX idx_season_stats_entry ON season_stats(career_entry_id);
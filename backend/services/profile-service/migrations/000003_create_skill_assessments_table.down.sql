@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS skill_assessment_scores;
+DROP TABLE IF EXISTS skill_assessments;
+
+DROP TYPE IF EXISTS assessment_source;
@@ -0,0 +1,33 @@
+-- Assessment source enum
+CREATE TYPE assessment_source AS ENUM ('ai', 'coach');
+
+-- One row per submitted assessment (AI scoring run or coach evaluation)
+CREATE TABLE IF NOT EXISTS skill_assessments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    source assessment_source NOT NULL,
+    -- Worker identifier for AI assessments, coach profile ID for coach assessments
+    assessor_id VARCHAR(100) NOT NULL,
+    video_id UUID,
+    model_version VARCHAR(50),
+    confidence DECIMAL(3,2) NOT NULL,
+    notes TEXT,
+    assessed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_confidence CHECK (confidence > 0 AND confidence <= 1)
+);
+
+CREATE INDEX idx_skill_assessments_profile ON skill_assessments(profile_id, assessed_at DESC);
+
+-- Individual skill scores, keyed by category or "category.sub_skill"
+CREATE TABLE IF NOT EXISTS skill_assessment_scores (
+    assessment_id UUID NOT NULL REFERENCES skill_assessments(id) ON DELETE CASCADE,
+    skill VARCHAR(50) NOT NULL,
+    score DECIMAL(3,1) NOT NULL,
+
+    PRIMARY KEY (assessment_id, skill),
+    CONSTRAINT valid_skill_score CHECK (score BETWEEN 0 AND 10)
+);
+
+CREATE INDEX idx_skill_assessment_scores_skill ON skill_assessment_scores(skill);
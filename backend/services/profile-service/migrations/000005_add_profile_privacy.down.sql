@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_profiles_visibility;
+
+ALTER TABLE profiles
+    DROP COLUMN IF EXISTS date_of_birth_visibility,
+    DROP COLUMN IF EXISTS contact_visibility,
+    DROP COLUMN IF EXISTS city_visibility,
+    DROP COLUMN IF EXISTS visibility,
+    DROP COLUMN IF EXISTS contact_phone,
+    DROP COLUMN IF EXISTS contact_email;
+
+DROP TYPE IF EXISTS audience;
@@ -0,0 +1,12 @@
+-- Audience enum shared by profile and field-level visibility
+CREATE TYPE audience AS ENUM ('public', 'scouts_and_academies', 'verified_scouts', 'hidden');
+
+ALTER TABLE profiles
+    ADD COLUMN contact_email VARCHAR(255),
+    ADD COLUMN contact_phone VARCHAR(20),
+    ADD COLUMN visibility audience NOT NULL DEFAULT 'public',
+    ADD COLUMN city_visibility audience NOT NULL DEFAULT 'scouts_and_academies',
+    ADD COLUMN contact_visibility audience NOT NULL DEFAULT 'verified_scouts',
+    ADD COLUMN date_of_birth_visibility audience NOT NULL DEFAULT 'verified_scouts';
+
+CREATE INDEX idx_profiles_visibility ON profiles(visibility);
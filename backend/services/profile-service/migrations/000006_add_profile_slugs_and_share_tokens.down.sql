@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS profile_share_tokens;
+DROP TABLE IF EXISTS profile_slug_history;
+
+DROP INDEX IF EXISTS idx_profiles_slug;
+ALTER TABLE profiles DROP COLUMN IF EXISTS slug;
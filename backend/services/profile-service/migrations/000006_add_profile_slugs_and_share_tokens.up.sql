@@ -0,0 +1,33 @@
+-- Human-readable slug used in public profile URLs
+ALTER TABLE profiles ADD COLUMN slug VARCHAR(60);
+
+-- Backfill existing profiles from display_name with an ID suffix for uniqueness
+UPDATE profiles
+SET slug = COALESCE(NULLIF(trim(both '-' from left(regexp_replace(lower(display_name), '[^a-z0-9]+', '-', 'g'), 50)), ''), 'profile')
+           || '-' || left(id::text, 8);
+
+ALTER TABLE profiles ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX idx_profiles_slug ON profiles(slug);
+
+-- Previous slugs keep redirecting to their profile after a rename
+CREATE TABLE IF NOT EXISTS profile_slug_history (
+    slug VARCHAR(60) PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_slug_history_profile ON profile_slug_history(profile_id);
+
+-- Expiring share tokens granting read access to non-public profiles.
+-- Only a SHA-256 hash of the token is stored.
+CREATE TABLE IF NOT EXISTS profile_share_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    label VARCHAR(100),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_share_tokens_profile ON profile_share_tokens(profile_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS profile_mutes;
+DROP TABLE IF EXISTS profile_blocks;
+DROP TABLE IF EXISTS profile_follows;
+
+ALTER TABLE profiles
+    DROP COLUMN IF EXISTS following_count,
+    DROP COLUMN IF EXISTS follower_count;
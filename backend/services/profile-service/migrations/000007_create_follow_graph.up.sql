@@ -0,0 +1,42 @@
+-- Denormalised counters, maintained in the same transaction as the edges
+ALTER TABLE profiles
+    ADD COLUMN follower_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN following_count INTEGER NOT NULL DEFAULT 0;
+
+-- Follow edges. The primary key serves "who does X follow" and the
+-- secondary index serves "who follows X"; both are ordered for keyset
+-- pagination on (created_at, other side).
+CREATE TABLE IF NOT EXISTS profile_follows (
+    follower_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (follower_id, followee_id),
+    CONSTRAINT no_self_follow CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX idx_profile_follows_following ON profile_follows(follower_id, created_at DESC, followee_id DESC);
+CREATE INDEX idx_profile_follows_followers ON profile_follows(followee_id, created_at DESC, follower_id DESC);
+
+-- A block removes follows in both directions and prevents new ones
+CREATE TABLE IF NOT EXISTS profile_blocks (
+    blocker_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT no_self_block CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_profile_blocks_blocked ON profile_blocks(blocked_id);
+
+-- A mute keeps the follow but hides the muted profile's activity from the
+-- muter's feed and notifications
+CREATE TABLE IF NOT EXISTS profile_mutes (
+    muter_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    muted_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (muter_id, muted_id),
+    CONSTRAINT no_self_mute CHECK (muter_id <> muted_id)
+);
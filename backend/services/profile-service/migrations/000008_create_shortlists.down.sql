@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS shortlist_entries;
+DROP TABLE IF EXISTS shortlists;
+DROP TYPE IF EXISTS shortlist_status;
+DROP TABLE IF EXISTS academy_staff;
@@ -0,0 +1,47 @@
+-- Scouts attached to an academy; staff share the academy's shortlists
+CREATE TABLE IF NOT EXISTS academy_staff (
+    academy_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    member_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (academy_profile_id, member_profile_id)
+);
+
+CREATE INDEX idx_academy_staff_member ON academy_staff(member_profile_id);
+
+CREATE TYPE shortlist_status AS ENUM ('watching', 'contacted', 'trial_invited', 'signed', 'rejected');
+
+-- Shortlists are owned by a scout or academy profile. When academy_profile_id
+-- is set the list is shared with that academy and all of its staff.
+CREATE TABLE IF NOT EXISTS shortlists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    academy_profile_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_shortlists_owner ON shortlists(owner_profile_id);
+CREATE INDEX idx_shortlists_academy ON shortlists(academy_profile_id) WHERE academy_profile_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS shortlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shortlist_id UUID NOT NULL REFERENCES shortlists(id) ON DELETE CASCADE,
+    player_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    sort_order INTEGER NOT NULL,
+    status shortlist_status NOT NULL DEFAULT 'watching',
+    notes TEXT,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    added_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_shortlist_player UNIQUE (shortlist_id, player_profile_id)
+);
+
+CREATE INDEX idx_shortlist_entries_order ON shortlist_entries(shortlist_id, sort_order);
+CREATE INDEX idx_shortlist_entries_player ON shortlist_entries(player_profile_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS scouting_report_versions;
+DROP TABLE IF EXISTS scouting_reports;
+DROP TYPE IF EXISTS report_visibility;
+DROP TABLE IF EXISTS report_templates;
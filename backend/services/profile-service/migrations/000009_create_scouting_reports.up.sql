@@ -0,0 +1,61 @@
+-- Per-academy report templates. Reports without a template use the built-in
+-- default defined in the service.
+CREATE TABLE IF NOT EXISTS report_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    academy_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    scale_max INTEGER NOT NULL DEFAULT 10,
+    -- [{"key": "first_touch", "label": "First touch", "description": "..."}]
+    attributes JSONB NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_scale_max CHECK (scale_max BETWEEN 3 AND 100)
+);
+
+CREATE INDEX idx_report_templates_academy ON report_templates(academy_profile_id);
+
+CREATE TYPE report_visibility AS ENUM ('private', 'org');
+
+-- Report header. Content lives in scouting_report_versions; every edit adds
+-- a version and bumps current_version.
+CREATE TABLE IF NOT EXISTS scouting_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    author_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    -- Organisation the report is shared with when visibility = 'org'
+    academy_profile_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    template_id UUID REFERENCES report_templates(id) ON DELETE SET NULL,
+    visibility report_visibility NOT NULL DEFAULT 'private',
+    current_version INTEGER NOT NULL DEFAULT 1,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT org_reports_have_academy CHECK (visibility = 'private' OR academy_profile_id IS NOT NULL)
+);
+
+CREATE INDEX idx_scouting_reports_player ON scouting_reports(player_profile_id, updated_at DESC);
+CREATE INDEX idx_scouting_reports_author ON scouting_reports(author_profile_id);
+CREATE INDEX idx_scouting_reports_academy ON scouting_reports(academy_profile_id) WHERE academy_profile_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS scouting_report_versions (
+    report_id UUID NOT NULL REFERENCES scouting_reports(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+
+    -- {"first_touch": 7, ...} on the template's scale at the time of writing
+    ratings JSONB NOT NULL DEFAULT '{}',
+    scale_max INTEGER NOT NULL,
+    strengths TEXT,
+    weaknesses TEXT,
+    projected_level VARCHAR(30),
+    recommendation VARCHAR(30),
+    -- [{"video_id": "...", "timestamp_seconds": 93, "note": "..."}]
+    video_refs JSONB NOT NULL DEFAULT '[]',
+
+    edited_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (report_id, version)
+);
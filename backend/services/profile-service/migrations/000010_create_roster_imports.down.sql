@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS roster_import_rows;
+DROP TABLE IF EXISTS roster_imports;
+DROP TYPE IF EXISTS roster_row_status;
+DROP TYPE IF EXISTS roster_import_status;
@@ -0,0 +1,43 @@
+CREATE TYPE roster_import_status AS ENUM ('pending', 'running', 'paused', 'completed', 'cancelled');
+CREATE TYPE roster_row_status AS ENUM ('pending', 'valid', 'invalid', 'imported', 'skipped');
+
+-- Bulk player imports uploaded by an academy. Rows are processed in batches
+-- by a background worker; locked_until is the worker's lease so an import
+-- interrupted by a restart is picked up again once the lease expires.
+CREATE TABLE IF NOT EXISTS roster_imports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    academy_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    mapping JSONB NOT NULL DEFAULT '{}',
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    status roster_import_status NOT NULL DEFAULT 'pending',
+    last_error TEXT,
+    locked_until TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_roster_imports_academy ON roster_imports(academy_profile_id, created_at DESC);
+CREATE INDEX idx_roster_imports_queue ON roster_imports(created_at) WHERE status IN ('pending', 'running');
+
+-- One row per data row in the upload, keyed by its row number in the file.
+-- data holds the mapped field values as strings, exactly as uploaded.
+CREATE TABLE IF NOT EXISTS roster_import_rows (
+    import_id UUID NOT NULL REFERENCES roster_imports(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    data JSONB NOT NULL,
+    status roster_row_status NOT NULL DEFAULT 'pending',
+    errors TEXT[] NOT NULL DEFAULT '{}',
+    user_id UUID,
+    profile_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    processed_at TIMESTAMP,
+
+    PRIMARY KEY (import_id, row_number)
+);
+
+CREATE INDEX idx_roster_import_rows_status ON roster_import_rows(import_id, status);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_profiles_coordinates;
+DROP INDEX IF EXISTS idx_profiles_country_code;
+
+ALTER TABLE profiles
+    DROP CONSTRAINT IF EXISTS valid_location_coordinates,
+    DROP COLUMN IF EXISTS location_lng,
+    DROP COLUMN IF EXISTS location_lat,
+    DROP COLUMN IF EXISTS location_city_id,
+    DROP COLUMN IF EXISTS location_country_code;
@@ -0,0 +1,18 @@
+-- Gazetteer-normalized location. location_country and location_city keep the
+-- display names; city ID and coordinates are only set for recognised cities.
+ALTER TABLE profiles
+    ADD COLUMN location_country_code CHAR(2),
+    ADD COLUMN location_city_id VARCHAR(64),
+    ADD COLUMN location_lat DOUBLE PRECISION,
+    ADD COLUMN location_lng DOUBLE PRECISION,
+    ADD CONSTRAINT valid_location_coordinates CHECK (
+        (location_lat IS NULL) = (location_lng IS NULL)
+        AND COALESCE(location_lat, 0) BETWEEN -90 AND 90
+        AND COALESCE(location_lng, 0) BETWEEN -180 AND 180
+    );
+
+CREATE INDEX idx_profiles_country_code ON profiles(location_country_code);
+
+-- Radius searches prefilter on a bounding box before the distance check
+CREATE INDEX idx_profiles_coordinates ON profiles(location_lat, location_lng)
+    WHERE location_lat IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS profile_changes;
+
+ALTER TABLE player_details
+    DROP COLUMN IF EXISTS verified_by,
+    DROP COLUMN IF EXISTS verified_at;
@@ -0,0 +1,20 @@
+-- Player details checked by an admin. Editing a verified field clears these.
+ALTER TABLE player_details
+    ADD COLUMN verified_at TIMESTAMP,
+    ADD COLUMN verified_by UUID;
+
+-- Change log: one row per edit, with the field-level diffs it made
+CREATE TABLE IF NOT EXISTS profile_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    -- NULL for changes made by the system (imports, scoring)
+    actor_user_id UUID,
+    actor_role VARCHAR(20) NOT NULL,
+    -- [{"field": "...", "old": ..., "new": ...}]
+    changes JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_changes_profile ON profile_changes(profile_id, created_at DESC, id DESC);
+CREATE INDEX idx_profile_changes_fields ON profile_changes USING gin(changes jsonb_path_ops);
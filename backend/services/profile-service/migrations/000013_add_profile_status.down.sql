@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_profiles_purge_after;
+DROP INDEX IF EXISTS idx_profiles_status;
+
+ALTER TABLE profiles
+    DROP COLUMN IF EXISTS purge_after,
+    DROP COLUMN IF EXISTS deactivation_reason,
+    DROP COLUMN IF EXISTS deactivated_by,
+    DROP COLUMN IF EXISTS deactivated_at,
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS profile_status;
@@ -0,0 +1,14 @@
+-- Profile lifecycle. Deactivated profiles are hidden and hard-deleted once
+-- purge_after passes unless the owner restores them; taken down profiles are
+-- hidden until an admin reinstates them.
+CREATE TYPE profile_status AS ENUM ('active', 'deactivated', 'taken_down');
+
+ALTER TABLE profiles
+    ADD COLUMN status profile_status NOT NULL DEFAULT 'active',
+    ADD COLUMN deactivated_at TIMESTAMP,
+    ADD COLUMN deactivated_by UUID,
+    ADD COLUMN deactivation_reason TEXT,
+    ADD COLUMN purge_after TIMESTAMP;
+
+CREATE INDEX idx_profiles_status ON profiles(status);
+CREATE INDEX idx_profiles_purge_after ON profiles(purge_after) WHERE status = 'deactivated';
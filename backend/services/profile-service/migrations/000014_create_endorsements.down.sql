@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS endorsements;
+DROP TYPE IF EXISTS endorsement_status;
@@ -0,0 +1,27 @@
+CREATE TYPE endorsement_status AS ENUM ('pending', 'accepted', 'hidden');
+
+-- Endorsements of a player by a verified scout, coach or academy. There is
+-- at most one row per endorser, player and skill; revoking keeps the row so
+-- a later endorsement of the same skill reuses it.
+CREATE TABLE IF NOT EXISTS endorsements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    endorser_profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    -- A skill taxonomy key ("passing.vision") or a character trait
+    skill VARCHAR(64) NOT NULL,
+    comment TEXT,
+    -- The endorser's trust level when the endorsement was made
+    endorser_trust_level trust_level NOT NULL,
+    status endorsement_status NOT NULL DEFAULT 'pending',
+    responded_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT endorsements_unique UNIQUE (endorser_profile_id, player_profile_id, skill),
+    CONSTRAINT no_self_endorsement CHECK (endorser_profile_id <> player_profile_id)
+);
+
+CREATE INDEX idx_endorsements_player ON endorsements(player_profile_id, created_at DESC)
+    WHERE revoked_at IS NULL;
@@ -0,0 +1,13 @@
+-- Facts owned by other services that count towards profile completion,
+-- recorded from their events. Signals are keyed by user because account
+-- verification can happen before the profile exists. ref_id distinguishes
+-- repeated signals such as one row per uploaded video, which keeps
+-- redelivered events idempotent.
+CREATE TABLE IF NOT EXISTS completion_signals (
+    user_id UUID NOT NULL,
+    signal VARCHAR(50) NOT NULL,
+    ref_id VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, signal, ref_id)
+);
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS profile_translations;
+
+DROP INDEX IF EXISTS idx_profiles_search;
+
+ALTER TABLE profiles
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS bio_language,
+    DROP COLUMN IF EXISTS primary_language;
+
+CREATE INDEX idx_profiles_search ON profiles
+    USING gin(to_tsvector('english', display_name || ' ' || COALESCE(bio, '')));
+
+DROP FUNCTION IF EXISTS profile_text_search_config(TEXT);
@@ -0,0 +1,45 @@
+-- Text search configuration for a content locale. Locales without a
+-- Postgres stemmer use the simple configuration. Keep in step with
+-- i18n.SearchConfig.
+CREATE OR REPLACE FUNCTION profile_text_search_config(locale TEXT) RETURNS regconfig AS $$
+    SELECT CASE locale
+        WHEN 'en' THEN 'english'::regconfig
+        WHEN 'fr' THEN 'french'::regconfig
+        WHEN 'es' THEN 'spanish'::regconfig
+        WHEN 'pt' THEN 'portuguese'::regconfig
+        ELSE 'simple'::regconfig
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- primary_language is the language of display_name and bio on the profile
+-- row; bio_language is what detection made of the bio when it was saved.
+ALTER TABLE profiles
+    ADD COLUMN primary_language VARCHAR(8) NOT NULL DEFAULT 'en',
+    ADD COLUMN bio_language VARCHAR(8),
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector(profile_text_search_config(primary_language), display_name || ' ' || COALESCE(bio, ''))
+    ) STORED;
+
+DROP INDEX IF EXISTS idx_profiles_search;
+CREATE INDEX idx_profiles_search ON profiles USING gin(search_vector);
+
+-- Display text in languages other than the primary one. A NULL field falls
+-- back to the profile row.
+CREATE TABLE IF NOT EXISTS profile_translations (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    locale VARCHAR(8) NOT NULL,
+    display_name VARCHAR(100),
+    bio TEXT,
+    detected_language VARCHAR(8),
+    search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector(profile_text_search_config(locale), COALESCE(display_name, '') || ' ' || COALESCE(bio, ''))
+    ) STORED,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (profile_id, locale)
+);
+
+CREATE INDEX idx_profile_translations_search ON profile_translations USING gin(search_vector);
+CREATE INDEX idx_profile_translations_locale ON profile_translations(locale);
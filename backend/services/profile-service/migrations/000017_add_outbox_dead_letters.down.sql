@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_outbox_events_failed_at;
+DROP INDEX IF EXISTS idx_outbox_events_unpublished;
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS failed_at;
+
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(id) WHERE published_at IS NULL;
@@ -0,0 +1,10 @@
+-- Events that keep failing to publish are set aside after a retry limit so
+-- the events behind them still go out. They stay for inspection and are
+-- re-queued by clearing failed_at.
+ALTER TABLE outbox_events ADD COLUMN failed_at TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_outbox_events_unpublished;
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(id)
+    WHERE published_at IS NULL AND failed_at IS NULL;
+CREATE INDEX idx_outbox_events_failed_at ON outbox_events(failed_at)
+    WHERE failed_at IS NOT NULL;
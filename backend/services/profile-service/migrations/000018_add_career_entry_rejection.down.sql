@@ -0,0 +1,2 @@
+ALTER TABLE career_entries DROP COLUMN IF EXISTS rejected_by;
+ALTER TABLE career_entries DROP COLUMN IF EXISTS rejected_at;
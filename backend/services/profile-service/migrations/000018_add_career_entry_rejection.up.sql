@@ -0,0 +1,4 @@
+-- The linked academy can reject an entry instead of confirming it. A player
+-- edit clears both, so the entry goes back to the club for review.
+ALTER TABLE career_entries ADD COLUMN rejected_at TIMESTAMP;
+ALTER TABLE career_entries ADD COLUMN rejected_by UUID REFERENCES profiles(id) ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+DELETE FROM profile_changes c WHERE NOT EXISTS (SELECT 1 FROM profiles p WHERE p.id = c.profile_id);
+ALTER TABLE profile_changes DROP COLUMN IF EXISTS anonymized_at;
+ALTER TABLE profile_changes
+    ADD CONSTRAINT profile_changes_profile_id_fkey
+    FOREIGN KEY (profile_id) REFERENCES profiles(id) ON DELETE CASCADE;
@@ -0,0 +1,4 @@
+-- The change log outlives the profile: a purge anonymizes its entries
+-- instead of cascading them away, so takedowns stay on record.
+ALTER TABLE profile_changes DROP CONSTRAINT IF EXISTS profile_changes_profile_id_fkey;
+ALTER TABLE profile_changes ADD COLUMN anonymized_at TIMESTAMP;
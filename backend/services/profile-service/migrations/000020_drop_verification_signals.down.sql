@@ -0,0 +1,2 @@
+-- The deleted signals cannot be restored; auth-service never published them
+SELECT 1;
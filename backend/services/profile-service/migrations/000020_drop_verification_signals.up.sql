@@ -0,0 +1,2 @@
+-- Email and phone verification no longer count towards profile completion
+DELETE FROM completion_signals WHERE signal IN ('email_verified', 'phone_verified');